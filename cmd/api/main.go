@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,9 +16,11 @@ import (
 	"beerdosan-backend/internal/pkg/jwt"
 	"beerdosan-backend/internal/pkg/logger"
 	"beerdosan-backend/internal/pkg/password"
+	"beerdosan-backend/internal/pkg/scheduler"
 
 	"beerdosan-backend/internal/app/api"
 	v1 "beerdosan-backend/internal/app/api/v1"
+	"beerdosan-backend/internal/app/jobs"
 	"beerdosan-backend/internal/app/repositories"
 	"beerdosan-backend/internal/app/service"
 	"beerdosan-backend/internal/app/usecase"
@@ -53,25 +56,30 @@ func main() {
 
 	txManager := database.NewTransactionManager(db)
 
-	passwordService := password.NewPasswordService(password.DefaultPasswordConfig())
-
-	jwtCfg, err := jwt.DefaultJWTConfig()
+	passwordCfg, err := appCfg.Password.ToPasswordConfig()
 	if err != nil {
-		log.Fatal("Failed to create default JWT config:", err)
+		log.Fatal("Invalid password configuration:", err)
 	}
-	if appCfg.JWT != nil {
-		if appCfg.JWT.AccessTokenDuration > 0 {
-			jwtCfg.AccessTokenDuration = appCfg.JWT.AccessTokenDuration
-		}
-		if appCfg.JWT.RefreshTokenDuration > 0 {
-			jwtCfg.RefreshTokenDuration = appCfg.JWT.RefreshTokenDuration
-		}
-		if appCfg.JWT.Issuer != "" {
-			jwtCfg.Issuer = appCfg.JWT.Issuer
+	if path := appCfg.Password.BreachedPasswordsPath; path != "" {
+		breaches, err := password.OpenBreachSource(path)
+		if err != nil {
+			log.Fatal("Failed to load breached passwords:", err)
 		}
-		if appCfg.JWT.Audience != "" {
-			jwtCfg.Audience = appCfg.JWT.Audience
+		defer breaches.Close()
+
+		// A filter only knows that a hash was seen at least MinCount times.
+		if filter, ok := breaches.(*password.BloomFilter); ok && filter.MinCount() < passwordCfg.BreachThreshold {
+			log.Fatalf("Breached password filter was built with min count %d, below breach_threshold %d", filter.MinCount(), passwordCfg.BreachThreshold)
 		}
+		passwordCfg.Breaches = breaches
+	}
+	// The domain model hashes through the default hasher.
+	password.SetDefaultHasher(password.NewHasher(passwordCfg))
+	passwordService := password.NewPasswordService(passwordCfg)
+
+	jwtCfg, err := appCfg.JWT.ToJWTConfig()
+	if err != nil {
+		log.Fatal("Failed to create JWT config:", err)
 	}
 
 	jwtService := jwt.NewJWTService(jwtCfg)
@@ -79,6 +87,37 @@ func main() {
 	userRepo := repositories.NewUserRepository(db)
 	sessionRepo := repositories.NewSessionRepository(db)
 	loginAttemptRepo := repositories.NewLoginAttemptRepository(db)
+	passwordHistoryRepo := repositories.NewPasswordHistoryRepository(db)
+	magicLinkRepo := repositories.NewMagicLinkRepository(db)
+	otpRepo := repositories.NewOTPRepository(db)
+	emailChangeRepo := repositories.NewEmailChangeRepository(db)
+	invitationRepo := repositories.NewInvitationRepository(db)
+
+	sessionLimits, err := appCfg.Session.ToSessionLimitConfig()
+	if err != nil {
+		log.Fatal("Invalid session configuration:", err)
+	}
+
+	passwordExpiry, err := appCfg.Password.ToPasswordExpiryConfig()
+	if err != nil {
+		log.Fatal("Invalid password configuration:", err)
+	}
+
+	var (
+		cacheNotifier  *database.Notifier
+		cachePublisher service.InvalidationPublisher
+	)
+	if appCfg.AuthCache.Enabled {
+		cacheNotifier = database.NewNotifier(db, appCfg.AuthCache.Channel())
+		cachePublisher = cacheNotifier
+	}
+	authCache := service.NewAuthCache(appCfg.AuthCache.ToAuthCacheConfig(), cachePublisher)
+
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	if cacheNotifier != nil {
+		go cacheNotifier.Listen(listenCtx, authCache.Purge, authCache.HandleNotification)
+	}
 
 	serviceRegistry := service.NewServiceRegistry(
 		userRepo,
@@ -86,17 +125,71 @@ func main() {
 		loginAttemptRepo,
 		jwtService,
 		passwordService,
+		txManager,
+		sessionLimits,
+		passwordExpiry,
+		authCache,
 	)
 
+	mail, err := appCfg.Mailer.ToMailer()
+	if err != nil {
+		log.Fatal("Invalid mailer configuration:", err)
+	}
+
+	otpSenders, err := appCfg.OTP.ToOTPSenders(mail)
+	if err != nil {
+		log.Fatal("Invalid OTP configuration:", err)
+	}
+
 	authUseCase := usecase.NewAuthUseCase(
 		serviceRegistry.AuthService(),
 		serviceRegistry.JWTService(),
 		serviceRegistry.PasswordService(),
 		userRepo,
 		sessionRepo,
+		loginAttemptRepo,
+		passwordHistoryRepo,
+		magicLinkRepo,
+		mail,
+		appCfg.MagicLink.ToMagicLinkConfig(),
+		otpRepo,
+		otpSenders,
+		appCfg.OTP.ToOTPConfig(),
+		appCfg.Account.ToAccountDeletionConfig(),
+		emailChangeRepo,
+		appCfg.EmailChange.ToEmailChangeConfig(),
+		invitationRepo,
+		appCfg.Invitation.ToInvitationConfig(),
 		txManager,
 	)
 
+	jobScheduler := scheduler.New(database.NewAdvisoryLocker(db))
+	if appCfg.Scheduler.Enabled {
+		sessionRetentionJob := jobs.NewSessionRetentionJob(sessionRepo, txManager, appCfg.Scheduler.SessionRetention.ToRetentionConfig())
+		if err := jobScheduler.Register(sessionRetentionJob, sessionRetentionJob.Interval()); err != nil {
+			log.Fatal("Failed to register session retention job:", err)
+		}
+
+		loginAttemptRetentionJob := jobs.NewLoginAttemptRetentionJob(loginAttemptRepo, txManager, appCfg.Scheduler.LoginAttemptRetention.ToRetentionConfig())
+		if err := jobScheduler.Register(loginAttemptRetentionJob, loginAttemptRetentionJob.Interval()); err != nil {
+			log.Fatal("Failed to register login attempt retention job:", err)
+		}
+
+		accountPurgeJob := jobs.NewAccountPurgeJob(userRepo, txManager, appCfg.Scheduler.AccountPurge.ToRetentionConfig())
+		if err := jobScheduler.Register(accountPurgeJob, accountPurgeJob.Interval()); err != nil {
+			log.Fatal("Failed to register account purge job:", err)
+		}
+
+		if err := jobScheduler.Start(context.Background()); err != nil {
+			log.Fatal("Failed to start scheduler:", err)
+		}
+	}
+
+	cookieCfg, err := appCfg.Cookie.ToCookieConfig()
+	if err != nil {
+		log.Fatal("Invalid cookie configuration:", err)
+	}
+
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 
@@ -105,7 +198,8 @@ func main() {
 	router.Use(api.RequestID())
 	router.Use(api.CORSMiddleware())
 	router.Use(api.SecurityHeaders())
-	router.Use(api.ValidateJSONMiddleware())
+	router.Use(api.ValidateJSONMiddleware("/oauth/"))
+	router.Use(api.CSRFMiddleware(cookieCfg))
 
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -115,7 +209,14 @@ func main() {
 		})
 	})
 
-	authHandler := v1.NewAuthHandler(authUseCase, serviceRegistry.AuthService())
+	// Metrics reveal the command line and internal state, so only admins see them.
+	router.GET("/debug/vars",
+		api.AuthMiddleware(serviceRegistry.AuthService(), cookieCfg),
+		api.AdminMiddleware(),
+		gin.WrapH(expvar.Handler()),
+	)
+
+	authHandler := v1.NewAuthHandler(authUseCase, serviceRegistry.AuthService(), cookieCfg, appCfg.StepUp.RecentAuthMaxAge())
 
 	routerRegister := api.NewGinRouterRegisterImpl(router)
 
@@ -123,6 +224,11 @@ func main() {
 		log.Fatal("Failed to register auth handler:", err)
 	}
 
+	oauthHandler := v1.NewOAuthHandler(authUseCase, appCfg.OAuth.ToClientCredentials())
+	if err := oauthHandler.Register(routerRegister); err != nil {
+		log.Fatal("Failed to register oauth handler:", err)
+	}
+
 	port := appCfg.Server.Port
 	if port == "" {
 		port = "8080"
@@ -153,6 +259,8 @@ func main() {
 
 	fmt.Println("🛑 Shutting down server...")
 
+	jobScheduler.Stop()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -0,0 +1,72 @@
+// Command breachfilter builds a bloom filter for password.OpenBreachSource
+// from a Have I Been Pwned SHA-1 file, keeping hashes seen at least -min-count
+// times.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"beerdosan-backend/internal/pkg/password"
+)
+
+func main() {
+	in := flag.String("in", "", "HIBP SHA-1 file (SHA1:COUNT per line)")
+	out := flag.String("out", "breached-passwords.bloom", "output filter path")
+	minCount := flag.Int("min-count", 1, "minimum breach count to include a hash")
+	fpRate := flag.Float64("fp-rate", 0.001, "false positive rate")
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// The first pass counts matching hashes to size the filter.
+	n, err := scanFile(*in, func(f *os.File) (uint64, error) {
+		return password.CountHIBP(bufio.NewReader(f), *minCount)
+	})
+	if err != nil {
+		log.Fatal("Failed to count hashes:", err)
+	}
+
+	var filter *password.BloomFilter
+	_, err = scanFile(*in, func(f *os.File) (uint64, error) {
+		filter, err = password.BuildBloomFilter(bufio.NewReader(f), n, *fpRate, *minCount)
+		return 0, err
+	})
+	if err != nil {
+		log.Fatal("Failed to build filter:", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal("Failed to create output:", err)
+	}
+	w := bufio.NewWriter(f)
+	size, err := filter.WriteTo(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		log.Fatal("Failed to write filter:", err)
+	}
+
+	fmt.Printf("Wrote %d hashes to %s (%d bytes)\n", n, *out, size)
+}
+
+func scanFile(path string, fn func(f *os.File) (uint64, error)) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return fn(f)
+}
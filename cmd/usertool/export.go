@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/repositories"
+)
+
+const exportPageSize = 500
+
+// exportFields maps every field an export may include to its value. The
+// password hash is only written when asked for by name.
+var exportFields = map[string]func(*domain.User) string{
+	"id":                    func(u *domain.User) string { return u.ID().String() },
+	"username":              func(u *domain.User) string { return u.Username().String() },
+	"email":                 func(u *domain.User) string { return u.Email().String() },
+	"first_name":            func(u *domain.User) string { return u.FirstName().String() },
+	"last_name":             func(u *domain.User) string { return u.LastName().String() },
+	"role":                  func(u *domain.User) string { return u.Role().String() },
+	"status":                func(u *domain.User) string { return u.Status().String() },
+	"phone_number":          func(u *domain.User) string { return u.PhoneNumber().String() },
+	"phone_number_verified": func(u *domain.User) string { return strconv.FormatBool(u.PhoneNumberVerified()) },
+	"password_hash":         func(u *domain.User) string { return string(u.Password()) },
+	"password_changed_at":   func(u *domain.User) string { return u.PasswordChangedAt().Format(time.RFC3339) },
+	"created_at":            func(u *domain.User) string { return u.CreatedAt().Time().Format(time.RFC3339) },
+	"updated_at":            func(u *domain.User) string { return u.UpdatedAt().Time().Format(time.RFC3339) },
+}
+
+const defaultExportFields = "id,username,email,first_name,last_name,role,status,phone_number,phone_number_verified,created_at,updated_at"
+
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	out := flags.String("out", "", "CSV or JSON file to write")
+	format := flags.String("format", "", "csv or json (default: from the file extension)")
+	fieldList := flags.String("fields", defaultExportFields, "comma separated fields to export")
+	_ = flags.Parse(args)
+
+	if *out == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	fileFormat, err := resolveFormat(*format, *out)
+	if err != nil {
+		return err
+	}
+
+	fields, err := parseExportFields(*fieldList)
+	if err != nil {
+		return err
+	}
+
+	appCfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db, err := openDatabase(appCfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// The export may include password hashes.
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	var writer exportWriter
+	if fileFormat == formatJSON {
+		writer = &jsonExportWriter{w: w, fields: fields}
+	} else {
+		writer = &csvExportWriter{w: csv.NewWriter(w), fields: fields}
+	}
+
+	userRepo := repositories.NewUserRepository(db)
+	count, err := exportUsers(context.Background(), userRepo, writer)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d users to %s\n", count, *out)
+	return nil
+}
+
+func parseExportFields(list string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(list, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if _, ok := exportFields[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields to export")
+	}
+	return fields, nil
+}
+
+// exportUsers pages through every user in id order.
+func exportUsers(ctx context.Context, userRepo repositories.UserRepository, writer exportWriter) (int, error) {
+	if err := writer.begin(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var afterID domain.UserID
+	for {
+		users, err := userRepo.ListAfterID(ctx, afterID, exportPageSize)
+		if err != nil {
+			return count, fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, user := range users {
+			if err := writer.write(user); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(users) < exportPageSize {
+			break
+		}
+		afterID = users[len(users)-1].ID()
+	}
+
+	return count, writer.end()
+}
+
+type exportWriter interface {
+	begin() error
+	write(user *domain.User) error
+	end() error
+}
+
+// csvExportWriter writes a header row followed by one row per user.
+type csvExportWriter struct {
+	w      *csv.Writer
+	fields []string
+}
+
+func (c *csvExportWriter) begin() error {
+	return c.w.Write(c.fields)
+}
+
+func (c *csvExportWriter) write(user *domain.User) error {
+	record := make([]string, len(c.fields))
+	for i, field := range c.fields {
+		record[i] = exportFields[field](user)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvExportWriter) end() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonExportWriter streams an array of objects whose keys keep the order of
+// the selected fields.
+type jsonExportWriter struct {
+	w      io.Writer
+	fields []string
+	count  int
+}
+
+func (j *jsonExportWriter) begin() error {
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonExportWriter) write(user *domain.User) error {
+	var b strings.Builder
+	if j.count > 0 {
+		b.WriteString(",")
+	}
+	b.WriteString("\n  {")
+	for i, field := range j.fields {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, _ := json.Marshal(field)
+		value, err := json.Marshal(exportFields[field](user))
+		if err != nil {
+			return err
+		}
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	j.count++
+
+	_, err := io.WriteString(j.w, b.String())
+	return err
+}
+
+func (j *jsonExportWriter) end() error {
+	_, err := io.WriteString(j.w, "\n]\n")
+	return err
+}
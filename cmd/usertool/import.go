@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/app/service"
+	"beerdosan-backend/internal/pkg/database"
+	"beerdosan-backend/internal/pkg/password"
+)
+
+// importFields are the columns an import file may have. A row needs either a
+// plain password, which is hashed with the configured algorithm, or an
+// existing bcrypt or argon2id password_hash.
+var importFields = []string{"username", "email", "first_name", "last_name", "password", "password_hash", "role", "phone_number"}
+
+var requiredImportFields = []string{"username", "email", "first_name", "last_name"}
+
+type importRow struct {
+	// position is "line N" for CSV and "record N" for JSON.
+	position string
+	values   map[string]string
+}
+
+type rowError struct {
+	position string
+	err      error
+}
+
+type importReport struct {
+	rows   int
+	users  []*domain.User
+	errors []rowError
+}
+
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	in := flags.String("in", "", "CSV or JSON file to import")
+	format := flags.String("format", "", "csv or json (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate every row and report without writing")
+	chunk := flags.Int("chunk", 500, "users inserted per transaction")
+	skipInvalid := flags.Bool("skip-invalid", false, "import the valid rows even if others are invalid")
+	_ = flags.Parse(args)
+
+	if *in == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	fileFormat, err := resolveFormat(*format, *in)
+	if err != nil {
+		return err
+	}
+
+	rows, err := readImportFile(*in, fileFormat)
+	if err != nil {
+		return err
+	}
+
+	appCfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	passwords, closePasswords, err := newPasswordService(appCfg)
+	if err != nil {
+		return err
+	}
+	defer closePasswords()
+
+	db, err := openDatabase(appCfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	userRepo := repositories.NewUserRepository(db)
+	passwordHistory := repositories.NewPasswordHistoryRepository(db)
+
+	report, err := validateRows(ctx, userRepo, passwords, rows)
+	if err != nil {
+		return err
+	}
+	report.print(os.Stdout)
+
+	if *dryRun {
+		fmt.Println("Dry run, nothing was written")
+		return nil
+	}
+	if len(report.errors) > 0 && !*skipInvalid {
+		return fmt.Errorf("%d invalid rows, fix them or pass -skip-invalid", len(report.errors))
+	}
+	if len(report.users) == 0 {
+		return nil
+	}
+
+	txManager := database.NewTransactionManager(db)
+	batch := txManager.NewBatch(*chunk)
+	for _, user := range report.users {
+		batch.Add(func(tx *gorm.DB) error {
+			created, err := userRepo.CreateInTx(tx, user)
+			if err != nil {
+				return err
+			}
+			if passwords.Policy(created.Role()).HistorySize > 0 {
+				return passwordHistory.CreateInTx(tx, created.ID(), created.Password())
+			}
+			return nil
+		})
+	}
+	// Each chunk commits on its own; a failure keeps the chunks before it.
+	imported, err := batch.Execute(ctx, txManager)
+	fmt.Printf("Imported %d users\n", imported)
+	if err != nil {
+		return fmt.Errorf("import stopped, users before the failed chunk were imported: %w", err)
+	}
+	return nil
+}
+
+func readImportFile(path, format string) ([]importRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if format == formatJSON {
+		return readJSONRows(f)
+	}
+	return readCSVRows(f)
+}
+
+// readCSVRows expects a header row naming the columns.
+func readCSVRows(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+	if err := checkColumns(header); err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		values := make(map[string]string, len(header))
+		for i, name := range header {
+			values[name] = record[i]
+		}
+		rows = append(rows, importRow{position: fmt.Sprintf("line %d", line), values: values})
+	}
+}
+
+// readJSONRows expects an array of objects with string values.
+func readJSONRows(r io.Reader) ([]importRow, error) {
+	var records []map[string]string
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	rows := make([]importRow, len(records))
+	for i, record := range records {
+		columns := make([]string, 0, len(record))
+		values := make(map[string]string, len(record))
+		for name, value := range record {
+			name = strings.ToLower(strings.TrimSpace(name))
+			columns = append(columns, name)
+			values[name] = value
+		}
+		if err := checkColumns(columns); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		rows[i] = importRow{position: fmt.Sprintf("record %d", i+1), values: values}
+	}
+
+	return rows, nil
+}
+
+func checkColumns(columns []string) error {
+	for _, column := range columns {
+		known := false
+		for _, field := range importFields {
+			known = known || column == field
+		}
+		if !known {
+			return fmt.Errorf("unknown column %q, expected some of %s", column, strings.Join(importFields, ", "))
+		}
+	}
+	return nil
+}
+
+// validateRows builds a user from every row and checks that no two rows, and
+// no row and existing user, share a username or email address.
+func validateRows(ctx context.Context, userRepo repositories.UserRepository, passwords service.PasswordService, rows []importRow) (*importReport, error) {
+	report := &importReport{rows: len(rows)}
+	usernames := make(map[string]string)
+	emails := make(map[string]string)
+
+	for _, row := range rows {
+		user, err := buildUser(row.values, passwords)
+		if err != nil {
+			report.errors = append(report.errors, rowError{row.position, err})
+			continue
+		}
+
+		username, email := user.Username().String(), user.Email().String()
+		if position, ok := usernames[username]; ok {
+			report.errors = append(report.errors, rowError{row.position, fmt.Errorf("username %q is also on %s", username, position)})
+			continue
+		}
+		if position, ok := emails[email]; ok {
+			report.errors = append(report.errors, rowError{row.position, fmt.Errorf("email %q is also on %s", email, position)})
+			continue
+		}
+		usernames[username] = row.position
+		emails[email] = row.position
+
+		existing, err := userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up username: %w", err)
+		}
+		if existing != nil {
+			report.errors = append(report.errors, rowError{row.position, fmt.Errorf("username %q is already taken", username)})
+			continue
+		}
+		existing, err = userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up email: %w", err)
+		}
+		if existing != nil {
+			report.errors = append(report.errors, rowError{row.position, fmt.Errorf("email %q is already taken", email)})
+			continue
+		}
+
+		report.users = append(report.users, user)
+	}
+
+	return report, nil
+}
+
+// buildUser validates a row through the domain constructors. Plain passwords
+// must also meet the password policy of the row's role.
+func buildUser(values map[string]string, passwords service.PasswordService) (*domain.User, error) {
+	for _, field := range requiredImportFields {
+		if strings.TrimSpace(values[field]) == "" {
+			return nil, fmt.Errorf("%s is required", field)
+		}
+	}
+
+	if _, err := domain.NewEmail(values["email"]); err != nil {
+		return nil, fmt.Errorf("email: %w", err)
+	}
+
+	role := domain.UserRoleUser
+	if value := values["role"]; value != "" {
+		parsed, err := domain.NewUserRole(value)
+		if err != nil {
+			return nil, fmt.Errorf("role: %w", err)
+		}
+		role = parsed
+	}
+
+	var (
+		user *domain.User
+		err  error
+	)
+	plainPassword, hash := values["password"], values["password_hash"]
+	switch {
+	case plainPassword != "" && hash != "":
+		return nil, errors.New("set password or password_hash, not both")
+	case hash != "":
+		if strings.HasPrefix(hash, "$argon2id$") {
+			if _, _, _, err := password.DecodeArgon2id(hash); err != nil {
+				return nil, fmt.Errorf("password_hash: %w", err)
+			}
+		}
+		if !password.DefaultHasher().Recognizes(password.HashedPassword(hash)) {
+			return nil, errors.New("password_hash is not a bcrypt or argon2id hash")
+		}
+		// NewUser only takes a plain password, so an existing hash goes
+		// through ReconstructUser, which leaves the username checks to us.
+		if strings.Contains(values["username"], "@") {
+			return nil, fmt.Errorf("username: %w", domain.ErrInvalidUsername)
+		}
+		now := time.Now()
+		user, err = domain.ReconstructUser(
+			domain.NewUserID().String(), values["username"], values["email"], values["first_name"], values["last_name"],
+			hash, role.String(), domain.StatusActive.String(), "", false, false, now, nil, now, now,
+		)
+	case plainPassword != "":
+		userInputs := []string{values["username"], values["email"], values["first_name"], values["last_name"]}
+		if err := passwords.ValidateStrength(plainPassword, role, userInputs...); err != nil {
+			return nil, fmt.Errorf("password: %w", err)
+		}
+		user, err = domain.NewUser(values["username"], values["email"], values["first_name"], values["last_name"], plainPassword)
+		if err == nil {
+			user.AssignRole(role)
+		}
+	default:
+		return nil, errors.New("password or password_hash is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if value := values["phone_number"]; value != "" {
+		phone, err := domain.NewPhoneNumber(value)
+		if err != nil {
+			return nil, fmt.Errorf("phone_number: %w", err)
+		}
+		user.ChangePhoneNumber(phone)
+	}
+
+	return user, nil
+}
+
+func (r *importReport) print(w io.Writer) {
+	for _, rowErr := range r.errors {
+		fmt.Fprintf(w, "%s: %v\n", rowErr.position, rowErr.err)
+	}
+	fmt.Fprintf(w, "%d rows: %d valid, %d invalid\n", r.rows, len(r.users), len(r.errors))
+}
@@ -0,0 +1,118 @@
+// Command usertool imports users from and exports them to CSV or JSON files.
+//
+//	usertool import -in users.csv [-dry-run] [-chunk 500] [-skip-invalid]
+//	usertool export -out users.json [-fields id,username,email]
+//
+// It reads the database and password settings from CONFIG_FILE, like the API,
+// and checks imported passwords against the same role policies.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"beerdosan-backend/internal/app/config"
+	"beerdosan-backend/internal/app/service"
+	"beerdosan-backend/internal/pkg/database"
+	"beerdosan-backend/internal/pkg/password"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: usertool import|export [flags]; run usertool import -h for the flags")
+	os.Exit(2)
+}
+
+// loadConfig reads CONFIG_FILE, like the API.
+func loadConfig() (*config.AppConfig, error) {
+	configPath := os.Getenv("CONFIG_FILE")
+	if configPath == "" {
+		configPath = "config/app.example.yaml"
+	}
+
+	appCfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file (%s): %w", configPath, err)
+	}
+	return appCfg, nil
+}
+
+func openDatabase(appCfg *config.AppConfig) (*database.Database, error) {
+	db, err := database.New(appCfg.Database.ToDBConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
+
+// newPasswordService builds the password service the API uses, breach
+// screening included, and installs its hasher as the default one the domain
+// model hashes through. Call the returned function when done.
+func newPasswordService(appCfg *config.AppConfig) (service.PasswordService, func() error, error) {
+	passwordCfg, err := appCfg.Password.ToPasswordConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid password configuration: %w", err)
+	}
+
+	closeBreaches := func() error { return nil }
+	if path := appCfg.Password.BreachedPasswordsPath; path != "" {
+		breaches, err := password.OpenBreachSource(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load breached passwords: %w", err)
+		}
+		if filter, ok := breaches.(*password.BloomFilter); ok && filter.MinCount() < passwordCfg.BreachThreshold {
+			breaches.Close()
+			return nil, nil, fmt.Errorf("breached password filter was built with min count %d, below breach_threshold %d", filter.MinCount(), passwordCfg.BreachThreshold)
+		}
+		passwordCfg.Breaches = breaches
+		closeBreaches = breaches.Close
+	}
+
+	password.SetDefaultHasher(password.NewHasher(passwordCfg))
+	return service.NewPasswordService(password.NewPasswordService(passwordCfg)), closeBreaches, nil
+}
+
+// resolveFormat returns format, or the one implied by the file extension.
+func resolveFormat(format, path string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	switch format {
+	case formatCSV, formatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, use -format csv or -format json", format)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AccessTokenCookieName  = "access_token"
+	RefreshTokenCookieName = "refresh_token"
+	CSRFCookieName         = "csrf_token"
+	CSRFHeaderName         = "X-CSRF-Token"
+	// MagicLinkCookieName holds the secret that ties a magic link to the
+	// browser that requested it.
+	MagicLinkCookieName = "magic_link_binding"
+)
+
+var ErrInvalidSameSite = errors.New("invalid same_site value")
+
+// CookieConfig controls the browser cookie mode, in which tokens travel in
+// HttpOnly cookies instead of response bodies and Authorization headers.
+type CookieConfig struct {
+	Enabled  bool
+	Domain   string
+	Path     string
+	SameSite http.SameSite
+}
+
+func DefaultCookieConfig() CookieConfig {
+	return CookieConfig{
+		Enabled:  false,
+		Path:     "/",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func ParseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	default:
+		return 0, ErrInvalidSameSite
+	}
+}
+
+// SetAuthCookies stores both tokens in HttpOnly cookies and issues a fresh
+// CSRF token in a cookie the frontend can read and echo back in CSRFHeaderName.
+func SetAuthCookies(c *gin.Context, cfg CookieConfig, accessToken string, accessExpiresAt time.Time, refreshToken string, refreshExpiresAt time.Time) error {
+	csrfToken, err := generateCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	setCookie(c, cfg, AccessTokenCookieName, accessToken, accessExpiresAt, true)
+	setCookie(c, cfg, RefreshTokenCookieName, refreshToken, refreshExpiresAt, true)
+	setCookie(c, cfg, CSRFCookieName, csrfToken, refreshExpiresAt, false)
+	return nil
+}
+
+func ClearAuthCookies(c *gin.Context, cfg CookieConfig) {
+	for _, name := range []string{AccessTokenCookieName, RefreshTokenCookieName, CSRFCookieName} {
+		http.SetCookie(c.Writer, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     cfg.Path,
+			Domain:   cfg.Domain,
+			MaxAge:   -1,
+			Secure:   true,
+			HttpOnly: name != CSRFCookieName,
+			SameSite: cfg.SameSite,
+		})
+	}
+}
+
+// SetMagicLinkCookie stores the binding token of a requested magic link. It is
+// set whether or not cookie mode is enabled, as links cannot be consumed
+// without it.
+func SetMagicLinkCookie(c *gin.Context, cfg CookieConfig, bindingToken string, expiresAt time.Time) {
+	setCookie(c, cfg, MagicLinkCookieName, bindingToken, expiresAt, true)
+}
+
+func ClearMagicLinkCookie(c *gin.Context, cfg CookieConfig) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     MagicLinkCookieName,
+		Value:    "",
+		Path:     cfg.Path,
+		Domain:   cfg.Domain,
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: cfg.SameSite,
+	})
+}
+
+func setCookie(c *gin.Context, cfg CookieConfig, name, value string, expiresAt time.Time, httpOnly bool) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     cfg.Path,
+		Domain:   cfg.Domain,
+		Expires:  expiresAt,
+		MaxAge:   int(time.Until(expiresAt).Seconds()),
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: cfg.SameSite,
+	})
+}
+
+func generateCSRFToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// CSRFMiddleware implements the double-submit cookie pattern: state-changing
+// requests that carry an auth cookie must repeat the CSRF cookie value in
+// CSRFHeaderName. Requests with an Authorization header are authenticated by
+// it, not by the cookie, so they are not exposed to CSRF and pass through.
+func CSRFMiddleware(cfg CookieConfig) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if !cfg.Enabled || isSafeMethod(c.Request.Method) ||
+			c.GetHeader("Authorization") != "" || !hasAuthCookie(c) {
+			c.Next()
+			return
+		}
+
+		cookieToken, err := c.Cookie(CSRFCookieName)
+		headerToken := c.GetHeader(CSRFHeaderName)
+		if err != nil || cookieToken == "" || headerToken == "" ||
+			subtle.ConstantTimeCompare([]byte(cookieToken), []byte(headerToken)) != 1 {
+			AbortWithError(c, NewForbiddenError("Invalid CSRF token"))
+			return
+		}
+
+		c.Next()
+	})
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+func hasAuthCookie(c *gin.Context) bool {
+	for _, name := range []string{AccessTokenCookieName, RefreshTokenCookieName} {
+		if value, err := c.Cookie(name); err == nil && value != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -47,6 +47,17 @@ func ResponseCreated[T any](c ResponseContext, data T) {
 	})
 }
 
+func ResponseAccepted[T any](c ResponseContext, data T) {
+	c.JSON(http.StatusAccepted, ApiResponse[T]{
+		Status: ResponseStatus{
+			Code:        http.StatusAccepted,
+			Header:      "Accepted",
+			Description: "Request accepted for processing",
+		},
+		Data: data,
+	})
+}
+
 func ResponseNoContent(c ResponseContext) {
 	c.JSON(http.StatusNoContent, ApiResponse[any]{
 		Status: ResponseStatus{
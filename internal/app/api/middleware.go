@@ -13,11 +13,15 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
+	"beerdosan-backend/internal/app/domain"
 	"beerdosan-backend/internal/app/service"
 )
 
 const (
 	RequestIDHeader = "X-Request-ID"
+	// DefaultRecentAuthMaxAge is how long after authenticating a user may
+	// perform sensitive operations without reauthenticating.
+	DefaultRecentAuthMaxAge = 5 * time.Minute
 )
 
 func RequestID() gin.HandlerFunc {
@@ -74,8 +78,8 @@ func LoggerMiddleware() gin.HandlerFunc {
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, X-Request-ID")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, X-Request-ID, X-CSRF-Token")
 		c.Header("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if c.Request.Method == "OPTIONS" {
@@ -87,21 +91,34 @@ func CORSMiddleware() gin.HandlerFunc {
 	}
 }
 
-func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
+// AuthMiddleware authenticates the request from the Authorization header, or
+// from the access token cookie when cookie mode is enabled and no header is
+// sent. The token must grant one of scopes, or full access when none are
+// given.
+func AuthMiddleware(authService service.AuthService, cookieCfg CookieConfig, scopes ...domain.Scope) gin.HandlerFunc {
+	if len(scopes) == 0 {
+		scopes = []domain.Scope{domain.ScopeFullAccess}
+	}
+
 	return gin.HandlerFunc(func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			AbortWithError(c, NewUnauthorizedError("Authorization header required"))
-			return
-		}
 
-		const bearerPrefix = "Bearer "
-		if !strings.HasPrefix(authHeader, bearerPrefix) {
-			AbortWithError(c, NewBadRequestError("Invalid authorization header format"))
+		var token string
+		switch {
+		case authHeader != "":
+			const bearerPrefix = "Bearer "
+			if !strings.HasPrefix(authHeader, bearerPrefix) {
+				AbortWithError(c, NewBadRequestError("Invalid authorization header format"))
+				return
+			}
+			token = strings.TrimPrefix(authHeader, bearerPrefix)
+		case cookieCfg.Enabled:
+			token, _ = c.Cookie(AccessTokenCookieName)
+		default:
+			AbortWithError(c, NewUnauthorizedError("Authorization header required"))
 			return
 		}
 
-		token := strings.TrimPrefix(authHeader, bearerPrefix)
 		if token == "" {
 			AbortWithError(c, NewUnauthorizedError("Access token required"))
 			return
@@ -113,11 +130,19 @@ func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("session_id", claims.SessionID)
+		if !hasAnyScope(claims, scopes) {
+			if claims.HasScope(domain.ScopePasswordChange) {
+				AbortWithError(c, NewAppError("PASSWORD_CHANGE_REQUIRED", http.StatusForbidden, "Password must be changed", nil))
+				return
+			}
+			AbortWithError(c, NewForbiddenError("Insufficient scope"))
+			return
+		}
+
 		c.Set("username", claims.Username)
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
+		c.Set("scopes", claims.Scopes)
 		c.Set("token_claims", claims)
 
 		c.Set("user_uuid", claims.UserUUID)
@@ -127,6 +152,37 @@ func AuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 	})
 }
 
+// RequireRecentAuth rejects requests when more than maxAge has passed, as of
+// now, since the user last authenticated (the token's auth_time), however
+// recently the token itself was issued. It must run after AuthMiddleware. The
+// client recovers by calling the reauthenticate endpoint and retrying with
+// the token it returns.
+func RequireRecentAuth(maxAge time.Duration) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		claims, ok := GetTokenClaims(c)
+		if !ok {
+			AbortWithError(c, NewUnauthorizedError("Authentication required"))
+			return
+		}
+
+		if !claims.AuthenticatedWithin(maxAge) {
+			AbortWithError(c, NewAppError("REAUTHENTICATION_REQUIRED", http.StatusForbidden, "Recent authentication required", nil))
+			return
+		}
+
+		c.Next()
+	})
+}
+
+func hasAnyScope(claims *service.AuthClaims, scopes []domain.Scope) bool {
+	for _, scope := range scopes {
+		if claims.HasScope(scope) {
+			return true
+		}
+	}
+	return false
+}
+
 func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		role, exists := c.Get("role")
@@ -163,8 +219,17 @@ func RateLimitMiddleware(maxRequests int, window string) gin.HandlerFunc {
 	})
 }
 
-func ValidateJSONMiddleware() gin.HandlerFunc {
+// ValidateJSONMiddleware requires a JSON body on write requests. Paths under
+// exemptPrefixes are skipped, e.g. OAuth endpoints that take form bodies.
+func ValidateJSONMiddleware(exemptPrefixes ...string) gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
+		for _, prefix := range exemptPrefixes {
+			if strings.HasPrefix(c.Request.URL.Path, prefix) {
+				c.Next()
+				return
+			}
+		}
+
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "PATCH" {
 			contentType := c.GetHeader("Content-Type")
 			if !strings.Contains(contentType, "application/json") {
@@ -187,15 +252,6 @@ func SecurityHeaders() gin.HandlerFunc {
 	})
 }
 
-func GetUserID(c *gin.Context) (int64, bool) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		return 0, false
-	}
-	id, ok := userID.(int64)
-	return id, ok
-}
-
 func GetUsername(c *gin.Context) (string, bool) {
 	username, exists := c.Get("username")
 	if !exists {
@@ -214,13 +270,13 @@ func GetUserEmail(c *gin.Context) (string, bool) {
 	return userEmail, ok
 }
 
-func GetSessionID(c *gin.Context) (int64, bool) {
-	sessionID, exists := c.Get("session_id")
+func GetScopes(c *gin.Context) ([]string, bool) {
+	scopes, exists := c.Get("scopes")
 	if !exists {
-		return 0, false
+		return nil, false
 	}
-	id, ok := sessionID.(int64)
-	return id, ok
+	tokenScopes, ok := scopes.([]string)
+	return tokenScopes, ok
 }
 
 func GetUserRole(c *gin.Context) (string, bool) {
@@ -242,7 +298,7 @@ func GetTokenClaims(c *gin.Context) (*service.AuthClaims, bool) {
 }
 
 func RequireAuth(c *gin.Context) error {
-	if _, exists := c.Get("user_id"); !exists {
+	if _, exists := c.Get("user_uuid"); !exists {
 		return NewUnauthorizedError("Authentication required")
 	}
 	return nil
@@ -1,6 +1,11 @@
 package v1
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"beerdosan-backend/internal/app/api"
@@ -10,14 +15,18 @@ import (
 )
 
 type AuthHandler struct {
-	authUseCase usecase.AuthUseCase
-	authService service.AuthService
+	authUseCase      usecase.AuthUseCase
+	authService      service.AuthService
+	cookieConfig     api.CookieConfig
+	recentAuthMaxAge time.Duration
 }
 
-func NewAuthHandler(authUseCase usecase.AuthUseCase, authService service.AuthService) *AuthHandler {
+func NewAuthHandler(authUseCase usecase.AuthUseCase, authService service.AuthService, cookieConfig api.CookieConfig, recentAuthMaxAge time.Duration) *AuthHandler {
 	return &AuthHandler{
-		authUseCase: authUseCase,
-		authService: authService,
+		authUseCase:      authUseCase,
+		authService:      authService,
+		cookieConfig:     cookieConfig,
+		recentAuthMaxAge: recentAuthMaxAge,
 	}
 }
 
@@ -28,13 +37,36 @@ func (h *AuthHandler) Register(r api.GinRouterRegister) error {
 	auth := v1.Group("/auth")
 
 	auth.POST("/login", h.Login)
-	auth.POST("/logout", api.AuthMiddleware(h.authService), h.Logout)
+	auth.POST("/magic-link", h.RequestMagicLink)
+	auth.POST("/magic-link/consume", h.ConsumeMagicLink)
+	auth.POST("/otp", h.RequestLoginOTP)
+	auth.POST("/otp/verify", h.VerifyLoginOTP)
+	auth.POST("/logout", api.AuthMiddleware(h.authService, h.cookieConfig, domain.ScopePasswordChange), h.Logout)
 	auth.POST("/refresh", h.RefreshToken)
-	auth.GET("/me", api.AuthMiddleware(h.authService), h.GetProfile)
-	auth.GET("/sessions", api.AuthMiddleware(h.authService), h.GetSessions)
-	auth.DELETE("/sessions/:sessionId", api.AuthMiddleware(h.authService), h.TerminateSession)
-	auth.DELETE("/sessions", api.AuthMiddleware(h.authService), h.TerminateAllSessions)
-	auth.PUT("/password", api.AuthMiddleware(h.authService), h.ChangePassword)
+	auth.GET("/me", api.AuthMiddleware(h.authService, h.cookieConfig), h.GetProfile)
+	auth.PATCH("/me", api.AuthMiddleware(h.authService, h.cookieConfig), h.UpdateProfile)
+	auth.POST("/me/email", api.AuthMiddleware(h.authService, h.cookieConfig), api.RequireRecentAuth(h.recentAuthMaxAge), h.RequestEmailChange)
+	auth.POST("/me/email/confirm", h.ConfirmEmailChange)
+	auth.POST("/me/export", api.AuthMiddleware(h.authService, h.cookieConfig), api.RequireRecentAuth(h.recentAuthMaxAge), h.ExportPersonalData)
+	auth.DELETE("/me", api.AuthMiddleware(h.authService, h.cookieConfig), api.RequireRecentAuth(h.recentAuthMaxAge), h.DeleteAccount)
+	auth.GET("/sessions", api.AuthMiddleware(h.authService, h.cookieConfig), h.GetSessions)
+	auth.PATCH("/sessions/:sessionId", api.AuthMiddleware(h.authService, h.cookieConfig), h.RenameSession)
+	auth.DELETE("/sessions/:sessionId", api.AuthMiddleware(h.authService, h.cookieConfig), api.RequireRecentAuth(h.recentAuthMaxAge), h.TerminateSession)
+	auth.DELETE("/sessions", api.AuthMiddleware(h.authService, h.cookieConfig), api.RequireRecentAuth(h.recentAuthMaxAge), h.TerminateAllSessions)
+	auth.POST("/reauthenticate", api.AuthMiddleware(h.authService, h.cookieConfig), h.Reauthenticate)
+	auth.POST("/reauthenticate/otp", api.AuthMiddleware(h.authService, h.cookieConfig), h.RequestReauthenticationOTP)
+	auth.PUT("/password", api.AuthMiddleware(h.authService, h.cookieConfig, domain.ScopePasswordChange), api.RequireRecentAuth(h.recentAuthMaxAge), h.ChangePassword)
+	auth.PUT("/phone", api.AuthMiddleware(h.authService, h.cookieConfig), api.RequireRecentAuth(h.recentAuthMaxAge), h.ChangePhoneNumber)
+	auth.POST("/phone/verify", api.AuthMiddleware(h.authService, h.cookieConfig), h.VerifyPhoneNumber)
+	auth.GET("/password/policy", h.GetPasswordPolicy)
+	auth.POST("/password/strength", h.EstimatePasswordStrength)
+	auth.POST("/invitations/accept", h.AcceptInvitation)
+
+	admin := v1.Group("/admin", api.AuthMiddleware(h.authService, h.cookieConfig), api.AdminMiddleware())
+	admin.POST("/users/:userId/require-password-change", h.RequirePasswordChange)
+	admin.POST("/invitations", h.CreateInvitation)
+	admin.GET("/invitations", h.ListInvitations)
+	admin.DELETE("/invitations/:invitationId", h.RevokeInvitation)
 
 	return nil
 }
@@ -42,7 +74,10 @@ func (h *AuthHandler) Register(r api.GinRouterRegister) error {
 func (h *AuthHandler) Login(c *gin.Context) {
 	type (
 		LoginRequest struct {
-			Username   string `json:"username" binding:"required"`
+			// Identifier is a username or an email address. Username is
+			// still accepted in its place for older clients.
+			Identifier string `json:"identifier"`
+			Username   string `json:"username"`
 			Password   string `json:"password" binding:"required"`
 			RememberMe bool   `json:"remember_me"`
 		}
@@ -60,8 +95,17 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	identifier := req.Identifier
+	if identifier == "" {
+		identifier = req.Username
+	}
+	if strings.TrimSpace(identifier) == "" {
+		api.AbortWithError(c, api.NewBadRequestError("identifier is required"))
+		return
+	}
+
 	loginInput := usecase.LoginInput{
-		Username:   req.Username,
+		Identifier: identifier,
 		Password:   req.Password,
 		DeviceInfo: api.GetUserAgent(c),
 		IPAddress:  api.GetClientIP(c),
@@ -74,9 +118,288 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	h.respondLogin(c, response)
+}
+
+// respondLogin answers a successful login. In cookie mode the tokens go into
+// cookies and only their expiry and the user are returned.
+func (h *AuthHandler) respondLogin(c *gin.Context, response *usecase.LoginOutput) {
+	type CookieLoginResponse struct {
+		ExpiresAt              time.Time        `json:"expires_at"`
+		User                   usecase.UserInfo `json:"user"`
+		PasswordChangeRequired bool             `json:"password_change_required"`
+	}
+
+	if h.cookieConfig.Enabled {
+		if err := api.SetAuthCookies(c, h.cookieConfig, response.AccessToken, response.ExpiresAt, response.RefreshToken, response.RefreshExpiresAt); err != nil {
+			api.AbortWithError(c, api.NewInternalError(err))
+			return
+		}
+
+		api.ResponseSuccess(c, CookieLoginResponse{
+			ExpiresAt:              response.ExpiresAt,
+			User:                   response.User,
+			PasswordChangeRequired: response.PasswordChangeRequired,
+		})
+		return
+	}
+
 	api.ResponseSuccess(c, response)
 }
 
+// RequestMagicLink always answers 202 so that it does not reveal whether the
+// address belongs to an account.
+func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
+	type (
+		MagicLinkRequest struct {
+			Email string `json:"email" binding:"required,email"`
+		}
+		MagicLinkResponse struct {
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+	)
+
+	var req MagicLinkRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	response, err := h.authUseCase.RequestMagicLink(c.Request.Context(), usecase.MagicLinkInput{
+		Email:     req.Email,
+		IPAddress: api.GetClientIP(c),
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.SetMagicLinkCookie(c, h.cookieConfig, response.BindingToken, response.ExpiresAt)
+	api.ResponseAccepted(c, MagicLinkResponse{ExpiresAt: response.ExpiresAt})
+}
+
+func (h *AuthHandler) ConsumeMagicLink(c *gin.Context) {
+	type (
+		ConsumeMagicLinkRequest struct {
+			Token      string `json:"token" binding:"required"`
+			RememberMe bool   `json:"remember_me"`
+		}
+	)
+
+	var req ConsumeMagicLinkRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	bindingToken, _ := c.Cookie(api.MagicLinkCookieName)
+
+	response, err := h.authUseCase.ConsumeMagicLink(c.Request.Context(), usecase.ConsumeMagicLinkInput{
+		Token:        req.Token,
+		BindingToken: bindingToken,
+		DeviceInfo:   api.GetUserAgent(c),
+		IPAddress:    api.GetClientIP(c),
+		RememberMe:   req.RememberMe,
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ClearMagicLinkCookie(c, h.cookieConfig)
+
+	h.respondLogin(c, response)
+}
+
+// RequestLoginOTP always answers 202 so that it does not reveal whether the
+// destination belongs to an account.
+func (h *AuthHandler) RequestLoginOTP(c *gin.Context) {
+	type OTPRequest struct {
+		Channel     string `json:"channel" binding:"required,oneof=email sms"`
+		Destination string `json:"destination" binding:"required"`
+	}
+
+	var req OTPRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	response, err := h.authUseCase.RequestLoginOTP(c.Request.Context(), usecase.OTPLoginInput{
+		Channel:     req.Channel,
+		Destination: req.Destination,
+		IPAddress:   api.GetClientIP(c),
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseAccepted(c, response)
+}
+
+func (h *AuthHandler) VerifyLoginOTP(c *gin.Context) {
+	type VerifyOTPRequest struct {
+		Channel     string `json:"channel" binding:"required,oneof=email sms"`
+		Destination string `json:"destination" binding:"required"`
+		Code        string `json:"code" binding:"required"`
+		RememberMe  bool   `json:"remember_me"`
+	}
+
+	var req VerifyOTPRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	response, err := h.authUseCase.VerifyLoginOTP(c.Request.Context(), usecase.OTPVerifyInput{
+		Channel:     req.Channel,
+		Destination: req.Destination,
+		Code:        req.Code,
+		DeviceInfo:  api.GetUserAgent(c),
+		IPAddress:   api.GetClientIP(c),
+		RememberMe:  req.RememberMe,
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	h.respondLogin(c, response)
+}
+
+// ChangePhoneNumber sets the user's phone number and texts it a verification
+// code for VerifyPhoneNumber.
+func (h *AuthHandler) ChangePhoneNumber(c *gin.Context) {
+	type ChangePhoneNumberRequest struct {
+		PhoneNumber string `json:"phone_number" binding:"required"`
+	}
+
+	var req ChangePhoneNumberRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.authUseCase.ChangePhoneNumber(c.Request.Context(), domain.UserID(userUUID), req.PhoneNumber)
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseAccepted(c, response)
+}
+
+func (h *AuthHandler) VerifyPhoneNumber(c *gin.Context) {
+	type (
+		VerifyPhoneNumberRequest struct {
+			Code string `json:"code" binding:"required"`
+		}
+		VerifyPhoneNumberResponse struct {
+			Message string `json:"message"`
+		}
+	)
+
+	var req VerifyPhoneNumberRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	if err := h.authUseCase.VerifyPhoneNumber(c.Request.Context(), domain.UserID(userUUID), req.Code); err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, VerifyPhoneNumberResponse{
+		Message: "Phone number verified successfully",
+	})
+}
+
+// RequestReauthenticationOTP sends a code that Reauthenticate accepts in
+// place of the password.
+func (h *AuthHandler) RequestReauthenticationOTP(c *gin.Context) {
+	type ReauthenticationOTPRequest struct {
+		Channel string `json:"channel" binding:"required,oneof=email sms"`
+	}
+
+	var req ReauthenticationOTPRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.authUseCase.RequestReauthenticationOTP(c.Request.Context(), domain.UserID(userUUID), req.Channel)
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseAccepted(c, response)
+}
+
+// Reauthenticate confirms the user's password or a code and returns fresh
+// tokens for the current session, as login does, so that routes behind
+// RequireRecentAuth accept them.
+func (h *AuthHandler) Reauthenticate(c *gin.Context) {
+	type ReauthenticateRequest struct {
+		Password string `json:"password" binding:"required_without=Code,max=128"`
+		Channel  string `json:"channel" binding:"required_with=Code,omitempty,oneof=email sms"`
+		Code     string `json:"code" binding:"required_without=Password"`
+	}
+
+	var req ReauthenticateRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	sessionUUID, ok := api.GetSessionUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Session not found"))
+		return
+	}
+
+	response, err := h.authUseCase.Reauthenticate(c.Request.Context(), usecase.ReauthenticateInput{
+		UserID:    domain.UserID(userUUID),
+		SessionID: domain.SessionID(sessionUUID),
+		Password:  req.Password,
+		Channel:   req.Channel,
+		Code:      req.Code,
+		IPAddress: api.GetClientIP(c),
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	h.respondLogin(c, response)
+}
+
 func (h *AuthHandler) Logout(c *gin.Context) {
 	userUUID, ok := api.GetUserUUID(c)
 	if !ok {
@@ -94,6 +417,9 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	sessionID := domain.SessionID(sessionUUID)
 
 	err := h.authUseCase.Logout(c.Request.Context(), userID, sessionID)
+	if h.cookieConfig.Enabled {
+		api.ClearAuthCookies(c, h.cookieConfig)
+	}
 	if err != nil {
 		api.AbortWithError(c, err)
 		return
@@ -108,13 +434,20 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 			RefreshToken string `json:"refresh_token" binding:"required"`
 		}
 		RefreshTokenResponse struct {
-			AccessToken  string `json:"access_token"`
-			RefreshToken string `json:"refresh_token"`
+			AccessToken            string `json:"access_token"`
+			RefreshToken           string `json:"refresh_token"`
+			PasswordChangeRequired bool   `json:"password_change_required"`
+		}
+		CookieRefreshTokenResponse struct {
+			ExpiresAt              time.Time `json:"expires_at"`
+			PasswordChangeRequired bool      `json:"password_change_required"`
 		}
 	)
 
 	var req RefreshTokenRequest
-	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+	if cookie, err := c.Cookie(api.RefreshTokenCookieName); h.cookieConfig.Enabled && err == nil && cookie != "" {
+		req.RefreshToken = cookie
+	} else if appErr := api.BindAndValidate(c, &req); appErr != nil {
 		api.AbortWithError(c, appErr)
 		return
 	}
@@ -131,21 +464,58 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
+	if h.cookieConfig.Enabled {
+		if err := api.SetAuthCookies(c, h.cookieConfig, response.AccessToken, response.ExpiresAt, response.RefreshToken, response.RefreshExpiresAt); err != nil {
+			api.AbortWithError(c, api.NewInternalError(err))
+			return
+		}
+
+		api.ResponseSuccess(c, CookieRefreshTokenResponse{
+			ExpiresAt:              response.ExpiresAt,
+			PasswordChangeRequired: response.PasswordChangeRequired,
+		})
+		return
+	}
+
 	api.ResponseSuccess(c, RefreshTokenResponse{
-		AccessToken:  string(response.AccessToken),
-		RefreshToken: string(response.RefreshToken),
+		AccessToken:            string(response.AccessToken),
+		RefreshToken:           string(response.RefreshToken),
+		PasswordChangeRequired: response.PasswordChangeRequired,
 	})
 }
 
 func (h *AuthHandler) GetProfile(c *gin.Context) {
-	type (
-		ProfileResponse struct {
-			ID       string `json:"id"`
-			Username string `json:"username"`
-			Email    string `json:"email"`
-			Role     string `json:"role"`
-		}
-	)
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	profile, err := h.authUseCase.GetUserProfile(c.Request.Context(), domain.UserID(userUUID))
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, profile)
+}
+
+// UpdateProfile changes the names that are present in the body.
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	type UpdateProfileRequest struct {
+		FirstName *string `json:"first_name"`
+		LastName  *string `json:"last_name"`
+	}
+
+	var req UpdateProfileRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+	if req.FirstName == nil && req.LastName == nil {
+		api.AbortWithError(c, api.NewBadRequestError("first_name or last_name is required"))
+		return
+	}
 
 	userUUID, ok := api.GetUserUUID(c)
 	if !ok {
@@ -153,31 +523,126 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	userID := domain.UserID(userUUID)
+	profile, err := h.authUseCase.UpdateProfile(c.Request.Context(), domain.UserID(userUUID), usecase.UpdateProfileInput{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, profile)
+}
+
+func (h *AuthHandler) RequestEmailChange(c *gin.Context) {
+	type RequestEmailChangeRequest struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	var req RequestEmailChangeRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
 
-	profile, err := h.authUseCase.GetUserProfile(c.Request.Context(), userID)
+	response, err := h.authUseCase.RequestEmailChange(c.Request.Context(), domain.UserID(userUUID), req.Email)
 	if err != nil {
 		api.AbortWithError(c, err)
 		return
 	}
 
-	api.ResponseSuccess(c, ProfileResponse{
-		ID:       string(profile.User.ID),
-		Username: string(profile.User.Username),
-		Email:    string(profile.User.Email),
-		Role:     string(profile.User.Role),
-	})
+	api.ResponseAccepted(c, response)
+}
+
+// ConfirmEmailChange is public: the token from the link is the proof, and the
+// link may be opened where the user is not signed in.
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	type ConfirmEmailChangeRequest struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	var req ConfirmEmailChangeRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	if err := h.authUseCase.ConfirmEmailChange(c.Request.Context(), req.Token); err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseNoContent(c)
+}
+
+// ExportPersonalData returns everything stored about the user as a JSON file
+// download.
+func (h *AuthHandler) ExportPersonalData(c *gin.Context) {
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	export, err := h.authUseCase.ExportPersonalData(c.Request.Context(), domain.UserID(userUUID))
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="personal-data-%s.json"`, export.ExportedAt.UTC().Format("20060102T150405Z")))
+	c.Header("Cache-Control", "no-store")
+	c.IndentedJSON(http.StatusOK, export)
+}
+
+// DeleteAccount schedules the account for deletion and logs the user out
+// everywhere. Logging in again before the returned time cancels it.
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.authUseCase.DeleteAccount(c.Request.Context(), domain.UserID(userUUID))
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	if h.cookieConfig.Enabled {
+		api.ClearAuthCookies(c, h.cookieConfig)
+	}
+
+	api.ResponseAccepted(c, response)
 }
 
 func (h *AuthHandler) GetSessions(c *gin.Context) {
 	type (
+		DeviceItem struct {
+			Browser        string `json:"browser"`
+			BrowserVersion string `json:"browser_version"`
+			OS             string `json:"os"`
+			OSVersion      string `json:"os_version"`
+			DeviceType     string `json:"device_type"`
+		}
 		SessionItem struct {
-			ID           string `json:"id"`
-			DeviceInfo   string `json:"device_info"`
-			IPAddress    string `json:"ip_address"`
-			CreatedAt    string `json:"created_at"`
-			LastActivity string `json:"last_activity"`
-			IsActive     bool   `json:"is_active"`
+			ID           string     `json:"id"`
+			Name         string     `json:"name"`
+			DeviceInfo   DeviceItem `json:"device_info"`
+			UserAgent    string     `json:"user_agent"`
+			IPAddress    string     `json:"ip_address"`
+			CreatedAt    string     `json:"created_at"`
+			LastActivity string     `json:"last_activity"`
+			IsActive     bool       `json:"is_active"`
+			IsCurrent    bool       `json:"is_current"`
 		}
 		PaginationMeta struct {
 			Page       int64 `json:"page"`
@@ -197,9 +662,16 @@ func (h *AuthHandler) GetSessions(c *gin.Context) {
 		return
 	}
 
+	currentSessionUUID, ok := api.GetSessionUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Session not found"))
+		return
+	}
+
 	userID := domain.UserID(userUUID)
+	currentSessionID := domain.SessionID(currentSessionUUID)
 
-	sessions, err := h.authUseCase.GetUserSessions(c.Request.Context(), userID)
+	sessions, err := h.authUseCase.GetUserSessions(c.Request.Context(), userID, currentSessionID)
 	if err != nil {
 		api.AbortWithError(c, err)
 		return
@@ -236,18 +708,65 @@ func (h *AuthHandler) GetSessions(c *gin.Context) {
 
 	for i, session := range paginatedSessions {
 		response.Sessions[i] = SessionItem{
-			ID:           string(session.ID),
-			DeviceInfo:   session.DeviceInfo,
+			ID:   string(session.ID),
+			Name: session.Name,
+			DeviceInfo: DeviceItem{
+				Browser:        session.Device.Browser,
+				BrowserVersion: session.Device.BrowserVersion,
+				OS:             session.Device.OS,
+				OSVersion:      session.Device.OSVersion,
+				DeviceType:     session.Device.DeviceType.String(),
+			},
+			UserAgent:    session.UserAgent,
 			IPAddress:    session.IPAddress,
 			CreatedAt:    session.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			LastActivity: session.LastActivity.Format("2006-01-02T15:04:05Z07:00"),
 			IsActive:     session.IsActive,
+			IsCurrent:    session.IsCurrent,
 		}
 	}
 
 	api.ResponseSuccess(c, response)
 }
 
+func (h *AuthHandler) RenameSession(c *gin.Context) {
+	type (
+		RenameSessionParam struct {
+			SessionID string `uri:"sessionId" binding:"required,uuid"`
+		}
+		RenameSessionRequest struct {
+			Name string `json:"name" binding:"max=100"`
+		}
+	)
+
+	var reqParam RenameSessionParam
+	if err := c.ShouldBindUri(&reqParam); err != nil {
+		api.AbortWithError(c, api.NewBadRequestError("Invalid session ID"))
+		return
+	}
+
+	var req RenameSessionRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	userID := domain.UserID(userUUID)
+
+	if err := h.authUseCase.RenameSession(c.Request.Context(), userID, domain.SessionID(reqParam.SessionID), req.Name); err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseNoContent(c)
+}
+
 func (h *AuthHandler) TerminateSession(c *gin.Context) {
 	type TerminateSessionParam struct {
 		SessionID string `uri:"sessionId" binding:"required,uuid"`
@@ -340,3 +859,199 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		Message: "Password changed successfully",
 	})
 }
+
+// GetPasswordPolicy returns the password rules for the role given in the
+// role query parameter, "user" by default.
+func (h *AuthHandler) GetPasswordPolicy(c *gin.Context) {
+	type PasswordPolicyQuery struct {
+		Role string `form:"role"`
+	}
+
+	var reqQuery PasswordPolicyQuery
+	if err := c.ShouldBindQuery(&reqQuery); err != nil {
+		api.AbortWithError(c, api.NewBadRequestError("Invalid query"))
+		return
+	}
+
+	role := domain.UserRoleUser
+	if reqQuery.Role != "" {
+		parsed, err := domain.NewUserRole(reqQuery.Role)
+		if err != nil {
+			api.AbortWithError(c, api.NewBadRequestError("Invalid role"))
+			return
+		}
+		role = parsed
+	}
+
+	response, err := h.authUseCase.GetPasswordPolicy(c.Request.Context(), role)
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, response)
+}
+
+func (h *AuthHandler) EstimatePasswordStrength(c *gin.Context) {
+	type PasswordStrengthRequest struct {
+		Password string `json:"password" binding:"required,max=128"`
+		Username string `json:"username"`
+		Email    string `json:"email"`
+	}
+
+	var req PasswordStrengthRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	response, err := h.authUseCase.EstimatePasswordStrength(c.Request.Context(), usecase.PasswordStrengthInput{
+		Password: req.Password,
+		Username: req.Username,
+		Email:    req.Email,
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, response)
+}
+
+func (h *AuthHandler) RequirePasswordChange(c *gin.Context) {
+	type RequirePasswordChangeParam struct {
+		UserID string `uri:"userId" binding:"required,uuid"`
+	}
+
+	var reqParam RequirePasswordChangeParam
+	if err := c.ShouldBindUri(&reqParam); err != nil {
+		api.AbortWithError(c, api.NewBadRequestError("Invalid user ID"))
+		return
+	}
+
+	if err := h.authUseCase.RequirePasswordChange(c.Request.Context(), domain.UserID(reqParam.UserID)); err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseNoContent(c)
+}
+
+func (h *AuthHandler) CreateInvitation(c *gin.Context) {
+	type CreateInvitationRequest struct {
+		Email string `json:"email" binding:"required,email"`
+		Role  string `json:"role" binding:"required"`
+	}
+
+	var req CreateInvitationRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	userUUID, ok := api.GetUserUUID(c)
+	if !ok {
+		api.AbortWithError(c, api.NewUnauthorizedError("Authentication required"))
+		return
+	}
+
+	response, err := h.authUseCase.CreateInvitation(c.Request.Context(), usecase.CreateInvitationInput{
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: domain.UserID(userUUID),
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseCreated(c, response)
+}
+
+func (h *AuthHandler) ListInvitations(c *gin.Context) {
+	type (
+		PaginationMeta struct {
+			Page       int64 `json:"page"`
+			Limit      int64 `json:"limit"`
+			Total      int64 `json:"total"`
+			TotalPages int64 `json:"total_pages"`
+		}
+		ListInvitationsResponse struct {
+			Invitations []usecase.InvitationOutput `json:"invitations"`
+			Pagination  PaginationMeta             `json:"pagination"`
+		}
+	)
+
+	page, limit, appErr := api.GetPagination(c)
+	if appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	response, err := h.authUseCase.ListInvitations(c.Request.Context(), page, limit)
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseSuccess(c, ListInvitationsResponse{
+		Invitations: response.Invitations,
+		Pagination: PaginationMeta{
+			Page:       int64(page),
+			Limit:      int64(limit),
+			Total:      response.Total,
+			TotalPages: (response.Total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+func (h *AuthHandler) RevokeInvitation(c *gin.Context) {
+	type RevokeInvitationParam struct {
+		InvitationID int64 `uri:"invitationId" binding:"required,min=1"`
+	}
+
+	var reqParam RevokeInvitationParam
+	if err := c.ShouldBindUri(&reqParam); err != nil {
+		api.AbortWithError(c, api.NewBadRequestError("Invalid invitation ID"))
+		return
+	}
+
+	if err := h.authUseCase.RevokeInvitation(c.Request.Context(), reqParam.InvitationID); err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseNoContent(c)
+}
+
+// AcceptInvitation is public: the token from the emailed link is the proof
+// that the caller was invited.
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	type AcceptInvitationRequest struct {
+		Token     string `json:"token" binding:"required"`
+		Username  string `json:"username" binding:"required"`
+		FirstName string `json:"first_name" binding:"required"`
+		LastName  string `json:"last_name" binding:"required"`
+		Password  string `json:"password" binding:"required"`
+	}
+
+	var req AcceptInvitationRequest
+	if appErr := api.BindAndValidate(c, &req); appErr != nil {
+		api.AbortWithError(c, appErr)
+		return
+	}
+
+	response, err := h.authUseCase.AcceptInvitation(c.Request.Context(), usecase.AcceptInvitationInput{
+		Token:     req.Token,
+		Username:  req.Username,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Password:  req.Password,
+	})
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	api.ResponseCreated(c, response)
+}
@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"beerdosan-backend/internal/app/api"
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/usecase"
+)
+
+// OAuthHandler exposes token introspection (RFC 7662) and revocation
+// (RFC 7009) to resource servers that cannot call AuthService directly.
+// Responses follow the RFCs instead of the API envelope.
+type OAuthHandler struct {
+	authUseCase usecase.AuthUseCase
+	clients     api.ClientCredentials
+}
+
+func NewOAuthHandler(authUseCase usecase.AuthUseCase, clients api.ClientCredentials) *OAuthHandler {
+	return &OAuthHandler{
+		authUseCase: authUseCase,
+		clients:     clients,
+	}
+}
+
+var _ api.GinController = (*OAuthHandler)(nil)
+
+func (h *OAuthHandler) Register(r api.GinRouterRegister) error {
+	oauth := r.WithGroup("/oauth", api.ClientAuthMiddleware(h.clients))
+
+	oauth.POST("/introspect", h.Introspect)
+	oauth.POST("/revoke", h.Revoke)
+
+	return nil
+}
+
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	type (
+		IntrospectRequest struct {
+			Token         string `form:"token" binding:"required"`
+			TokenTypeHint string `form:"token_type_hint"`
+		}
+		IntrospectResponse struct {
+			Active    bool   `json:"active"`
+			ClientID  string `json:"client_id,omitempty"`
+			Username  string `json:"username,omitempty"`
+			TokenType string `json:"token_type,omitempty"`
+			Exp       int64  `json:"exp,omitempty"`
+			Iat       int64  `json:"iat,omitempty"`
+			Sub       string `json:"sub,omitempty"`
+			Sid       string `json:"sid,omitempty"`
+			Role      string `json:"role,omitempty"`
+			Scope     string `json:"scope,omitempty"`
+		}
+	)
+
+	var req IntrospectRequest
+	if err := c.ShouldBind(&req); err != nil {
+		abortWithOAuthError(c, http.StatusBadRequest, "invalid_request", "token is required")
+		return
+	}
+
+	result, err := h.authUseCase.IntrospectToken(c.Request.Context(), req.Token)
+	if err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	if !result.Active {
+		c.JSON(http.StatusOK, IntrospectResponse{Active: false})
+		return
+	}
+
+	clientID, _ := api.GetClientID(c)
+
+	c.JSON(http.StatusOK, IntrospectResponse{
+		Active:    true,
+		ClientID:  clientID,
+		Username:  result.Username,
+		TokenType: oauthTokenType(result.TokenType),
+		Exp:       result.ExpiresAt.Unix(),
+		Iat:       result.IssuedAt.Unix(),
+		Sub:       result.UserID.String(),
+		Sid:       result.SessionID.String(),
+		Role:      result.Role.String(),
+		Scope:     oauthScope(result.Scopes),
+	})
+}
+
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	type RevokeRequest struct {
+		Token         string `form:"token" binding:"required"`
+		TokenTypeHint string `form:"token_type_hint"`
+	}
+
+	var req RevokeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		abortWithOAuthError(c, http.StatusBadRequest, "invalid_request", "token is required")
+		return
+	}
+
+	// token_type_hint is accepted but not needed: both token types resolve to
+	// the same session.
+	if err := h.authUseCase.RevokeToken(c.Request.Context(), req.Token); err != nil {
+		api.AbortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func oauthTokenType(tokenType domain.TokenType) string {
+	switch tokenType {
+	case domain.TokenTypeAccess:
+		return "access_token"
+	case domain.TokenTypeRefresh:
+		return "refresh_token"
+	default:
+		return string(tokenType)
+	}
+}
+
+func oauthScope(scopes []domain.Scope) string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, scope.String())
+	}
+	return strings.Join(values, " ")
+}
+
+func abortWithOAuthError(c *gin.Context, status int, code, description string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error":             code,
+		"error_description": description,
+	})
+}
@@ -2,19 +2,44 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 
+	"beerdosan-backend/internal/app/api"
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/jobs"
+	"beerdosan-backend/internal/app/service"
+	"beerdosan-backend/internal/app/usecase"
 	"beerdosan-backend/internal/pkg/database"
+	"beerdosan-backend/internal/pkg/jwt"
+	"beerdosan-backend/internal/pkg/mailer"
+	"beerdosan-backend/internal/pkg/password"
+	"beerdosan-backend/internal/pkg/sender"
 )
 
 type AppConfig struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	JWT      *JWTConfig     `yaml:"jwt,omitempty"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	JWT         *JWTConfig        `yaml:"jwt,omitempty"`
+	Scheduler   SchedulerConfig   `yaml:"scheduler"`
+	Session     SessionConfig     `yaml:"session"`
+	Cookie      CookieConfig      `yaml:"cookie"`
+	OAuth       OAuthConfig       `yaml:"oauth"`
+	AuthCache   AuthCacheConfig   `yaml:"auth_cache"`
+	Password    PasswordConfig    `yaml:"password"`
+	MagicLink   MagicLinkConfig   `yaml:"magic_link"`
+	Mailer      MailerConfig      `yaml:"mailer"`
+	OTP         OTPConfig         `yaml:"otp"`
+	StepUp      StepUpConfig      `yaml:"step_up"`
+	Account     AccountConfig     `yaml:"account"`
+	EmailChange EmailChangeConfig `yaml:"email_change"`
+	Invitation  InvitationConfig  `yaml:"invitation"`
 }
 
 type ServerConfig struct {
@@ -44,12 +69,561 @@ func (c DatabaseConfig) ToDBConfig() database.Config {
 }
 
 type JWTConfig struct {
-	PrivateKeyPath       string        `yaml:"private_key_path"`
-	PublicKeyPath        string        `yaml:"public_key_path"`
-	AccessTokenDuration  time.Duration `yaml:"access_token_duration"`
-	RefreshTokenDuration time.Duration `yaml:"refresh_token_duration"`
-	Issuer               string        `yaml:"issuer"`
-	Audience             string        `yaml:"audience"`
+	Algorithm                      string        `yaml:"algorithm"`
+	PrivateKeyPath                 string        `yaml:"private_key_path"`
+	PublicKeyPath                  string        `yaml:"public_key_path"`
+	AccessTokenDuration            time.Duration `yaml:"access_token_duration"`
+	RefreshTokenDuration           time.Duration `yaml:"refresh_token_duration"`
+	RememberMeRefreshTokenDuration time.Duration `yaml:"remember_me_refresh_token_duration"`
+	Issuer                         string        `yaml:"issuer"`
+	Audience                       string        `yaml:"audience"`
+	// RejectLegacyClaims stops accepting tokens issued before the versioned
+	// claims schema. Enable it once the longest refresh lifetime has passed
+	// since the upgrade.
+	RejectLegacyClaims bool `yaml:"reject_legacy_claims"`
+}
+
+// ToJWTConfig loads the signing keys from PrivateKeyPath and PublicKeyPath, or
+// generates an ephemeral key for the configured algorithm when no private key
+// path is set, and applies the configured lifetimes. A nil receiver yields the
+// defaults.
+func (c *JWTConfig) ToJWTConfig() (*jwt.JWTConfig, error) {
+	if c == nil {
+		return jwt.DefaultJWTConfig()
+	}
+
+	alg := jwt.AlgorithmRS256
+	if c.Algorithm != "" {
+		parsed, err := jwt.ParseAlgorithm(c.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		alg = parsed
+	}
+
+	var (
+		cfg *jwt.JWTConfig
+		err error
+	)
+	if c.PrivateKeyPath != "" {
+		cfg, err = loadJWTKeys(alg, c.PrivateKeyPath, c.PublicKeyPath)
+	} else {
+		cfg, err = jwt.GenerateJWTConfig(alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c.AccessTokenDuration > 0 {
+		cfg.AccessTokenDuration = c.AccessTokenDuration
+	}
+	if c.RefreshTokenDuration > 0 {
+		cfg.RefreshTokenDuration = c.RefreshTokenDuration
+	}
+	if c.RememberMeRefreshTokenDuration > 0 {
+		cfg.RememberMeRefreshTokenDuration = c.RememberMeRefreshTokenDuration
+	}
+	if c.Issuer != "" {
+		cfg.Issuer = c.Issuer
+	}
+	if c.Audience != "" {
+		cfg.Audience = c.Audience
+	}
+	if c.RejectLegacyClaims {
+		cfg.AcceptLegacyClaims = false
+	}
+
+	return cfg, nil
+}
+
+func loadJWTKeys(alg jwt.Algorithm, privateKeyPath, publicKeyPath string) (*jwt.JWTConfig, error) {
+	privateKeyPEM, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	var publicKeyPEM []byte
+	if publicKeyPath != "" {
+		publicKeyPEM, err = os.ReadFile(publicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key: %w", err)
+		}
+	}
+
+	return jwt.LoadJWTConfigFromPEM(alg, string(privateKeyPEM), string(publicKeyPEM))
+}
+
+type SchedulerConfig struct {
+	Enabled               bool               `yaml:"enabled"`
+	SessionRetention      RetentionJobConfig `yaml:"session_retention"`
+	LoginAttemptRetention RetentionJobConfig `yaml:"login_attempt_retention"`
+	AccountPurge          RetentionJobConfig `yaml:"account_purge"`
+}
+
+type RetentionJobConfig struct {
+	Interval      time.Duration `yaml:"interval"`
+	Retention     time.Duration `yaml:"retention"`
+	BatchSize     int           `yaml:"batch_size"`
+	MaxRowsPerRun int           `yaml:"max_rows_per_run"`
+}
+
+func (c RetentionJobConfig) ToRetentionConfig() jobs.RetentionConfig {
+	return jobs.RetentionConfig{
+		Interval:      c.Interval,
+		Retention:     c.Retention,
+		BatchSize:     c.BatchSize,
+		MaxRowsPerRun: c.MaxRowsPerRun,
+	}
+}
+
+type SessionConfig struct {
+	MaxActivePerUser int            `yaml:"max_active_per_user"`
+	RoleLimits       map[string]int `yaml:"role_limits"`
+	LimitPolicy      string         `yaml:"limit_policy"`
+}
+
+func (c SessionConfig) ToSessionLimitConfig() (service.SessionLimitConfig, error) {
+	cfg := service.DefaultSessionLimitConfig()
+	cfg.MaxActiveSessions = c.MaxActivePerUser
+
+	if c.LimitPolicy != "" {
+		policy, err := domain.NewSessionLimitPolicy(c.LimitPolicy)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Policy = policy
+	}
+
+	for name, limit := range c.RoleLimits {
+		role, err := domain.NewUserRole(name)
+		if err != nil {
+			return cfg, fmt.Errorf("session role limit %q: %w", name, err)
+		}
+		cfg.RoleLimits[role] = limit
+	}
+
+	return cfg, nil
+}
+
+type PasswordConfig struct {
+	// Preset is "default" or "secure" and selects the base configuration the
+	// other settings override.
+	Preset       string                          `yaml:"preset"`
+	Policy       PasswordPolicyConfig            `yaml:"policy"`
+	RolePolicies map[string]PasswordPolicyConfig `yaml:"role_policies"`
+
+	Algorithm     string                   `yaml:"algorithm"`
+	BcryptCost    int                      `yaml:"bcrypt_cost"`
+	Argon2Memory  uint32                   `yaml:"argon2_memory"`
+	Argon2Time    uint32                   `yaml:"argon2_time"`
+	Argon2Threads uint8                    `yaml:"argon2_threads"`
+	Argon2KeyLen  uint32                   `yaml:"argon2_key_len"`
+	Argon2SaltLen uint32                   `yaml:"argon2_salt_len"`
+	HistorySize   *int                     `yaml:"history_size"`
+	MaxAge        time.Duration            `yaml:"max_age"`
+	RoleMaxAge    map[string]time.Duration `yaml:"role_max_age"`
+
+	BreachedPasswordsPath string `yaml:"breached_passwords_path"`
+	BreachThreshold       int    `yaml:"breach_threshold"`
+}
+
+// PasswordPolicyConfig overrides the rules of a password policy. Unset fields
+// keep the value of the policy it applies to.
+type PasswordPolicyConfig struct {
+	MinLength        int   `yaml:"min_length"`
+	MaxLength        int   `yaml:"max_length"`
+	RequireUppercase *bool `yaml:"require_uppercase"`
+	RequireLowercase *bool `yaml:"require_lowercase"`
+	RequireNumbers   *bool `yaml:"require_numbers"`
+	RequireSymbols   *bool `yaml:"require_symbols"`
+	MinScore         *int  `yaml:"min_score"`
+	HistorySize      *int  `yaml:"history_size"`
+}
+
+func (c PasswordPolicyConfig) applyTo(policy password.PasswordPolicy) password.PasswordPolicy {
+	if c.MinLength > 0 {
+		policy.MinLength = c.MinLength
+	}
+	if c.MaxLength > 0 {
+		policy.MaxLength = c.MaxLength
+	}
+	if c.RequireUppercase != nil {
+		policy.RequireUppercase = *c.RequireUppercase
+	}
+	if c.RequireLowercase != nil {
+		policy.RequireLowercase = *c.RequireLowercase
+	}
+	if c.RequireNumbers != nil {
+		policy.RequireNumbers = *c.RequireNumbers
+	}
+	if c.RequireSymbols != nil {
+		policy.RequireSymbols = *c.RequireSymbols
+	}
+	if c.MinScore != nil {
+		policy.MinScore = *c.MinScore
+	}
+	if c.HistorySize != nil {
+		policy.HistorySize = *c.HistorySize
+	}
+	return policy
+}
+
+func validatePasswordPolicy(policy password.PasswordPolicy) error {
+	if policy.MinLength > policy.MaxLength {
+		return fmt.Errorf("min_length %d exceeds max_length %d", policy.MinLength, policy.MaxLength)
+	}
+	if policy.MinScore < 0 || policy.MinScore > 4 {
+		return fmt.Errorf("min_score %d is not between 0 and 4", policy.MinScore)
+	}
+	if policy.HistorySize < 0 {
+		return fmt.Errorf("history_size %d is negative", policy.HistorySize)
+	}
+	return nil
+}
+
+// ToPasswordConfig applies the configured policies, algorithm and costs on top
+// of the preset. Role policies start from the resulting default policy.
+func (c PasswordConfig) ToPasswordConfig() (*password.PasswordConfig, error) {
+	var cfg *password.PasswordConfig
+	switch c.Preset {
+	case "", "default":
+		cfg = password.DefaultPasswordConfig()
+	case "secure":
+		cfg = password.SecurePasswordConfig()
+	default:
+		return nil, fmt.Errorf("unknown password preset %q", c.Preset)
+	}
+
+	// history_size predates policies and sets the default policy's size.
+	if c.HistorySize != nil {
+		cfg.Policy.HistorySize = *c.HistorySize
+	}
+	cfg.Policy = c.Policy.applyTo(cfg.Policy)
+	if err := validatePasswordPolicy(cfg.Policy); err != nil {
+		return nil, fmt.Errorf("password policy: %w", err)
+	}
+
+	for name, override := range c.RolePolicies {
+		role, err := domain.NewUserRole(name)
+		if err != nil {
+			return nil, fmt.Errorf("password role policy %q: %w", name, err)
+		}
+
+		policy := override.applyTo(cfg.Policy)
+		if err := validatePasswordPolicy(policy); err != nil {
+			return nil, fmt.Errorf("password role policy %q: %w", name, err)
+		}
+		cfg.RolePolicies[role.String()] = policy
+	}
+
+	if c.Algorithm != "" {
+		alg, err := password.ParseAlgorithm(c.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Algorithm = alg
+	}
+	if c.BcryptCost > 0 {
+		cfg.BcryptCost = c.BcryptCost
+	}
+	if c.Argon2Memory > 0 {
+		cfg.Argon2Memory = c.Argon2Memory
+	}
+	if c.Argon2Time > 0 {
+		cfg.Argon2Time = c.Argon2Time
+	}
+	if c.Argon2Threads > 0 {
+		cfg.Argon2Threads = c.Argon2Threads
+	}
+	if c.Argon2KeyLen > 0 {
+		cfg.Argon2KeyLen = c.Argon2KeyLen
+	}
+	if c.Argon2SaltLen > 0 {
+		cfg.Argon2SaltLen = c.Argon2SaltLen
+	}
+	// Hashes above these costs would not verify.
+	if cfg.Argon2Memory > password.MaxArgon2Memory || cfg.Argon2Time > password.MaxArgon2Time || cfg.Argon2Threads > password.MaxArgon2Threads {
+		return nil, fmt.Errorf("argon2 costs may be at most memory %d, time %d and threads %d",
+			password.MaxArgon2Memory, password.MaxArgon2Time, password.MaxArgon2Threads)
+	}
+	if c.BreachThreshold > 0 {
+		cfg.BreachThreshold = c.BreachThreshold
+	}
+
+	return cfg, nil
+}
+
+func (c PasswordConfig) ToPasswordExpiryConfig() (service.PasswordExpiryConfig, error) {
+	cfg := service.DefaultPasswordExpiryConfig()
+	cfg.MaxAge = c.MaxAge
+
+	for name, maxAge := range c.RoleMaxAge {
+		role, err := domain.NewUserRole(name)
+		if err != nil {
+			return cfg, fmt.Errorf("password role max age %q: %w", name, err)
+		}
+		cfg.RoleMaxAge[role] = maxAge
+	}
+
+	return cfg, nil
+}
+
+type AuthCacheConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	MaxUsers      int           `yaml:"max_users"`
+	MaxSessions   int           `yaml:"max_sessions"`
+	TTL           time.Duration `yaml:"ttl"`
+	NotifyChannel string        `yaml:"notify_channel"`
+}
+
+// ToAuthCacheConfig returns zero sizes, which disable caching, unless the cache
+// is enabled.
+func (c AuthCacheConfig) ToAuthCacheConfig() service.AuthCacheConfig {
+	if !c.Enabled {
+		return service.AuthCacheConfig{}
+	}
+
+	cfg := service.DefaultAuthCacheConfig()
+	if c.MaxUsers > 0 {
+		cfg.MaxUsers = c.MaxUsers
+	}
+	if c.MaxSessions > 0 {
+		cfg.MaxSessions = c.MaxSessions
+	}
+	if c.TTL > 0 {
+		cfg.TTL = c.TTL
+	}
+	return cfg
+}
+
+// Channel is the Postgres NOTIFY channel replicas use to invalidate each
+// other's caches.
+func (c AuthCacheConfig) Channel() string {
+	if c.NotifyChannel != "" {
+		return c.NotifyChannel
+	}
+	return "auth_cache_invalidation"
+}
+
+type CookieConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Domain   string `yaml:"domain"`
+	Path     string `yaml:"path"`
+	SameSite string `yaml:"same_site"`
+}
+
+func (c CookieConfig) ToCookieConfig() (api.CookieConfig, error) {
+	cfg := api.DefaultCookieConfig()
+	cfg.Enabled = c.Enabled
+	cfg.Domain = c.Domain
+	if c.Path != "" {
+		cfg.Path = c.Path
+	}
+
+	sameSite, err := api.ParseSameSite(c.SameSite)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.SameSite = sameSite
+
+	return cfg, nil
+}
+
+type StepUpConfig struct {
+	// MaxAge is how recently the user must have authenticated to change their
+	// password or phone number or to terminate sessions.
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+func (c StepUpConfig) RecentAuthMaxAge() time.Duration {
+	if c.MaxAge > 0 {
+		return c.MaxAge
+	}
+	return api.DefaultRecentAuthMaxAge
+}
+
+type AccountConfig struct {
+	// DeletionGracePeriod is how long a deleted account can still be restored
+	// by logging in before it is purged.
+	DeletionGracePeriod time.Duration `yaml:"deletion_grace_period"`
+}
+
+func (c AccountConfig) ToAccountDeletionConfig() usecase.AccountDeletionConfig {
+	cfg := usecase.DefaultAccountDeletionConfig()
+	if c.DeletionGracePeriod > 0 {
+		cfg.GracePeriod = c.DeletionGracePeriod
+	}
+	return cfg
+}
+
+type EmailChangeConfig struct {
+	TTL time.Duration `yaml:"ttl"`
+	URL string        `yaml:"url"`
+}
+
+func (c EmailChangeConfig) ToEmailChangeConfig() usecase.EmailChangeConfig {
+	cfg := usecase.DefaultEmailChangeConfig()
+	if c.TTL > 0 {
+		cfg.TTL = c.TTL
+	}
+	if c.URL != "" {
+		cfg.URL = c.URL
+	}
+	return cfg
+}
+
+type InvitationConfig struct {
+	TTL time.Duration `yaml:"ttl"`
+	URL string        `yaml:"url"`
+}
+
+func (c InvitationConfig) ToInvitationConfig() usecase.InvitationConfig {
+	cfg := usecase.DefaultInvitationConfig()
+	if c.TTL > 0 {
+		cfg.TTL = c.TTL
+	}
+	if c.URL != "" {
+		cfg.URL = c.URL
+	}
+	return cfg
+}
+
+type MagicLinkConfig struct {
+	TTL          time.Duration `yaml:"ttl"`
+	URL          string        `yaml:"url"`
+	MaxPerWindow *int          `yaml:"max_per_window"`
+	Window       time.Duration `yaml:"window"`
+}
+
+func (c MagicLinkConfig) ToMagicLinkConfig() usecase.MagicLinkConfig {
+	cfg := usecase.DefaultMagicLinkConfig()
+	if c.TTL > 0 {
+		cfg.TTL = c.TTL
+	}
+	if c.URL != "" {
+		cfg.URL = c.URL
+	}
+	if c.MaxPerWindow != nil {
+		cfg.MaxPerWindow = *c.MaxPerWindow
+	}
+	if c.Window > 0 {
+		cfg.Window = c.Window
+	}
+	return cfg
+}
+
+type MailerConfig struct {
+	Driver string           `yaml:"driver"`
+	SMTP   SMTPMailerConfig `yaml:"smtp"`
+}
+
+type SMTPMailerConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+func (c MailerConfig) ToMailer() (mailer.Mailer, error) {
+	port := c.SMTP.Port
+	if port == 0 {
+		port = 587
+	}
+
+	return mailer.New(c.Driver, mailer.SMTPConfig{
+		Host:     c.SMTP.Host,
+		Port:     port,
+		Username: c.SMTP.Username,
+		Password: c.SMTP.Password,
+		From:     c.SMTP.From,
+	})
+}
+
+type OTPConfig struct {
+	TTL            time.Duration  `yaml:"ttl"`
+	MaxAttempts    int            `yaml:"max_attempts"`
+	ResendInterval time.Duration  `yaml:"resend_interval"`
+	Email          OTPEmailConfig `yaml:"email"`
+	SMS            OTPSMSConfig   `yaml:"sms"`
+}
+
+type OTPEmailConfig struct {
+	Driver  string `yaml:"driver"`
+	Subject string `yaml:"subject"`
+}
+
+type OTPSMSConfig struct {
+	Driver string       `yaml:"driver"`
+	Twilio TwilioConfig `yaml:"twilio"`
+}
+
+type TwilioConfig struct {
+	AccountSID string `yaml:"account_sid"`
+	AuthToken  string `yaml:"auth_token"`
+	From       string `yaml:"from"`
+}
+
+func (c OTPConfig) ToOTPConfig() usecase.OTPConfig {
+	cfg := usecase.DefaultOTPConfig()
+	if c.TTL > 0 {
+		cfg.TTL = c.TTL
+	}
+	if c.MaxAttempts > 0 {
+		cfg.MaxAttempts = c.MaxAttempts
+	}
+	if c.ResendInterval > 0 {
+		cfg.ResendInterval = c.ResendInterval
+	}
+	return cfg
+}
+
+// ToOTPSenders builds the email and SMS adapters. The email adapter sends
+// through m when its driver is "mailer".
+func (c OTPConfig) ToOTPSenders(m mailer.Mailer) (usecase.OTPSenders, error) {
+	subject := c.Email.Subject
+	if subject == "" {
+		subject = "Your sign-in code"
+	}
+
+	email, err := sender.NewEmail(c.Email.Driver, m, subject)
+	if err != nil {
+		return nil, fmt.Errorf("otp email: %w", err)
+	}
+
+	sms, err := sender.NewSMS(c.SMS.Driver, sender.TwilioConfig{
+		AccountSID: c.SMS.Twilio.AccountSID,
+		AuthToken:  c.SMS.Twilio.AuthToken,
+		From:       c.SMS.Twilio.From,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("otp sms: %w", err)
+	}
+
+	return usecase.OTPSenders{
+		domain.OTPChannelEmail: email,
+		domain.OTPChannelSMS:   sms,
+	}, nil
+}
+
+type OAuthConfig struct {
+	Clients []OAuthClientConfig `yaml:"clients"`
+}
+
+type OAuthClientConfig struct {
+	ID     string `yaml:"id"`
+	Secret string `yaml:"secret"`
+}
+
+func (c OAuthConfig) ToClientCredentials() api.ClientCredentials {
+	clients := make(api.ClientCredentials, len(c.Clients))
+	for _, client := range c.Clients {
+		if client.ID == "" || client.Secret == "" {
+			continue
+		}
+		clients[client.ID] = client.Secret
+	}
+	return clients
 }
 
 func Load(path string) (*AppConfig, error) {
@@ -68,7 +642,11 @@ func Load(path string) (*AppConfig, error) {
 	}
 
 	var cfg AppConfig
-	if err := v.Unmarshal(&cfg); err != nil {
+	// Keys in the YAML files are snake_case, so decode using the yaml tags
+	// instead of mapstructure's default field-name matching.
+	if err := v.Unmarshal(&cfg, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "yaml"
+	}); err != nil {
 		return nil, err
 	}
 	return &cfg, nil
@@ -9,7 +9,7 @@ import (
 	"strings"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
+	"beerdosan-backend/internal/pkg/password"
 )
 
 var (
@@ -27,12 +27,12 @@ func NewHashedPassword(plainPassword string) (HashedPassword, error) {
 		return "", ErrInvalidPassword
 	}
 
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.DefaultCost)
+	hashed, err := password.DefaultHasher().Hash(plainPassword)
 	if err != nil {
 		return "", err
 	}
 
-	return HashedPassword(hashedBytes), nil
+	return HashedPassword(hashed), nil
 }
 
 func NewHashedPasswordFromHash(hash string) (HashedPassword, error) {
@@ -43,8 +43,12 @@ func NewHashedPasswordFromHash(hash string) (HashedPassword, error) {
 }
 
 func (hp HashedPassword) VerifyPassword(plainPassword string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hp), []byte(plainPassword))
-	return err == nil
+	return password.DefaultHasher().Verify(password.HashedPassword(hp), plainPassword)
+}
+
+// NeedsRehash reports whether the hash uses an outdated algorithm or cost.
+func (hp HashedPassword) NeedsRehash() bool {
+	return password.DefaultHasher().NeedsRehash(password.HashedPassword(hp))
 }
 
 func (hp HashedPassword) String() string {
@@ -205,13 +209,35 @@ func (rtv RefreshTokenValue) IsEmpty() bool {
 	return string(rtv) == ""
 }
 
+// Scope limits what an access token may be used for.
+type Scope string
+
+const (
+	ScopeFullAccess Scope = "full_access"
+	// ScopePasswordChange is the only scope granted while the user must
+	// choose a new password.
+	ScopePasswordChange Scope = "password:change"
+)
+
+func (s Scope) String() string {
+	return string(s)
+}
+
+// TokenClaims are the verified claims of a token. Claims read from a legacy
+// token (Legacy set) carry no role; callers must take it from the user.
 type TokenClaims struct {
 	UserID    string    `json:"user_id"`
 	SessionID string    `json:"session_id"`
 	Role      string    `json:"role"`
+	Scopes    []Scope   `json:"scopes"`
 	TokenType string    `json:"token_type"`
+	Version   int       `json:"version"`
+	Legacy    bool      `json:"legacy"`
 	ExpiresAt time.Time `json:"expires_at"`
 	IssuedAt  time.Time `json:"issued_at"`
+	// AuthTime is when the user last authenticated; zero for tokens issued
+	// before it was recorded.
+	AuthTime time.Time `json:"auth_time"`
 }
 
 func (tc *TokenClaims) IsExpired() bool {
@@ -225,3 +251,12 @@ func (tc *TokenClaims) IsAccessToken() bool {
 func (tc *TokenClaims) IsRefreshToken() bool {
 	return tc.TokenType == string(TokenTypeRefresh)
 }
+
+func (tc *TokenClaims) HasScope(scope Scope) bool {
+	for _, s := range tc.Scopes {
+		if s == scope || s == ScopeFullAccess {
+			return true
+		}
+	}
+	return false
+}
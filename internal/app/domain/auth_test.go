@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestHashedPassword(t *testing.T) {
@@ -33,6 +34,21 @@ func TestHashedPassword(t *testing.T) {
 		_, err = domain.NewHashedPasswordFromHash("")
 		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
 	})
+
+	t.Run("NeedsRehash", func(t *testing.T) {
+		// Arrange
+		bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		require.NoError(t, err)
+		legacy, err := domain.NewHashedPasswordFromHash(string(bcryptHash))
+		require.NoError(t, err)
+		current, err := domain.NewHashedPassword("password123")
+		require.NoError(t, err)
+
+		// Assert
+		assert.True(t, legacy.VerifyPassword("password123"))
+		assert.True(t, legacy.NeedsRehash())
+		assert.False(t, current.NeedsRehash())
+	})
 }
 
 func TestJWT(t *testing.T) {
@@ -178,4 +194,9 @@ func TestTokenClaims(t *testing.T) {
 	claims.TokenType = string(domain.TokenTypeRefresh)
 	assert.False(t, claims.IsAccessToken())
 	assert.True(t, claims.IsRefreshToken())
+
+	assert.False(t, claims.HasScope(domain.ScopeFullAccess))
+	claims.Scopes = []domain.Scope{domain.ScopeFullAccess}
+	assert.True(t, claims.HasScope(domain.ScopeFullAccess))
+	assert.True(t, claims.HasScope(domain.Scope("profile")))
 }
@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidDeviceType  = errors.New("invalid device type")
+	ErrInvalidSessionName = errors.New("invalid session name")
+)
+
+const maxSessionNameLength = 100
+
+type DeviceType string
+
+const (
+	DeviceTypeDesktop DeviceType = "desktop"
+	DeviceTypeMobile  DeviceType = "mobile"
+	DeviceTypeTablet  DeviceType = "tablet"
+	DeviceTypeBot     DeviceType = "bot"
+	DeviceTypeUnknown DeviceType = "unknown"
+)
+
+func NewDeviceType(s string) (DeviceType, error) {
+	deviceType := DeviceType(strings.ToLower(strings.TrimSpace(s)))
+	switch deviceType {
+	case DeviceTypeDesktop, DeviceTypeMobile, DeviceTypeTablet, DeviceTypeBot, DeviceTypeUnknown:
+		return deviceType, nil
+	default:
+		return "", ErrInvalidDeviceType
+	}
+}
+
+func (d DeviceType) String() string {
+	return string(d)
+}
+
+// Device is the structured form of a user agent string.
+type Device struct {
+	browser        string
+	browserVersion string
+	os             string
+	osVersion      string
+	deviceType     DeviceType
+}
+
+func ReconstructDevice(browser, browserVersion, os, osVersion, deviceType string) (Device, error) {
+	deviceTypeVO, err := NewDeviceType(deviceType)
+	if err != nil {
+		return Device{}, err
+	}
+
+	return Device{
+		browser:        browser,
+		browserVersion: browserVersion,
+		os:             os,
+		osVersion:      osVersion,
+		deviceType:     deviceTypeVO,
+	}, nil
+}
+
+func (d Device) Browser() string {
+	return d.browser
+}
+
+func (d Device) BrowserVersion() string {
+	return d.browserVersion
+}
+
+func (d Device) OS() string {
+	return d.os
+}
+
+func (d Device) OSVersion() string {
+	return d.osVersion
+}
+
+func (d Device) Type() DeviceType {
+	return d.deviceType
+}
+
+// String renders a short human readable label such as "Chrome 120 on macOS".
+func (d Device) String() string {
+	browser := d.browser
+	if major := majorVersion(d.browserVersion); browser != "" && major != "" {
+		browser += " " + major
+	}
+
+	switch {
+	case browser != "" && d.os != "":
+		return browser + " on " + d.os
+	case browser != "":
+		return browser
+	case d.os != "":
+		return d.os
+	default:
+		return "Unknown device"
+	}
+}
+
+type uaPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Order matters: several browsers embed the tokens of the engines they are
+// built on, e.g. Edge and Opera also send "Chrome/" and "Safari/".
+var browserPatterns = []uaPattern{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/([\d.]+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+	{"Internet Explorer", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)([\d.]+)`)},
+	{"curl", regexp.MustCompile(`^curl/([\d.]+)`)},
+}
+
+var osPatterns = []uaPattern{
+	{"iOS", regexp.MustCompile(`(?:iPhone|CPU) OS ([\d_]+)`)},
+	{"Android", regexp.MustCompile(`Android ([\d.]+)`)},
+	{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+	{"macOS", regexp.MustCompile(`Mac OS X ([\d_.]+)`)},
+	{"ChromeOS", regexp.MustCompile(`CrOS \S+ ([\d.]+)`)},
+	{"Linux", regexp.MustCompile(`Linux()`)},
+}
+
+var windowsVersions = map[string]string{
+	"10.0": "10",
+	"6.3":  "8.1",
+	"6.2":  "8",
+	"6.1":  "7",
+}
+
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp`)
+
+// ParseUserAgent extracts browser, operating system and device type from a
+// user agent string. Unrecognised parts are left empty and the device type
+// falls back to DeviceTypeUnknown.
+func ParseUserAgent(userAgent string) Device {
+	device := Device{deviceType: DeviceTypeUnknown}
+
+	for _, p := range browserPatterns {
+		if m := p.re.FindStringSubmatch(userAgent); m != nil {
+			device.browser = p.name
+			device.browserVersion = m[1]
+			break
+		}
+	}
+
+	for _, p := range osPatterns {
+		if m := p.re.FindStringSubmatch(userAgent); m != nil {
+			device.os = p.name
+			device.osVersion = strings.ReplaceAll(m[1], "_", ".")
+			break
+		}
+	}
+
+	if device.os == "Windows" {
+		if version, ok := windowsVersions[device.osVersion]; ok {
+			device.osVersion = version
+		}
+	}
+
+	switch {
+	case botPattern.MatchString(userAgent):
+		device.deviceType = DeviceTypeBot
+	case strings.Contains(userAgent, "iPad") || strings.Contains(userAgent, "Tablet") ||
+		(device.os == "Android" && !strings.Contains(userAgent, "Mobile")):
+		device.deviceType = DeviceTypeTablet
+	case strings.Contains(userAgent, "Mobi") || strings.Contains(userAgent, "iPhone"):
+		device.deviceType = DeviceTypeMobile
+	case device.os == "Windows" || device.os == "macOS" || device.os == "Linux" || device.os == "ChromeOS":
+		device.deviceType = DeviceTypeDesktop
+	}
+
+	// iPads report the iPhone OS token as "CPU OS", which the iOS pattern
+	// already covers, but the OS is better known as iPadOS.
+	if device.deviceType == DeviceTypeTablet && device.os == "iOS" {
+		device.os = "iPadOS"
+	}
+
+	return device
+}
+
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+// SessionName is an optional label chosen by the user, e.g. "Work laptop".
+// The zero value means the session has not been named.
+type SessionName string
+
+func NewSessionName(name string) (SessionName, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > maxSessionNameLength {
+		return "", ErrInvalidSessionName
+	}
+
+	return SessionName(name), nil
+}
+
+func (n SessionName) String() string {
+	return string(n)
+}
+
+func (n SessionName) IsEmpty() bool {
+	return n == ""
+}
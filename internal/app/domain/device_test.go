@@ -0,0 +1,140 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	testCases := []struct {
+		name           string
+		userAgent      string
+		browser        string
+		browserVersion string
+		os             string
+		osVersion      string
+		deviceType     domain.DeviceType
+		label          string
+	}{
+		{
+			"chrome on windows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.109 Safari/537.36",
+			"Chrome", "120.0.6099.109", "Windows", "10", domain.DeviceTypeDesktop, "Chrome 120 on Windows",
+		},
+		{
+			"edge on windows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.91",
+			"Edge", "120.0.2210.91", "Windows", "10", domain.DeviceTypeDesktop, "Edge 120 on Windows",
+		},
+		{
+			"safari on macos",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+			"Safari", "17.1", "macOS", "10.15.7", domain.DeviceTypeDesktop, "Safari 17 on macOS",
+		},
+		{
+			"firefox on linux",
+			"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0",
+			"Firefox", "121.0", "Linux", "", domain.DeviceTypeDesktop, "Firefox 121 on Linux",
+		},
+		{
+			"safari on iphone",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1.2 Mobile/15E148 Safari/604.1",
+			"Safari", "17.1.2", "iOS", "17.1.2", domain.DeviceTypeMobile, "Safari 17 on iOS",
+		},
+		{
+			"safari on ipad",
+			"Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+			"Safari", "16.6", "iPadOS", "16.6", domain.DeviceTypeTablet, "Safari 16 on iPadOS",
+		},
+		{
+			"chrome on android phone",
+			"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.144 Mobile Safari/537.36",
+			"Chrome", "120.0.6099.144", "Android", "14", domain.DeviceTypeMobile, "Chrome 120 on Android",
+		},
+		{
+			"samsung internet on android tablet",
+			"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Safari/537.36",
+			"Samsung Internet", "23.0", "Android", "13", domain.DeviceTypeTablet, "Samsung Internet 23 on Android",
+		},
+		{
+			"googlebot",
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			"", "", "", "", domain.DeviceTypeBot, "Unknown device",
+		},
+		{
+			"curl",
+			"curl/8.4.0",
+			"curl", "8.4.0", "", "", domain.DeviceTypeUnknown, "curl 8",
+		},
+		{
+			"unrecognised",
+			"test-user-agent",
+			"", "", "", "", domain.DeviceTypeUnknown, "Unknown device",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			device := domain.ParseUserAgent(tc.userAgent)
+
+			// Assert
+			assert.Equal(t, tc.browser, device.Browser())
+			assert.Equal(t, tc.browserVersion, device.BrowserVersion())
+			assert.Equal(t, tc.os, device.OS())
+			assert.Equal(t, tc.osVersion, device.OSVersion())
+			assert.Equal(t, tc.deviceType, device.Type())
+			assert.Equal(t, tc.label, device.String())
+		})
+	}
+}
+
+func TestReconstructDevice(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		device, err := domain.ReconstructDevice("Chrome", "120.0", "Windows", "10", "desktop")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Chrome", device.Browser())
+		assert.Equal(t, domain.DeviceTypeDesktop, device.Type())
+	})
+
+	t.Run("failure: invalid device type", func(t *testing.T) {
+		_, err := domain.ReconstructDevice("Chrome", "120.0", "Windows", "10", "toaster")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidDeviceType)
+	})
+}
+
+func TestNewSessionName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		want      domain.SessionName
+		expectErr error
+	}{
+		{"success: trimmed", "  Work laptop  ", "Work laptop", nil},
+		{"success: empty clears the name", "", "", nil},
+		{"success: max length", strings.Repeat("a", 100), domain.SessionName(strings.Repeat("a", 100)), nil},
+		{"success: multibyte characters counted as one", strings.Repeat("é", 100), domain.SessionName(strings.Repeat("é", 100)), nil},
+		{"failure: too long", strings.Repeat("a", 101), "", domain.ErrInvalidSessionName},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := domain.NewSessionName(tc.value)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+				assert.Equal(t, string(tc.want), got.String())
+			}
+		})
+	}
+}
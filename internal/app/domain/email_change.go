@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidEmailChangeToken = errors.New("invalid email change token")
+)
+
+// EmailChangeToken is the secret in the confirmation link sent to the new
+// address. Only its hash is stored.
+type EmailChangeToken string
+
+func GenerateEmailChangeToken() (EmailChangeToken, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return EmailChangeToken(base64.RawURLEncoding.EncodeToString(bytes)), nil
+}
+
+func NewEmailChangeToken(value string) (EmailChangeToken, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 32 {
+		return "", ErrInvalidEmailChangeToken
+	}
+
+	return EmailChangeToken(value), nil
+}
+
+// Hash returns the hex SHA-256 of the token.
+func (t EmailChangeToken) Hash() string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t EmailChangeToken) String() string {
+	return string(t)
+}
+
+// EmailChange is a request to move an account to a new email address. The
+// address only changes once the link sent to it is followed.
+type EmailChange struct {
+	id         ID
+	userID     UserID
+	oldEmail   NonEmptyString
+	newEmail   NonEmptyString
+	tokenHash  string
+	expiresAt  Timestamp
+	consumedAt *time.Time
+	createdAt  CreatedAt
+}
+
+func NewEmailChange(userID UserID, oldEmail, newEmail string, token EmailChangeToken, expiresAt time.Time) (*EmailChange, error) {
+	oldEmailVO, err := NewNonEmptyString(oldEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	newEmailVO, err := NewNonEmptyString(newEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailChange{
+		userID:    userID,
+		oldEmail:  oldEmailVO,
+		newEmail:  newEmailVO,
+		tokenHash: token.Hash(),
+		expiresAt: expiresAtVO,
+		createdAt: NewCreatedAtNow(),
+	}, nil
+}
+
+func ReconstructEmailChange(
+	id int64,
+	userID, oldEmail, newEmail, tokenHash string,
+	expiresAt time.Time,
+	consumedAt *time.Time,
+	createdAt time.Time,
+) (*EmailChange, error) {
+	idVO, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDVO, err := NewUserIDFromString(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldEmailVO, err := NewNonEmptyString(oldEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	newEmailVO, err := NewNonEmptyString(newEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAtVO, err := NewCreatedAt(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailChange{
+		id:         idVO,
+		userID:     userIDVO,
+		oldEmail:   oldEmailVO,
+		newEmail:   newEmailVO,
+		tokenHash:  tokenHash,
+		expiresAt:  expiresAtVO,
+		consumedAt: consumedAt,
+		createdAt:  createdAtVO,
+	}, nil
+}
+
+func (e *EmailChange) ID() ID {
+	return e.id
+}
+
+func (e *EmailChange) UserID() UserID {
+	return e.userID
+}
+
+func (e *EmailChange) OldEmail() NonEmptyString {
+	return e.oldEmail
+}
+
+func (e *EmailChange) NewEmail() NonEmptyString {
+	return e.newEmail
+}
+
+func (e *EmailChange) TokenHash() string {
+	return e.tokenHash
+}
+
+func (e *EmailChange) ExpiresAt() Timestamp {
+	return e.expiresAt
+}
+
+func (e *EmailChange) ConsumedAt() *time.Time {
+	return e.consumedAt
+}
+
+func (e *EmailChange) CreatedAt() CreatedAt {
+	return e.createdAt
+}
+
+func (e *EmailChange) IsExpired() bool {
+	return time.Now().After(e.expiresAt.Time())
+}
+
+func (e *EmailChange) IsConsumed() bool {
+	return e.consumedAt != nil
+}
+
+// CanConfirm reports whether the change may still be applied.
+func (e *EmailChange) CanConfirm() bool {
+	return !e.IsConsumed() && !e.IsExpired()
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailChange(t *testing.T) {
+	userID := domain.NewUserID()
+
+	token, err := domain.GenerateEmailChangeToken()
+	require.NoError(t, err)
+
+	t.Run("NewEmailChange stores only the token hash", func(t *testing.T) {
+		// Act
+		change, err := domain.NewEmailChange(userID, "old@example.com", "new@example.com", token, time.Now().Add(24*time.Hour))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, token.Hash(), change.TokenHash())
+		assert.NotContains(t, change.TokenHash(), token.String())
+		assert.Equal(t, "old@example.com", change.OldEmail().String())
+		assert.Equal(t, "new@example.com", change.NewEmail().String())
+		assert.True(t, change.CanConfirm())
+	})
+
+	t.Run("expired or consumed changes cannot be confirmed", func(t *testing.T) {
+		// Arrange
+		consumedAt := time.Now()
+		expired, err := domain.ReconstructEmailChange(1, userID.String(), "old@example.com", "new@example.com", token.Hash(),
+			time.Now().Add(-time.Minute), nil, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		consumed, err := domain.ReconstructEmailChange(2, userID.String(), "old@example.com", "new@example.com", token.Hash(),
+			time.Now().Add(time.Hour), &consumedAt, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.True(t, expired.IsExpired())
+		assert.False(t, expired.CanConfirm())
+		assert.True(t, consumed.IsConsumed())
+		assert.False(t, consumed.CanConfirm())
+	})
+
+	t.Run("NewEmailChangeToken rejects short values", func(t *testing.T) {
+		// Act
+		_, err := domain.NewEmailChangeToken("short")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidEmailChangeToken)
+	})
+}
@@ -89,3 +89,77 @@ func (r UserRole) IsUser() bool {
 func (r UserRole) IsGuest() bool {
 	return r == UserRoleGuest
 }
+
+var (
+	ErrInvalidSessionLimitPolicy = errors.New("invalid session limit policy")
+)
+
+// SessionLimitPolicy decides what happens when a user already holds the
+// maximum number of active sessions and logs in again.
+type SessionLimitPolicy string
+
+const (
+	SessionLimitPolicyReject      SessionLimitPolicy = "reject"
+	SessionLimitPolicyEvictOldest SessionLimitPolicy = "evict_oldest"
+)
+
+func NewSessionLimitPolicy(s string) (SessionLimitPolicy, error) {
+	policy := SessionLimitPolicy(strings.ToLower(strings.TrimSpace(s)))
+	switch policy {
+	case SessionLimitPolicyReject, SessionLimitPolicyEvictOldest:
+		return policy, nil
+	default:
+		return "", ErrInvalidSessionLimitPolicy
+	}
+}
+
+func (p SessionLimitPolicy) String() string {
+	return string(p)
+}
+
+func (p SessionLimitPolicy) IsReject() bool {
+	return p == SessionLimitPolicyReject
+}
+
+func (p SessionLimitPolicy) IsEvictOldest() bool {
+	return p == SessionLimitPolicyEvictOldest
+}
+
+var (
+	ErrInvalidOTPChannel = errors.New("invalid one-time passcode channel")
+)
+
+// OTPChannel is how a one-time passcode reaches the user.
+type OTPChannel string
+
+const (
+	OTPChannelEmail OTPChannel = "email"
+	OTPChannelSMS   OTPChannel = "sms"
+)
+
+func NewOTPChannel(s string) (OTPChannel, error) {
+	channel := OTPChannel(strings.ToLower(strings.TrimSpace(s)))
+	switch channel {
+	case OTPChannelEmail, OTPChannelSMS:
+		return channel, nil
+	default:
+		return "", ErrInvalidOTPChannel
+	}
+}
+
+func (c OTPChannel) String() string {
+	return string(c)
+}
+
+// OTPPurpose keeps codes issued for one flow from being accepted by another.
+type OTPPurpose string
+
+const (
+	OTPPurposeLogin             OTPPurpose = "login"
+	OTPPurposePhoneVerification OTPPurpose = "phone_verification"
+	OTPPurposeReauthentication  OTPPurpose = "reauthentication"
+)
+
+func (p OTPPurpose) String() string {
+	return string(p)
+}
@@ -96,3 +96,39 @@ func TestUserRoleMethods(t *testing.T) {
 	assert.True(t, domain.UserRoleGuest.IsGuest())
 	assert.False(t, domain.UserRoleAdmin.IsGuest())
 }
+
+func TestNewSessionLimitPolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		want      domain.SessionLimitPolicy
+		expectErr error
+	}{
+		{"failure: invalid policy", "evict_newest", "", domain.ErrInvalidSessionLimitPolicy},
+		{"failure: empty", "", "", domain.ErrInvalidSessionLimitPolicy},
+		{"success: reject", "reject", domain.SessionLimitPolicyReject, nil},
+		{"success: evict oldest", "evict_oldest", domain.SessionLimitPolicyEvictOldest, nil},
+		{"success: case-insensitivity", "  Evict_Oldest  ", domain.SessionLimitPolicyEvictOldest, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := domain.NewSessionLimitPolicy(tc.value)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+				assert.Equal(t, string(tc.want), got.String())
+			}
+		})
+	}
+}
+
+func TestSessionLimitPolicyMethods(t *testing.T) {
+	assert.True(t, domain.SessionLimitPolicyReject.IsReject())
+	assert.False(t, domain.SessionLimitPolicyReject.IsEvictOldest())
+	assert.True(t, domain.SessionLimitPolicyEvictOldest.IsEvictOldest())
+	assert.False(t, domain.SessionLimitPolicyEvictOldest.IsReject())
+}
@@ -56,4 +56,17 @@ var (
 	ErrSessionNotFound       = DefineError(ErrCatAuth, "SESSION_NOT_FOUND", "session not found")
 	ErrInvalidSession        = DefineError(ErrCatAuth, "INVALID_SESSION", "session is invalid")
 	ErrRefreshTokenExpired   = DefineError(ErrCatAuth, "REFRESH_TOKEN_EXPIRED", "refresh token has expired")
+	ErrSessionLimitReached   = DefineError(ErrCatBusiness, "SESSION_LIMIT_REACHED", "maximum number of active sessions reached")
+	ErrPasswordReused        = DefineError(ErrCatValidation, "PASSWORD_REUSED", "password matches a recently used password")
+	ErrMagicLinkInvalid      = DefineError(ErrCatAuth, "MAGIC_LINK_INVALID", "magic link is invalid or has expired")
+	ErrMagicLinkWrongBrowser = DefineError(ErrCatAuth, "MAGIC_LINK_WRONG_BROWSER", "magic link must be opened in the browser that requested it")
+	ErrOTPInvalid            = DefineError(ErrCatAuth, "OTP_INVALID", "code is invalid or has expired")
+	ErrOTPAttemptsExceeded   = DefineError(ErrCatAuth, "OTP_ATTEMPTS_EXCEEDED", "too many wrong codes, request a new one")
+	ErrOTPResendTooSoon      = DefineError(ErrCatBusiness, "OTP_RESEND_TOO_SOON", "a code was sent recently, wait before requesting another")
+	ErrPhoneNumberTaken      = DefineError(ErrCatBusiness, "PHONE_NUMBER_TAKEN", "phone number is already verified by another account")
+	ErrEmailTaken            = DefineError(ErrCatBusiness, "EMAIL_TAKEN", "email address is already used by another account")
+	ErrEmailChangeInvalid    = DefineError(ErrCatValidation, "EMAIL_CHANGE_INVALID", "email change link is invalid or has expired")
+	ErrUsernameTaken         = DefineError(ErrCatBusiness, "USERNAME_TAKEN", "username is already used by another account")
+	ErrInvitationNotFound    = DefineError(ErrCatBusiness, "INVITATION_NOT_FOUND", "invitation not found")
+	ErrInvitationInvalid     = DefineError(ErrCatValidation, "INVITATION_INVALID", "invitation is invalid, revoked or has expired")
 )
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrInvalidEmail    = errors.New("invalid email address")
+	ErrInvalidUsername = errors.New("username must not contain @")
+)
+
+// maxEmailLength is the longest address SMTP can deliver to.
+const maxEmailLength = 254
+
+// Email is a lower-cased, NFC-normalised email address. Addresses are
+// compared case-insensitively everywhere, so storing them folded keeps one
+// spelling per account.
+type Email string
+
+func NewEmail(s string) (Email, error) {
+	s = strings.ToLower(norm.NFC.String(strings.TrimSpace(s)))
+	if s == "" || len(s) > maxEmailLength {
+		return "", ErrInvalidEmail
+	}
+
+	// ParseAddress also accepts "Name <addr>"; only a bare address will do.
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return "", ErrInvalidEmail
+	}
+
+	return Email(s), nil
+}
+
+// ReconstructEmail wraps an address loaded from storage without validating
+// it. Rows written before NewEmail became strict may not pass it, and must
+// still load.
+func ReconstructEmail(s string) Email {
+	return Email(s)
+}
+
+func (e Email) String() string {
+	return string(e)
+}
+
+// NormalizeUsername folds compatibility characters (NFKC) and case, so that
+// "Ｂob" and "bob" name the same account.
+func NormalizeUsername(s string) string {
+	return strings.ToLower(norm.NFKC.String(strings.TrimSpace(s)))
+}
+
+// NormalizeLoginIdentifier returns the canonical form of a username or email
+// address typed at login.
+func NormalizeLoginIdentifier(s string) string {
+	if strings.Contains(s, "@") {
+		if email, err := NewEmail(s); err == nil {
+			return email.String()
+		}
+	}
+	return NormalizeUsername(s)
+}
@@ -0,0 +1,89 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected domain.Email
+		wantErr  bool
+	}{
+		{name: "plain", input: "alice@example.com", expected: "alice@example.com"},
+		{name: "case and spaces are folded", input: "  Alice@Example.COM ", expected: "alice@example.com"},
+		{name: "decomposed accent is composed", input: "jose\u0301@example.com", expected: "jos\u00e9@example.com"},
+		{name: "display name", input: "Alice <alice@example.com>", wantErr: true},
+		{name: "missing domain", input: "alice@", wantErr: true},
+		{name: "missing at", input: "alice.example.com", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			email, err := domain.NewEmail(tt.input)
+
+			// Assert
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidEmail)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, email)
+		})
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	assert.Equal(t, "bob", domain.NormalizeUsername(" Bob "))
+	assert.Equal(t, "bob", domain.NormalizeUsername("Ｂob"), "fullwidth letters fold to ASCII")
+}
+
+func TestNormalizeLoginIdentifier(t *testing.T) {
+	assert.Equal(t, "alice@example.com", domain.NormalizeLoginIdentifier("Alice@Example.com"))
+	assert.Equal(t, "alice", domain.NormalizeLoginIdentifier("ALICE"))
+}
+
+func TestNewUser_NormalizesIdentity(t *testing.T) {
+	// Act
+	user, err := domain.NewUser("Alice", "Alice@Example.com", "Alice", "User", "password123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.NonEmptyString("alice"), user.Username())
+	assert.Equal(t, domain.Email("alice@example.com"), user.Email())
+}
+
+func TestNewUser_RejectsUsernameWithAt(t *testing.T) {
+	// Act
+	user, err := domain.NewUser("alice@example.com", "alice@example.com", "Alice", "User", "password123")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidUsername)
+	assert.Nil(t, user)
+}
+
+func TestReconstructUser_KeepsStoredEmailThatNewEmailRejects(t *testing.T) {
+	// Arrange
+	hashedPassword, err := domain.NewHashedPassword("password123")
+	require.NoError(t, err)
+	now := time.Now()
+
+	// Act
+	user, err := domain.ReconstructUser(
+		domain.NewUserID().String(), "legacy", "legacy user@example.com", "Legacy", "User",
+		hashedPassword.String(), "user", "active", "", false, false, now, nil, now, now,
+	)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.Email("legacy user@example.com"), user.Email())
+}
@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInvitationToken = errors.New("invalid invitation token")
+)
+
+// InvitationToken is the secret in the link emailed to an invitee. Only its
+// hash is stored.
+type InvitationToken string
+
+func GenerateInvitationToken() (InvitationToken, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return InvitationToken(base64.RawURLEncoding.EncodeToString(bytes)), nil
+}
+
+func NewInvitationToken(value string) (InvitationToken, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 32 {
+		return "", ErrInvalidInvitationToken
+	}
+
+	return InvitationToken(value), nil
+}
+
+// Hash returns the hex SHA-256 of the token.
+func (t InvitationToken) Hash() string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t InvitationToken) String() string {
+	return string(t)
+}
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	InvitationStatusExpired  InvitationStatus = "expired"
+)
+
+func (s InvitationStatus) String() string {
+	return string(s)
+}
+
+// Invitation lets the person at an email address create an account with a
+// role chosen by the admin who invited them.
+type Invitation struct {
+	id         ID
+	email      Email
+	role       UserRole
+	invitedBy  UserID
+	tokenHash  string
+	expiresAt  Timestamp
+	acceptedAt *time.Time
+	revokedAt  *time.Time
+	createdAt  CreatedAt
+}
+
+func NewInvitation(email string, role UserRole, invitedBy UserID, token InvitationToken, expiresAt time.Time) (*Invitation, error) {
+	emailVO, err := NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	roleVO, err := NewUserRole(role.String())
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invitation{
+		email:     emailVO,
+		role:      roleVO,
+		invitedBy: invitedBy,
+		tokenHash: token.Hash(),
+		expiresAt: expiresAtVO,
+		createdAt: NewCreatedAtNow(),
+	}, nil
+}
+
+func ReconstructInvitation(
+	id int64,
+	email, role, invitedBy, tokenHash string,
+	expiresAt time.Time,
+	acceptedAt, revokedAt *time.Time,
+	createdAt time.Time,
+) (*Invitation, error) {
+	idVO, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	emailVO := ReconstructEmail(email)
+
+	roleVO, err := NewUserRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	invitedByVO, err := NewUserIDFromString(invitedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAtVO, err := NewCreatedAt(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invitation{
+		id:         idVO,
+		email:      emailVO,
+		role:       roleVO,
+		invitedBy:  invitedByVO,
+		tokenHash:  tokenHash,
+		expiresAt:  expiresAtVO,
+		acceptedAt: acceptedAt,
+		revokedAt:  revokedAt,
+		createdAt:  createdAtVO,
+	}, nil
+}
+
+func (i *Invitation) ID() ID {
+	return i.id
+}
+
+func (i *Invitation) Email() Email {
+	return i.email
+}
+
+func (i *Invitation) Role() UserRole {
+	return i.role
+}
+
+func (i *Invitation) InvitedBy() UserID {
+	return i.invitedBy
+}
+
+func (i *Invitation) TokenHash() string {
+	return i.tokenHash
+}
+
+func (i *Invitation) ExpiresAt() Timestamp {
+	return i.expiresAt
+}
+
+func (i *Invitation) AcceptedAt() *time.Time {
+	return i.acceptedAt
+}
+
+func (i *Invitation) RevokedAt() *time.Time {
+	return i.revokedAt
+}
+
+func (i *Invitation) CreatedAt() CreatedAt {
+	return i.createdAt
+}
+
+func (i *Invitation) Status() InvitationStatus {
+	switch {
+	case i.acceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.revokedAt != nil:
+		return InvitationStatusRevoked
+	case time.Now().After(i.expiresAt.Time()):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// CanAccept reports whether the invitation may still be used to create an
+// account.
+func (i *Invitation) CanAccept() bool {
+	return i.Status() == InvitationStatusPending
+}
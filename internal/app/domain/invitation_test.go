@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitation(t *testing.T) {
+	inviter := domain.NewUserID()
+
+	token, err := domain.GenerateInvitationToken()
+	require.NoError(t, err)
+
+	t.Run("NewInvitation normalises the email and stores only the token hash", func(t *testing.T) {
+		// Act
+		invitation, err := domain.NewInvitation("New.Hire@Example.com", domain.UserRoleAdmin, inviter, token, time.Now().Add(time.Hour))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, domain.Email("new.hire@example.com"), invitation.Email())
+		assert.Equal(t, domain.UserRoleAdmin, invitation.Role())
+		assert.Equal(t, inviter, invitation.InvitedBy())
+		assert.Equal(t, token.Hash(), invitation.TokenHash())
+		assert.Equal(t, domain.InvitationStatusPending, invitation.Status())
+		assert.True(t, invitation.CanAccept())
+	})
+
+	t.Run("NewInvitation rejects unknown roles", func(t *testing.T) {
+		// Act
+		_, err := domain.NewInvitation("new@example.com", domain.UserRole("owner"), inviter, token, time.Now().Add(time.Hour))
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("accepted, revoked and expired invitations cannot be accepted", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		accepted, err := domain.ReconstructInvitation(1, "a@example.com", "user", inviter.String(), token.Hash(),
+			now.Add(time.Hour), &now, nil, now.Add(-time.Hour))
+		require.NoError(t, err)
+		revoked, err := domain.ReconstructInvitation(2, "b@example.com", "user", inviter.String(), token.Hash(),
+			now.Add(time.Hour), nil, &now, now.Add(-time.Hour))
+		require.NoError(t, err)
+		expired, err := domain.ReconstructInvitation(3, "c@example.com", "user", inviter.String(), token.Hash(),
+			now.Add(-time.Minute), nil, nil, now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.Equal(t, domain.InvitationStatusAccepted, accepted.Status())
+		assert.Equal(t, domain.InvitationStatusRevoked, revoked.Status())
+		assert.Equal(t, domain.InvitationStatusExpired, expired.Status())
+		assert.False(t, accepted.CanAccept())
+		assert.False(t, revoked.CanAccept())
+		assert.False(t, expired.CanAccept())
+	})
+
+	t.Run("NewInvitationToken rejects short values", func(t *testing.T) {
+		// Act
+		_, err := domain.NewInvitationToken("short")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidInvitationToken)
+	})
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidMagicLinkToken = errors.New("invalid magic link token")
+)
+
+// MagicLinkToken is a random secret handed out once, either in the emailed
+// link or in the browser binding cookie. Only its hash is stored.
+type MagicLinkToken string
+
+func GenerateMagicLinkToken() (MagicLinkToken, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return MagicLinkToken(base64.RawURLEncoding.EncodeToString(bytes)), nil
+}
+
+func NewMagicLinkToken(value string) (MagicLinkToken, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 32 {
+		return "", ErrInvalidMagicLinkToken
+	}
+
+	return MagicLinkToken(value), nil
+}
+
+// Hash returns the hex SHA-256 of the token. The token carries 256 bits of
+// entropy, so a fast hash is enough to make a leaked table useless.
+func (t MagicLinkToken) Hash() string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t MagicLinkToken) String() string {
+	return string(t)
+}
+
+// MagicLink is a single-use passwordless login link. It is bound to the
+// browser that asked for it through a second token kept in a cookie there.
+type MagicLink struct {
+	id          ID
+	userID      UserID
+	email       NonEmptyString
+	tokenHash   string
+	bindingHash string
+	ipAddress   IPAddress
+	expiresAt   Timestamp
+	consumedAt  *time.Time
+	createdAt   CreatedAt
+}
+
+func NewMagicLink(
+	userID UserID,
+	email, ipAddress string,
+	token, binding MagicLinkToken,
+	expiresAt time.Time,
+) (*MagicLink, error) {
+	emailVO, err := NewNonEmptyString(email)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddressVO, err := NewIPAddress(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MagicLink{
+		userID:      userID,
+		email:       emailVO,
+		tokenHash:   token.Hash(),
+		bindingHash: binding.Hash(),
+		ipAddress:   ipAddressVO,
+		expiresAt:   expiresAtVO,
+		createdAt:   NewCreatedAtNow(),
+	}, nil
+}
+
+func ReconstructMagicLink(
+	id int64,
+	userID, email, tokenHash, bindingHash, ipAddress string,
+	expiresAt time.Time,
+	consumedAt *time.Time,
+	createdAt time.Time,
+) (*MagicLink, error) {
+	idVO, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDVO, err := NewUserIDFromString(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	emailVO, err := NewNonEmptyString(email)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddressVO, err := NewIPAddress(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAtVO, err := NewCreatedAt(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MagicLink{
+		id:          idVO,
+		userID:      userIDVO,
+		email:       emailVO,
+		tokenHash:   tokenHash,
+		bindingHash: bindingHash,
+		ipAddress:   ipAddressVO,
+		expiresAt:   expiresAtVO,
+		consumedAt:  consumedAt,
+		createdAt:   createdAtVO,
+	}, nil
+}
+
+func (m *MagicLink) ID() ID {
+	return m.id
+}
+
+func (m *MagicLink) UserID() UserID {
+	return m.userID
+}
+
+func (m *MagicLink) Email() NonEmptyString {
+	return m.email
+}
+
+func (m *MagicLink) TokenHash() string {
+	return m.tokenHash
+}
+
+func (m *MagicLink) BindingHash() string {
+	return m.bindingHash
+}
+
+func (m *MagicLink) IPAddress() IPAddress {
+	return m.ipAddress
+}
+
+func (m *MagicLink) ExpiresAt() Timestamp {
+	return m.expiresAt
+}
+
+func (m *MagicLink) ConsumedAt() *time.Time {
+	return m.consumedAt
+}
+
+func (m *MagicLink) CreatedAt() CreatedAt {
+	return m.createdAt
+}
+
+func (m *MagicLink) IsExpired() bool {
+	return time.Now().After(m.expiresAt.Time())
+}
+
+func (m *MagicLink) IsConsumed() bool {
+	return m.consumedAt != nil
+}
+
+// CanConsume reports whether the link may still be exchanged for a session.
+func (m *MagicLink) CanConsume() bool {
+	return !m.IsConsumed() && !m.IsExpired()
+}
+
+// MatchesBinding reports whether binding is the token that was stored in the
+// requesting browser.
+func (m *MagicLink) MatchesBinding(binding MagicLinkToken) bool {
+	return subtle.ConstantTimeCompare([]byte(binding.Hash()), []byte(m.bindingHash)) == 1
+}
@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicLink(t *testing.T) {
+	userID := domain.NewUserID()
+
+	token, err := domain.GenerateMagicLinkToken()
+	require.NoError(t, err)
+	binding, err := domain.GenerateMagicLinkToken()
+	require.NoError(t, err)
+
+	t.Run("NewMagicLink stores only hashes", func(t *testing.T) {
+		// Act
+		link, err := domain.NewMagicLink(userID, "user@example.com", "127.0.0.1", token, binding, time.Now().Add(15*time.Minute))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, token.Hash(), link.TokenHash())
+		assert.NotContains(t, link.TokenHash(), token.String())
+		assert.NotEqual(t, link.TokenHash(), link.BindingHash())
+		assert.True(t, link.CanConsume())
+	})
+
+	t.Run("MatchesBinding", func(t *testing.T) {
+		// Arrange
+		link, err := domain.NewMagicLink(userID, "user@example.com", "127.0.0.1", token, binding, time.Now().Add(15*time.Minute))
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.True(t, link.MatchesBinding(binding))
+		assert.False(t, link.MatchesBinding(token))
+	})
+
+	t.Run("expired or consumed links cannot be consumed", func(t *testing.T) {
+		// Arrange
+		consumedAt := time.Now()
+		expired, err := domain.ReconstructMagicLink(1, userID.String(), "user@example.com", token.Hash(), binding.Hash(), "127.0.0.1",
+			time.Now().Add(-time.Minute), nil, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		consumed, err := domain.ReconstructMagicLink(2, userID.String(), "user@example.com", token.Hash(), binding.Hash(), "127.0.0.1",
+			time.Now().Add(time.Minute), &consumedAt, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.True(t, expired.IsExpired())
+		assert.False(t, expired.CanConsume())
+		assert.True(t, consumed.IsConsumed())
+		assert.False(t, consumed.CanConsume())
+	})
+
+	t.Run("NewMagicLinkToken rejects short values", func(t *testing.T) {
+		// Act
+		_, err := domain.NewMagicLinkToken("short")
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrInvalidMagicLinkToken)
+	})
+}
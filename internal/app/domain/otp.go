@@ -0,0 +1,244 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const OTPCodeLength = 6
+
+var (
+	ErrInvalidOTPCode = errors.New("invalid one-time passcode")
+)
+
+var otpCodeSpace = big.NewInt(1_000_000)
+
+// OTPCode is a six digit one-time passcode.
+type OTPCode string
+
+func GenerateOTPCode() (OTPCode, error) {
+	n, err := rand.Int(rand.Reader, otpCodeSpace)
+	if err != nil {
+		return "", err
+	}
+	return OTPCode(fmt.Sprintf("%06d", n.Int64())), nil
+}
+
+func NewOTPCode(s string) (OTPCode, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != OTPCodeLength {
+		return "", ErrInvalidOTPCode
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidOTPCode
+		}
+	}
+	return OTPCode(s), nil
+}
+
+func (c OTPCode) String() string {
+	return string(c)
+}
+
+// hashOTPCode salts the code so that equal codes do not share a hash. With a
+// million possible codes the hash does not stop an offline search; the short
+// lifetime and the attempt limit are what protect a code.
+func hashOTPCode(salt []byte, code OTPCode) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), code...))
+	return hex.EncodeToString(salt) + ":" + hex.EncodeToString(sum[:])
+}
+
+// OneTimePasscode is a short-lived code sent to the user's email address or
+// phone. It accepts a limited number of guesses and can be used once.
+type OneTimePasscode struct {
+	id          ID
+	userID      UserID
+	purpose     OTPPurpose
+	channel     OTPChannel
+	destination NonEmptyString
+	codeHash    string
+	attempts    int
+	maxAttempts int
+	expiresAt   Timestamp
+	consumedAt  *time.Time
+	createdAt   CreatedAt
+}
+
+func NewOneTimePasscode(
+	userID UserID,
+	purpose OTPPurpose,
+	channel OTPChannel,
+	destination string,
+	code OTPCode,
+	maxAttempts int,
+	expiresAt time.Time,
+) (*OneTimePasscode, error) {
+	destinationVO, err := NewNonEmptyString(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return &OneTimePasscode{
+		userID:      userID,
+		purpose:     purpose,
+		channel:     channel,
+		destination: destinationVO,
+		codeHash:    hashOTPCode(salt, code),
+		maxAttempts: maxAttempts,
+		expiresAt:   expiresAtVO,
+		createdAt:   NewCreatedAtNow(),
+	}, nil
+}
+
+func ReconstructOneTimePasscode(
+	id int64,
+	userID, purpose, channel, destination, codeHash string,
+	attempts, maxAttempts int,
+	expiresAt time.Time,
+	consumedAt *time.Time,
+	createdAt time.Time,
+) (*OneTimePasscode, error) {
+	idVO, err := NewID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDVO, err := NewUserIDFromString(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	channelVO, err := NewOTPChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationVO, err := NewNonEmptyString(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAtVO, err := NewTimestamp(expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAtVO, err := NewCreatedAt(createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OneTimePasscode{
+		id:          idVO,
+		userID:      userIDVO,
+		purpose:     OTPPurpose(purpose),
+		channel:     channelVO,
+		destination: destinationVO,
+		codeHash:    codeHash,
+		attempts:    attempts,
+		maxAttempts: maxAttempts,
+		expiresAt:   expiresAtVO,
+		consumedAt:  consumedAt,
+		createdAt:   createdAtVO,
+	}, nil
+}
+
+func (o *OneTimePasscode) ID() ID {
+	return o.id
+}
+
+func (o *OneTimePasscode) UserID() UserID {
+	return o.userID
+}
+
+func (o *OneTimePasscode) Purpose() OTPPurpose {
+	return o.purpose
+}
+
+func (o *OneTimePasscode) Channel() OTPChannel {
+	return o.channel
+}
+
+func (o *OneTimePasscode) Destination() NonEmptyString {
+	return o.destination
+}
+
+func (o *OneTimePasscode) CodeHash() string {
+	return o.codeHash
+}
+
+func (o *OneTimePasscode) Attempts() int {
+	return o.attempts
+}
+
+func (o *OneTimePasscode) MaxAttempts() int {
+	return o.maxAttempts
+}
+
+func (o *OneTimePasscode) ExpiresAt() Timestamp {
+	return o.expiresAt
+}
+
+func (o *OneTimePasscode) ConsumedAt() *time.Time {
+	return o.consumedAt
+}
+
+func (o *OneTimePasscode) CreatedAt() CreatedAt {
+	return o.createdAt
+}
+
+func (o *OneTimePasscode) IsExpired() bool {
+	return time.Now().After(o.expiresAt.Time())
+}
+
+func (o *OneTimePasscode) IsConsumed() bool {
+	return o.consumedAt != nil
+}
+
+func (o *OneTimePasscode) AttemptsExhausted() bool {
+	return o.attempts >= o.maxAttempts
+}
+
+// CanVerify reports whether the code may still be checked.
+func (o *OneTimePasscode) CanVerify() bool {
+	return !o.IsConsumed() && !o.IsExpired() && !o.AttemptsExhausted()
+}
+
+// Matches reports whether code is the one that was sent. It does not count the
+// attempt; callers record it first so that concurrent guesses are counted too.
+func (o *OneTimePasscode) Matches(code OTPCode) bool {
+	saltHex, _, ok := strings.Cut(o.codeHash, ":")
+	if !ok {
+		return false
+	}
+
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hashOTPCode(salt, code)), []byte(o.codeHash)) == 1
+}
+
+// ResendAt is when another code for the same purpose may be sent.
+func (o *OneTimePasscode) ResendAt(interval time.Duration) time.Time {
+	return o.createdAt.Time().Add(interval)
+}
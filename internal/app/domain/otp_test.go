@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOTPCode(t *testing.T) {
+	for range 100 {
+		// Act
+		code, err := domain.GenerateOTPCode()
+
+		// Assert
+		require.NoError(t, err)
+		_, err = domain.NewOTPCode(code.String())
+		require.NoError(t, err, code)
+	}
+}
+
+func TestNewOTPCode(t *testing.T) {
+	_, err := domain.NewOTPCode(" 012345 ")
+	assert.NoError(t, err)
+
+	for _, invalid := range []string{"", "12345", "1234567", "12a456"} {
+		_, err := domain.NewOTPCode(invalid)
+		assert.ErrorIs(t, err, domain.ErrInvalidOTPCode, invalid)
+	}
+}
+
+func TestOneTimePasscode(t *testing.T) {
+	userID := domain.NewUserID()
+	code, err := domain.NewOTPCode("482913")
+	require.NoError(t, err)
+
+	t.Run("Matches", func(t *testing.T) {
+		// Arrange
+		otp, err := domain.NewOneTimePasscode(userID, domain.OTPPurposeLogin, domain.OTPChannelEmail, "user@example.com", code, 5, time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.True(t, otp.Matches(code))
+		assert.False(t, otp.Matches("482914"))
+		assert.NotContains(t, otp.CodeHash(), code.String())
+		assert.True(t, otp.CanVerify())
+	})
+
+	t.Run("equal codes do not share a hash", func(t *testing.T) {
+		// Arrange
+		first, err := domain.NewOneTimePasscode(userID, domain.OTPPurposeLogin, domain.OTPChannelEmail, "user@example.com", code, 5, time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+		second, err := domain.NewOneTimePasscode(userID, domain.OTPPurposeLogin, domain.OTPChannelEmail, "user@example.com", code, 5, time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first.CodeHash(), second.CodeHash())
+	})
+
+	t.Run("exhausted, expired or consumed codes cannot be verified", func(t *testing.T) {
+		// Arrange
+		now := time.Now()
+		hash := mustOTPHash(t, userID, code)
+
+		exhausted, err := domain.ReconstructOneTimePasscode(1, userID.String(), "login", "sms", "+14155552671", hash, 5, 5, now.Add(time.Minute), nil, now)
+		require.NoError(t, err)
+		expired, err := domain.ReconstructOneTimePasscode(2, userID.String(), "login", "sms", "+14155552671", hash, 0, 5, now.Add(-time.Second), nil, now.Add(-time.Minute))
+		require.NoError(t, err)
+		consumed, err := domain.ReconstructOneTimePasscode(3, userID.String(), "login", "sms", "+14155552671", hash, 1, 5, now.Add(time.Minute), &now, now)
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.True(t, exhausted.AttemptsExhausted())
+		assert.False(t, exhausted.CanVerify())
+		assert.False(t, expired.CanVerify())
+		assert.False(t, consumed.CanVerify())
+		assert.True(t, exhausted.Matches(code), "the hash survives reconstruction")
+	})
+
+	t.Run("ResendAt", func(t *testing.T) {
+		// Arrange
+		otp, err := domain.NewOneTimePasscode(userID, domain.OTPPurposeLogin, domain.OTPChannelEmail, "user@example.com", code, 5, time.Now().Add(5*time.Minute))
+		require.NoError(t, err)
+
+		// Act & Assert
+		assert.Equal(t, otp.CreatedAt().Time().Add(time.Minute), otp.ResendAt(time.Minute))
+	})
+}
+
+func mustOTPHash(t *testing.T, userID domain.UserID, code domain.OTPCode) string {
+	t.Helper()
+
+	otp, err := domain.NewOneTimePasscode(userID, domain.OTPPurposeLogin, domain.OTPChannelSMS, "+14155552671", code, 5, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	return otp.CodeHash()
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidPhoneNumber = errors.New("invalid phone number, expected E.164 format such as +14155552671")
+)
+
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// phoneNumberSeparators are stripped so that numbers may be typed the way they
+// are usually written, e.g. "+1 (415) 555-2671".
+var phoneNumberSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// PhoneNumber is a phone number in E.164 format: a plus sign, the country code
+// and the subscriber number, at most 15 digits in all.
+type PhoneNumber string
+
+func NewPhoneNumber(s string) (PhoneNumber, error) {
+	s = phoneNumberSeparators.Replace(strings.TrimSpace(s))
+	if !e164Regex.MatchString(s) {
+		return "", ErrInvalidPhoneNumber
+	}
+	return PhoneNumber(s), nil
+}
+
+func (p PhoneNumber) String() string {
+	return string(p)
+}
+
+func (p PhoneNumber) IsEmpty() bool {
+	return string(p) == ""
+}
+
+// Masked hides all but the last four digits, for showing where a code was
+// sent.
+func (p PhoneNumber) Masked() string {
+	s := string(p)
+	if len(s) <= 5 {
+		return s
+	}
+	return "+" + strings.Repeat("*", len(s)-5) + s[len(s)-4:]
+}
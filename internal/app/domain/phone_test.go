@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"testing"
+
+	"beerdosan-backend/internal/app/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPhoneNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected domain.PhoneNumber
+		wantErr  bool
+	}{
+		{name: "E.164", input: "+14155552671", expected: "+14155552671"},
+		{name: "separators are stripped", input: " +1 (415) 555-2671 ", expected: "+14155552671"},
+		{name: "dots", input: "+44.20.7946.0958", expected: "+442079460958"},
+		{name: "missing plus", input: "14155552671", wantErr: true},
+		{name: "leading zero country code", input: "+04155552671", wantErr: true},
+		{name: "too long", input: "+1234567890123456", wantErr: true},
+		{name: "letters", input: "+1415CALLNOW", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			phone, err := domain.NewPhoneNumber(tt.input)
+
+			// Assert
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidPhoneNumber)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, phone)
+		})
+	}
+}
+
+func TestPhoneNumber_Masked(t *testing.T) {
+	// Arrange
+	phone, err := domain.NewPhoneNumber("+14155552671")
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.Equal(t, "+*******2671", phone.Masked())
+}
+
+func TestUser_ChangePhoneNumber(t *testing.T) {
+	// Arrange
+	user, err := domain.NewUser("phone_user", "phone@example.com", "Phone", "User", "password123")
+	require.NoError(t, err)
+	phone, err := domain.NewPhoneNumber("+14155552671")
+	require.NoError(t, err)
+
+	// Act
+	user.ChangePhoneNumber(phone)
+	user.VerifyPhoneNumber()
+
+	// Assert
+	assert.Equal(t, phone, user.PhoneNumber())
+	assert.True(t, user.PhoneNumberVerified())
+
+	other, err := domain.NewPhoneNumber("+442079460958")
+	require.NoError(t, err)
+	user.ChangePhoneNumber(other)
+	assert.False(t, user.PhoneNumberVerified(), "a new number must be verified again")
+}
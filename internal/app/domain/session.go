@@ -12,7 +12,10 @@ type Session struct {
 	deviceFingerprint DeviceFingerprint
 	ipAddress         IPAddress
 	userAgent         NonEmptyString
+	device            Device
+	name              SessionName
 	isActive          bool
+	rememberMe        bool
 	expiresAt         Timestamp
 	refreshExpiresAt  Timestamp
 	createdAt         CreatedAt
@@ -86,6 +89,7 @@ func NewSession(
 		deviceFingerprint: deviceFingerprintVO,
 		ipAddress:         ipAddressVO,
 		userAgent:         userAgentVO,
+		device:            ParseUserAgent(userAgent),
 		isActive:          true,
 		expiresAt:         expiresAtVO,
 		refreshExpiresAt:  refreshExpiresAtVO,
@@ -96,8 +100,9 @@ func NewSession(
 }
 
 func ReconstructSession(
-	id, userID, accessToken, refreshTokenValue, deviceFingerprint, ipAddress, userAgent string,
-	isActive bool,
+	id, userID, accessToken, refreshTokenValue, deviceFingerprint, ipAddress, userAgent, name string,
+	device Device,
+	isActive, rememberMe bool,
 	expiresAt, refreshExpiresAt, createdAt, updatedAt, lastActivity time.Time,
 ) (*Session, error) {
 	idVO, err := NewSessionIDFromString(id)
@@ -135,6 +140,11 @@ func ReconstructSession(
 		return nil, err
 	}
 
+	nameVO, err := NewSessionName(name)
+	if err != nil {
+		return nil, err
+	}
+
 	expiresAtVO, err := NewTimestamp(expiresAt)
 	if err != nil {
 		return nil, err
@@ -168,7 +178,10 @@ func ReconstructSession(
 		deviceFingerprint: deviceFingerprintVO,
 		ipAddress:         ipAddressVO,
 		userAgent:         userAgentVO,
+		device:            device,
+		name:              nameVO,
 		isActive:          isActive,
+		rememberMe:        rememberMe,
 		expiresAt:         expiresAtVO,
 		refreshExpiresAt:  refreshExpiresAtVO,
 		createdAt:         createdAtVO,
@@ -210,10 +223,24 @@ func (s *Session) DeviceInfo() string {
 	return s.userAgent.String()
 }
 
+func (s *Session) Device() Device {
+	return s.device
+}
+
+func (s *Session) Name() SessionName {
+	return s.name
+}
+
 func (s *Session) IsActive() bool {
 	return s.isActive
 }
 
+// RememberMe reports whether the session was created with the long refresh
+// lifetime; refreshes keep issuing tokens for the same mode.
+func (s *Session) RememberMe() bool {
+	return s.rememberMe
+}
+
 func (s *Session) ExpiresAt() Timestamp {
 	return s.expiresAt
 }
@@ -256,6 +283,18 @@ func (s *Session) Deactivate() {
 	s.updatedAt = NewUpdatedAtNow()
 }
 
+// Rename sets the user-chosen label of the session. An empty name clears it.
+// Renaming is not activity, so updatedAt is left alone.
+func (s *Session) Rename(name string) error {
+	nameVO, err := NewSessionName(name)
+	if err != nil {
+		return err
+	}
+
+	s.name = nameVO
+	return nil
+}
+
 func (s *Session) RefreshAccessToken(newAccessToken string, newExpiresAt time.Time) error {
 	if !s.CanRefresh() {
 		return ErrRefreshTokenExpired
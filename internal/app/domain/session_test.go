@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -46,12 +47,17 @@ func TestSession(t *testing.T) {
 		assert.True(t, session.IsValid())
 		assert.True(t, session.CanRefresh())
 		assert.Equal(t, "test-user-agent", session.DeviceInfo())
+		assert.Equal(t, domain.DeviceTypeUnknown, session.Device().Type())
+		assert.True(t, session.Name().IsEmpty())
+		assert.False(t, session.RememberMe())
 	})
 
 	t.Run("ReconstructSession", func(t *testing.T) {
 		// Arrange
 		sessionID := domain.NewSessionID()
 		now := time.Now()
+		device, err := domain.ReconstructDevice("Firefox", "121.0", "Linux", "", "desktop")
+		require.NoError(t, err)
 
 		// Act
 		session, err := domain.ReconstructSession(
@@ -62,6 +68,9 @@ func TestSession(t *testing.T) {
 			string(fp),
 			"192.168.1.1",
 			"reconstructed-ua",
+			"Work laptop",
+			device,
+			true,
 			true,
 			now.Add(1*time.Hour),
 			now.Add(2*time.Hour),
@@ -75,6 +84,9 @@ func TestSession(t *testing.T) {
 		assert.Equal(t, sessionID, session.ID())
 		assert.Equal(t, userID, session.UserID())
 		assert.True(t, session.IsActive())
+		assert.Equal(t, domain.SessionName("Work laptop"), session.Name())
+		assert.Equal(t, device, session.Device())
+		assert.True(t, session.RememberMe())
 	})
 
 	t.Run("Session business methods", func(t *testing.T) {
@@ -105,6 +117,14 @@ func TestSession(t *testing.T) {
 		// MatchesDevice
 		assert.True(t, expiredSession.MatchesDevice(string(fp), "1.1.1.1"))
 		assert.False(t, expiredSession.MatchesDevice("other-fp", "1.1.1.1"))
+
+		// Rename
+		require.NoError(t, expiredSession.Rename("  Work laptop  "))
+		assert.Equal(t, domain.SessionName("Work laptop"), expiredSession.Name())
+		assert.ErrorIs(t, expiredSession.Rename(strings.Repeat("a", 101)), domain.ErrInvalidSessionName)
+		assert.Equal(t, domain.SessionName("Work laptop"), expiredSession.Name())
+		require.NoError(t, expiredSession.Rename(""))
+		assert.True(t, expiredSession.Name().IsEmpty())
 	})
 }
 
@@ -1,31 +1,43 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
 type User struct {
-	id        UserID
-	username  NonEmptyString
-	email     NonEmptyString
-	firstName NonEmptyString
-	lastName  NonEmptyString
-	password  HashedPassword
-	role      UserRole
-	status    Status
-	createdAt CreatedAt
-	updatedAt UpdatedAt
+	id                 UserID
+	username           NonEmptyString
+	email              Email
+	firstName          NonEmptyString
+	lastName           NonEmptyString
+	password           HashedPassword
+	phoneNumber        PhoneNumber
+	phoneVerified      bool
+	passwordChangedAt  time.Time
+	mustChangePassword bool
+	// deletionScheduledAt is when a deletion the user asked for becomes
+	// permanent; nil unless the status is StatusDeleted.
+	deletionScheduledAt *time.Time
+	role                UserRole
+	status              Status
+	createdAt           CreatedAt
+	updatedAt           UpdatedAt
 }
 
 func NewUser(
 	username, email, firstName, lastName, plainPassword string,
 ) (*User, error) {
-	usernameVO, err := NewNonEmptyString(username)
+	usernameVO, err := NewNonEmptyString(NormalizeUsername(username))
 	if err != nil {
 		return nil, err
 	}
+	// Login takes a username or an email address and tells them apart by the @.
+	if strings.Contains(usernameVO.String(), "@") {
+		return nil, ErrInvalidUsername
+	}
 
-	emailVO, err := NewNonEmptyString(email)
+	emailVO, err := NewEmail(email)
 	if err != nil {
 		return nil, err
 	}
@@ -67,21 +79,25 @@ func NewUser(
 	}
 
 	return &User{
-		id:        NewUserID(),
-		username:  usernameVO,
-		email:     emailVO,
-		firstName: firstNameVO,
-		lastName:  lastNameVO,
-		password:  hashedPassword,
-		role:      role,
-		status:    status,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:                NewUserID(),
+		username:          usernameVO,
+		email:             emailVO,
+		firstName:         firstNameVO,
+		lastName:          lastNameVO,
+		password:          hashedPassword,
+		passwordChangedAt: now,
+		role:              role,
+		status:            status,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
 	}, nil
 }
 
 func ReconstructUser(
-	id, username, email, firstName, lastName, hashedPassword, role, status string,
+	id, username, email, firstName, lastName, hashedPassword, role, status, phoneNumber string,
+	phoneVerified, mustChangePassword bool,
+	passwordChangedAt time.Time,
+	deletionScheduledAt *time.Time,
 	createdAt, updatedAt time.Time,
 ) (*User, error) {
 	idVO, err := NewUserIDFromString(id)
@@ -89,15 +105,12 @@ func ReconstructUser(
 		return nil, err
 	}
 
-	usernameVO, err := NewNonEmptyString(username)
+	usernameVO, err := NewNonEmptyString(NormalizeUsername(username))
 	if err != nil {
 		return nil, err
 	}
 
-	emailVO, err := NewNonEmptyString(email)
-	if err != nil {
-		return nil, err
-	}
+	emailVO := ReconstructEmail(email)
 
 	firstNameVO, err := NewNonEmptyString(firstName)
 	if err != nil {
@@ -124,6 +137,14 @@ func ReconstructUser(
 		return nil, err
 	}
 
+	var phoneNumberVO PhoneNumber
+	if phoneNumber != "" {
+		phoneNumberVO, err = NewPhoneNumber(phoneNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	createdAtVO, err := NewCreatedAt(createdAt)
 	if err != nil {
 		return nil, err
@@ -135,16 +156,21 @@ func ReconstructUser(
 	}
 
 	return &User{
-		id:        idVO,
-		username:  usernameVO,
-		email:     emailVO,
-		firstName: firstNameVO,
-		lastName:  lastNameVO,
-		password:  passwordVO,
-		role:      roleVO,
-		status:    statusVO,
-		createdAt: createdAtVO,
-		updatedAt: updatedAtVO,
+		id:                  idVO,
+		username:            usernameVO,
+		email:               emailVO,
+		firstName:           firstNameVO,
+		lastName:            lastNameVO,
+		password:            passwordVO,
+		phoneNumber:         phoneNumberVO,
+		phoneVerified:       phoneVerified && phoneNumberVO != "",
+		passwordChangedAt:   passwordChangedAt,
+		mustChangePassword:  mustChangePassword,
+		deletionScheduledAt: deletionScheduledAt,
+		role:                roleVO,
+		status:              statusVO,
+		createdAt:           createdAtVO,
+		updatedAt:           updatedAtVO,
 	}, nil
 }
 
@@ -156,7 +182,7 @@ func (u *User) Username() NonEmptyString {
 	return u.username
 }
 
-func (u *User) Email() NonEmptyString {
+func (u *User) Email() Email {
 	return u.email
 }
 
@@ -172,6 +198,53 @@ func (u *User) Password() HashedPassword {
 	return u.password
 }
 
+// PhoneNumber is empty when the user has not added one.
+func (u *User) PhoneNumber() PhoneNumber {
+	return u.phoneNumber
+}
+
+// PhoneNumberVerified reports whether the user proved they receive texts at
+// PhoneNumber. Only verified numbers are used to log in.
+func (u *User) PhoneNumberVerified() bool {
+	return u.phoneVerified
+}
+
+// ChangePhoneNumber replaces the phone number, which then needs to be
+// verified again.
+func (u *User) ChangePhoneNumber(phoneNumber PhoneNumber) {
+	if phoneNumber != u.phoneNumber {
+		u.phoneNumber = phoneNumber
+		u.phoneVerified = false
+		u.updatedAt = NewUpdatedAtNow()
+	}
+}
+
+func (u *User) VerifyPhoneNumber() {
+	if !u.phoneNumber.IsEmpty() {
+		u.phoneVerified = true
+		u.updatedAt = NewUpdatedAtNow()
+	}
+}
+
+func (u *User) PasswordChangedAt() time.Time {
+	return u.passwordChangedAt
+}
+
+// MustChangePassword reports whether an administrator required the user to
+// choose a new password before using the account again.
+func (u *User) MustChangePassword() bool {
+	return u.mustChangePassword
+}
+
+// PasswordExpired reports whether the password is older than maxAge. A maxAge
+// of zero or less means passwords never expire.
+func (u *User) PasswordExpired(maxAge time.Duration) bool {
+	if maxAge <= 0 {
+		return false
+	}
+	return time.Since(u.passwordChangedAt) > maxAge
+}
+
 func (u *User) FullName() string {
 	return u.firstName.String() + " " + u.lastName.String()
 }
@@ -233,6 +306,19 @@ func (u *User) UpdateProfile(firstName, lastName string) error {
 	return nil
 }
 
+// ChangeEmail switches the account to a new address. Callers confirm first
+// that the user controls it.
+func (u *User) ChangeEmail(email string) error {
+	emailVO, err := NewEmail(email)
+	if err != nil {
+		return err
+	}
+
+	u.email = emailVO
+	u.updatedAt = NewUpdatedAtNow()
+	return nil
+}
+
 func (u *User) VerifyPassword(plainPassword string) bool {
 	return u.password.VerifyPassword(plainPassword)
 }
@@ -244,10 +330,74 @@ func (u *User) ChangePassword(newPlainPassword string) error {
 	}
 
 	u.password = newHashedPassword
+	u.passwordChangedAt = time.Now()
+	u.mustChangePassword = false
 	u.updatedAt = NewUpdatedAtNow()
 	return nil
 }
 
+// AssignRole gives the user a role, such as the one an invitation was
+// created with.
+func (u *User) AssignRole(role UserRole) {
+	u.role = role
+	u.updatedAt = NewUpdatedAtNow()
+}
+
+// RequirePasswordChange forces the user to set a new password at their next
+// login.
+func (u *User) RequirePasswordChange() {
+	u.mustChangePassword = true
+	u.updatedAt = NewUpdatedAtNow()
+}
+
+// RehashPassword replaces a password hash with outdated parameters by a fresh
+// one. It must only be called with the plain password that was just verified,
+// and reports whether the hash changed.
+func (u *User) RehashPassword(plainPassword string) (bool, error) {
+	if !u.password.NeedsRehash() {
+		return false, nil
+	}
+
+	newHashedPassword, err := NewHashedPassword(plainPassword)
+	if err != nil {
+		return false, err
+	}
+
+	u.password = newHashedPassword
+	u.updatedAt = NewUpdatedAtNow()
+	return true, nil
+}
+
 func (u *User) CanLogin() bool {
 	return u.IsActive()
 }
+
+// DeletionScheduledAt is when the account is purged, or nil when no deletion
+// is pending.
+func (u *User) DeletionScheduledAt() *time.Time {
+	return u.deletionScheduledAt
+}
+
+// DeletionScheduled reports whether the user asked for the account to be
+// deleted and the grace period is still running, during which logging in
+// again cancels the deletion.
+func (u *User) DeletionScheduled() bool {
+	return u.status.IsDeleted() && u.deletionScheduledAt != nil
+}
+
+// ScheduleDeletion marks the account deleted, to be purged at purgeAt.
+func (u *User) ScheduleDeletion(purgeAt time.Time) {
+	u.status = StatusDeleted
+	u.deletionScheduledAt = &purgeAt
+	u.updatedAt = NewUpdatedAtNow()
+}
+
+// CancelDeletion reactivates an account whose deletion is still pending.
+func (u *User) CancelDeletion() {
+	if !u.DeletionScheduled() {
+		return
+	}
+	u.status = StatusActive
+	u.deletionScheduledAt = nil
+	u.updatedAt = NewUpdatedAtNow()
+}
@@ -39,7 +39,7 @@ func TestNewUser(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, user)
 				assert.Equal(t, domain.NonEmptyString(tc.username), user.Username())
-				assert.Equal(t, domain.NonEmptyString(tc.email), user.Email())
+				assert.Equal(t, domain.Email(tc.email), user.Email())
 				assert.Equal(t, tc.firstName+" "+tc.lastName, user.FullName())
 				assert.True(t, user.VerifyPassword(tc.plainPassword))
 				assert.True(t, user.IsActive())
@@ -66,6 +66,11 @@ func TestReconstructUser(t *testing.T) {
 		hashedPassword.String(),
 		"admin",
 		"inactive",
+		"",
+		false,
+		false,
+		now,
+		nil,
 		now,
 		now,
 	)
@@ -131,4 +136,74 @@ func TestUser_BusinessLogic(t *testing.T) {
 		err = user.ChangePassword("short")
 		assert.Error(t, err)
 	})
+
+	t.Run("RehashPassword", func(t *testing.T) {
+		// Act
+		rehashed, err := user.RehashPassword("newPassword456")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, rehashed, "a hash made with the current parameters is kept")
+		assert.True(t, user.VerifyPassword("newPassword456"))
+	})
+}
+
+func TestUser_PasswordExpiry(t *testing.T) {
+	// Arrange
+	hashedPassword, err := domain.NewHashedPassword("password123")
+	require.NoError(t, err)
+	now := time.Now()
+
+	user, err := domain.ReconstructUser(
+		domain.NewUserID().String(),
+		"old_password",
+		"old@example.com",
+		"Old",
+		"Password",
+		hashedPassword.String(),
+		"user",
+		"active",
+		"",
+		false,
+		false,
+		now.Add(-48*time.Hour),
+		nil,
+		now,
+		now,
+	)
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.True(t, user.PasswordExpired(24*time.Hour))
+	assert.False(t, user.PasswordExpired(72*time.Hour))
+	assert.False(t, user.PasswordExpired(0), "a zero max age never expires")
+
+	user.RequirePasswordChange()
+	assert.True(t, user.MustChangePassword())
+
+	require.NoError(t, user.ChangePassword("newPassword456"))
+	assert.False(t, user.MustChangePassword())
+	assert.False(t, user.PasswordExpired(24*time.Hour))
+}
+
+func TestUser_ScheduleDeletion(t *testing.T) {
+	// Arrange
+	user, err := domain.NewUser("leaving", "leaving@example.com", "Leaving", "User", "password123")
+	require.NoError(t, err)
+	purgeAt := time.Now().Add(30 * 24 * time.Hour)
+
+	// Act
+	user.ScheduleDeletion(purgeAt)
+
+	// Assert
+	assert.True(t, user.DeletionScheduled())
+	assert.Equal(t, domain.StatusDeleted, user.Status())
+	assert.False(t, user.CanLogin())
+	require.NotNil(t, user.DeletionScheduledAt())
+	assert.True(t, purgeAt.Equal(*user.DeletionScheduledAt()))
+
+	user.CancelDeletion()
+	assert.False(t, user.DeletionScheduled())
+	assert.Nil(t, user.DeletionScheduledAt())
+	assert.True(t, user.CanLogin())
 }
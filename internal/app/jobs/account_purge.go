@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// AccountPurgeJob anonymises accounts whose deletion grace period has ended
+// and deletes the rest of their data. The grace period is fixed when the
+// deletion is requested, so the Retention setting is not used.
+type AccountPurgeJob struct {
+	userRepo  repositories.UserRepository
+	txManager *database.TransactionManager
+	config    RetentionConfig
+}
+
+func NewAccountPurgeJob(
+	userRepo repositories.UserRepository,
+	txManager *database.TransactionManager,
+	config RetentionConfig,
+) *AccountPurgeJob {
+	return &AccountPurgeJob{
+		userRepo:  userRepo,
+		txManager: txManager,
+		config:    config.Merge(DefaultAccountPurgeConfig()),
+	}
+}
+
+func (j *AccountPurgeJob) Name() string {
+	return "account_purge"
+}
+
+func (j *AccountPurgeJob) Interval() time.Duration {
+	return j.config.Interval
+}
+
+func (j *AccountPurgeJob) Run(ctx context.Context) (int64, error) {
+	ids, err := j.userRepo.FindIDsDueForPurge(ctx, time.Now(), j.config.MaxRowsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find accounts due for purge: %w", err)
+	}
+
+	return deleteInBatches(ctx, j.txManager, j.config.BatchSize, ids, j.purge)
+}
+
+// purge counts only the accounts actually purged; a deletion cancelled since
+// they were found is skipped.
+func (j *AccountPurgeJob) purge(tx *gorm.DB, ids []domain.UserID) (int64, error) {
+	var purged int64
+	for _, id := range ids {
+		ok, err := j.userRepo.PurgeInTx(tx, id)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			purged++
+		}
+	}
+	return purged, nil
+}
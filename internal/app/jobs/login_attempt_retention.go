@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// LoginAttemptRetentionJob deletes login attempts older than the retention
+// period. The retention must stay well above the rate-limit window.
+type LoginAttemptRetentionJob struct {
+	loginAttemptRepo repositories.LoginAttemptRepository
+	txManager        *database.TransactionManager
+	config           RetentionConfig
+}
+
+func NewLoginAttemptRetentionJob(
+	loginAttemptRepo repositories.LoginAttemptRepository,
+	txManager *database.TransactionManager,
+	config RetentionConfig,
+) *LoginAttemptRetentionJob {
+	return &LoginAttemptRetentionJob{
+		loginAttemptRepo: loginAttemptRepo,
+		txManager:        txManager,
+		config:           config.Merge(DefaultLoginAttemptRetentionConfig()),
+	}
+}
+
+func (j *LoginAttemptRetentionJob) Name() string {
+	return "login_attempt_retention"
+}
+
+func (j *LoginAttemptRetentionJob) Interval() time.Duration {
+	return j.config.Interval
+}
+
+func (j *LoginAttemptRetentionJob) Run(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-j.config.Retention)
+
+	ids, err := j.loginAttemptRepo.FindIDsAttemptedBefore(ctx, cutoff, j.config.MaxRowsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired login attempts: %w", err)
+	}
+
+	return deleteInBatches(ctx, j.txManager, j.config.BatchSize, ids, j.loginAttemptRepo.DeleteInTx)
+}
@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/pkg/database"
+)
+
+type RetentionConfig struct {
+	Interval      time.Duration
+	Retention     time.Duration
+	BatchSize     int
+	MaxRowsPerRun int
+}
+
+func DefaultSessionRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		Interval:      time.Hour,
+		Retention:     30 * 24 * time.Hour,
+		BatchSize:     500,
+		MaxRowsPerRun: 10000,
+	}
+}
+
+func DefaultLoginAttemptRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		Interval:      time.Hour,
+		Retention:     90 * 24 * time.Hour,
+		BatchSize:     500,
+		MaxRowsPerRun: 10000,
+	}
+}
+
+// DefaultAccountPurgeConfig purges in small batches, as each account touches
+// several tables.
+func DefaultAccountPurgeConfig() RetentionConfig {
+	return RetentionConfig{
+		Interval:      time.Hour,
+		BatchSize:     50,
+		MaxRowsPerRun: 1000,
+	}
+}
+
+// Merge returns c with every unset field taken from defaults.
+func (c RetentionConfig) Merge(defaults RetentionConfig) RetentionConfig {
+	if c.Interval <= 0 {
+		c.Interval = defaults.Interval
+	}
+	if c.Retention <= 0 {
+		c.Retention = defaults.Retention
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaults.BatchSize
+	}
+	if c.MaxRowsPerRun <= 0 {
+		c.MaxRowsPerRun = defaults.MaxRowsPerRun
+	}
+	return c
+}
+
+// deleteInBatches deletes ids with deleteFn, one call and one transaction per
+// BatchSize ids, so that a large backlog never holds locks for long. It
+// returns the rows deleted by the committed chunks, also when a later chunk
+// fails.
+func deleteInBatches[ID any](
+	ctx context.Context,
+	txManager *database.TransactionManager,
+	batchSize int,
+	ids []ID,
+	deleteFn func(tx *gorm.DB, ids []ID) (int64, error),
+) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Each operation already covers a whole chunk, so commit one at a time.
+	batch := txManager.NewBatch(1)
+	for chunk := range slices.Chunk(ids, max(batchSize, 1)) {
+		batch.AddCounted(func(tx *gorm.DB) (int64, error) {
+			return deleteFn(tx, chunk)
+		})
+	}
+
+	deleted, err := batch.Execute(ctx, txManager)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete in batches: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// SessionRetentionJob deletes sessions that were deactivated, or whose refresh
+// token expired, longer ago than the retention period.
+type SessionRetentionJob struct {
+	sessionRepo repositories.SessionRepository
+	txManager   *database.TransactionManager
+	config      RetentionConfig
+}
+
+func NewSessionRetentionJob(
+	sessionRepo repositories.SessionRepository,
+	txManager *database.TransactionManager,
+	config RetentionConfig,
+) *SessionRetentionJob {
+	return &SessionRetentionJob{
+		sessionRepo: sessionRepo,
+		txManager:   txManager,
+		config:      config.Merge(DefaultSessionRetentionConfig()),
+	}
+}
+
+func (j *SessionRetentionJob) Name() string {
+	return "session_retention"
+}
+
+func (j *SessionRetentionJob) Interval() time.Duration {
+	return j.config.Interval
+}
+
+func (j *SessionRetentionJob) Run(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-j.config.Retention)
+
+	ids, err := j.sessionRepo.FindPurgeableIDs(ctx, cutoff, j.config.MaxRowsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find purgeable sessions: %w", err)
+	}
+
+	return deleteInBatches(ctx, j.txManager, j.config.BatchSize, ids, j.sessionRepo.DeleteInTx)
+}
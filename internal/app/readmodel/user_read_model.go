@@ -12,16 +12,21 @@ type UserListItem struct {
 }
 
 type UserProfile struct {
-	ID        int64     `json:"id"`
+	ID        string    `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
 	FullName  string    `json:"full_name"`
+	Role      string    `json:"role"`
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// PhoneNumber is empty when the user has not added one.
+	PhoneNumber         string `json:"phone_number"`
+	PhoneNumberVerified bool   `json:"phone_number_verified"`
+
 	ProfilePicture *string    `json:"profile_picture,omitempty"`
 	LastLoginAt    *time.Time `json:"last_login_at,omitempty"`
 	PostCount      int64      `json:"post_count"`
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// EmailChangeRepository stores pending email address changes by the hash of
+// their confirmation token.
+type EmailChangeRepository interface {
+	Create(ctx context.Context, change *domain.EmailChange) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChange, error)
+	// MarkConsumed reports whether this call consumed the change.
+	MarkConsumed(ctx context.Context, id domain.ID) (bool, error)
+	// ConsumeAllByUserID retires the user's pending changes, so that only the
+	// newest link works.
+	ConsumeAllByUserID(ctx context.Context, userID domain.UserID) error
+}
+
+type EmailChangeRepositoryGorm struct {
+	db *database.Database
+}
+
+func NewEmailChangeRepository(db *database.Database) *EmailChangeRepositoryGorm {
+	return &EmailChangeRepositoryGorm{db: db}
+}
+
+var _ EmailChangeRepository = (*EmailChangeRepositoryGorm)(nil)
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+)
+
+type EmailChangeModel struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID     string    `gorm:"type:uuid;not null;index"`
+	OldEmail   string    `gorm:"type:varchar(255);not null"`
+	NewEmail   string    `gorm:"type:varchar(255);not null"`
+	TokenHash  string    `gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (EmailChangeModel) TableName() string {
+	return "email_changes"
+}
+
+func (m *EmailChangeModel) ToDomain() (*domain.EmailChange, error) {
+	return domain.ReconstructEmailChange(
+		int64(m.ID),
+		m.UserID,
+		m.OldEmail,
+		m.NewEmail,
+		m.TokenHash,
+		m.ExpiresAt,
+		m.ConsumedAt,
+		m.CreatedAt,
+	)
+}
+
+func (r *EmailChangeRepositoryGorm) Create(ctx context.Context, change *domain.EmailChange) error {
+	model := &EmailChangeModel{
+		UserID:    change.UserID().String(),
+		OldEmail:  change.OldEmail().String(),
+		NewEmail:  change.NewEmail().String(),
+		TokenHash: change.TokenHash(),
+		ExpiresAt: change.ExpiresAt().Time(),
+		CreatedAt: change.CreatedAt().Time(),
+	}
+
+	return r.db.Conn(ctx).Create(model).Error
+}
+
+func (r *EmailChangeRepositoryGorm) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChange, error) {
+	var model EmailChangeModel
+	err := r.db.Conn(ctx).Where("token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+func (r *EmailChangeRepositoryGorm) MarkConsumed(ctx context.Context, id domain.ID) (bool, error) {
+	result := r.db.Conn(ctx).Model(&EmailChangeModel{}).
+		Where("id = ? AND consumed_at IS NULL", id.Value()).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *EmailChangeRepositoryGorm) ConsumeAllByUserID(ctx context.Context, userID domain.UserID) error {
+	return r.db.Conn(ctx).Model(&EmailChangeModel{}).
+		Where("user_id = ? AND consumed_at IS NULL", userID.String()).
+		Update("consumed_at", time.Now()).Error
+}
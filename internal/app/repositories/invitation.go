@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// InvitationRepository stores invitations by the hash of their token.
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error)
+	GetByID(ctx context.Context, id domain.ID) (*domain.Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)
+	// List returns invitations newest first, with the total count.
+	List(ctx context.Context, offset, limit int) ([]*domain.Invitation, int64, error)
+	// Revoke reports whether the invitation was still open.
+	Revoke(ctx context.Context, id domain.ID) (bool, error)
+	// RevokeOpenByEmail revokes the open invitations to an address, so that
+	// only the newest link works.
+	RevokeOpenByEmail(ctx context.Context, email domain.Email) error
+	// MarkAcceptedInTx reports whether this call accepted the invitation, so
+	// that it cannot create two accounts.
+	MarkAcceptedInTx(tx *gorm.DB, id domain.ID) (bool, error)
+}
+
+type InvitationRepositoryGorm struct {
+	db *database.Database
+}
+
+func NewInvitationRepository(db *database.Database) *InvitationRepositoryGorm {
+	return &InvitationRepositoryGorm{db: db}
+}
+
+var _ InvitationRepository = (*InvitationRepositoryGorm)(nil)
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+)
+
+type InvitationModel struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	Email      string    `gorm:"type:varchar(255);not null;index"`
+	Role       string    `gorm:"type:varchar(20);not null"`
+	InvitedBy  string    `gorm:"type:uuid;not null"`
+	TokenHash  string    `gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+func (InvitationModel) TableName() string {
+	return "invitations"
+}
+
+func (m *InvitationModel) ToDomain() (*domain.Invitation, error) {
+	return domain.ReconstructInvitation(
+		int64(m.ID),
+		m.Email,
+		m.Role,
+		m.InvitedBy,
+		m.TokenHash,
+		m.ExpiresAt,
+		m.AcceptedAt,
+		m.RevokedAt,
+		m.CreatedAt,
+	)
+}
+
+// openInvitation matches invitations that were neither accepted nor revoked.
+const openInvitation = "accepted_at IS NULL AND revoked_at IS NULL"
+
+func (r *InvitationRepositoryGorm) Create(ctx context.Context, invitation *domain.Invitation) (*domain.Invitation, error) {
+	model := &InvitationModel{
+		Email:     invitation.Email().String(),
+		Role:      invitation.Role().String(),
+		InvitedBy: invitation.InvitedBy().String(),
+		TokenHash: invitation.TokenHash(),
+		ExpiresAt: invitation.ExpiresAt().Time(),
+		CreatedAt: invitation.CreatedAt().Time(),
+	}
+
+	if err := r.db.Conn(ctx).Create(model).Error; err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+func (r *InvitationRepositoryGorm) GetByID(ctx context.Context, id domain.ID) (*domain.Invitation, error) {
+	return r.getWhere(ctx, "id = ?", id.Value())
+}
+
+func (r *InvitationRepositoryGorm) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	return r.getWhere(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *InvitationRepositoryGorm) getWhere(ctx context.Context, query string, args ...interface{}) (*domain.Invitation, error) {
+	var model InvitationModel
+	err := r.db.Conn(ctx).Where(query, args...).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+func (r *InvitationRepositoryGorm) List(ctx context.Context, offset, limit int) ([]*domain.Invitation, int64, error) {
+	var total int64
+	if err := r.db.Conn(ctx).Model(&InvitationModel{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var models []InvitationModel
+	err := r.db.Conn(ctx).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	invitations := make([]*domain.Invitation, len(models))
+	for i, model := range models {
+		invitation, err := model.ToDomain()
+		if err != nil {
+			return nil, 0, err
+		}
+		invitations[i] = invitation
+	}
+
+	return invitations, total, nil
+}
+
+func (r *InvitationRepositoryGorm) Revoke(ctx context.Context, id domain.ID) (bool, error) {
+	result := r.db.Conn(ctx).Model(&InvitationModel{}).
+		Where("id = ? AND "+openInvitation, id.Value()).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *InvitationRepositoryGorm) RevokeOpenByEmail(ctx context.Context, email domain.Email) error {
+	return r.db.Conn(ctx).Model(&InvitationModel{}).
+		Where("email = ? AND "+openInvitation, email.String()).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *InvitationRepositoryGorm) MarkAcceptedInTx(tx *gorm.DB, id domain.ID) (bool, error) {
+	result := tx.Model(&InvitationModel{}).
+		Where("id = ? AND "+openInvitation+" AND expires_at > ?", id.Value(), time.Now()).
+		Update("accepted_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
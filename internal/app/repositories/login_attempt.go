@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"time"
+
 	"beerdosan-backend/internal/app/domain"
 	"beerdosan-backend/internal/pkg/database"
 
@@ -13,6 +14,14 @@ type LoginAttemptRepository interface {
 	Create(ctx context.Context, attempt *domain.LoginAttempt) error
 	CreateInTx(tx *gorm.DB, attempt *domain.LoginAttempt) error
 	CountFailedAttemptsByUsernameAndIP(ctx context.Context, username, ipAddress string, since time.Time) (int64, error)
+	ListByUsername(ctx context.Context, username string) ([]*domain.LoginAttempt, error)
+	// GetLastSuccessfulAt returns nil when the user has never logged in.
+	GetLastSuccessfulAt(ctx context.Context, username string) (*time.Time, error)
+
+	FindIDsAttemptedBefore(ctx context.Context, cutoff time.Time, limit int) ([]int64, error)
+	// DeleteInTx deletes the attempts with the given ids and returns how
+	// many it deleted.
+	DeleteInTx(tx *gorm.DB, ids []int64) (int64, error)
 }
 
 type LoginAttemptRepositoryGorm struct {
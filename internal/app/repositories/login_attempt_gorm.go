@@ -59,7 +59,7 @@ func CreateNewLoginAttemptModelFromDomain(attempt *domain.LoginAttempt) *LoginAt
 }
 
 func (r *LoginAttemptRepositoryGorm) Create(ctx context.Context, attempt *domain.LoginAttempt) error {
-	return r.CreateInTx(r.db.WithContext(ctx), attempt)
+	return r.CreateInTx(r.db.Conn(ctx), attempt)
 }
 
 func (r *LoginAttemptRepositoryGorm) CreateInTx(tx *gorm.DB, attempt *domain.LoginAttempt) error {
@@ -74,8 +74,58 @@ func (r *LoginAttemptRepositoryGorm) CreateInTx(tx *gorm.DB, attempt *domain.Log
 
 func (r *LoginAttemptRepositoryGorm) CountFailedAttemptsByUsernameAndIP(ctx context.Context, username, ipAddress string, since time.Time) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&LoginAttemptModel{}).
+	err := r.db.Conn(ctx).Model(&LoginAttemptModel{}).
 		Where("username = ? AND ip_address = ? AND success = false AND attempted_at >= ?", username, ipAddress, since).
 		Count(&count).Error
 	return count, err
 }
+
+func (r *LoginAttemptRepositoryGorm) ListByUsername(ctx context.Context, username string) ([]*domain.LoginAttempt, error) {
+	var models []LoginAttemptModel
+	err := r.db.Conn(ctx).Where("username = ?", username).
+		Order("attempted_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]*domain.LoginAttempt, len(models))
+	for i, model := range models {
+		attempt, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		attempts[i] = attempt
+	}
+
+	return attempts, nil
+}
+
+func (r *LoginAttemptRepositoryGorm) GetLastSuccessfulAt(ctx context.Context, username string) (*time.Time, error) {
+	var models []LoginAttemptModel
+	err := r.db.Conn(ctx).Select("attempted_at").
+		Where("username = ? AND success = true", username).
+		Order("attempted_at DESC").
+		Limit(1).
+		Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return nil, err
+	}
+
+	return &models[0].AttemptedAt, nil
+}
+
+func (r *LoginAttemptRepositoryGorm) FindIDsAttemptedBefore(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	err := r.db.Conn(ctx).Model(&LoginAttemptModel{}).
+		Where("attempted_at < ?", cutoff).
+		Order("attempted_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *LoginAttemptRepositoryGorm) DeleteInTx(tx *gorm.DB, ids []int64) (int64, error) {
+	result := tx.Where("id IN ?", ids).Delete(&LoginAttemptModel{})
+	return result.RowsAffected, result.Error
+}
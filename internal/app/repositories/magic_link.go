@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// MagicLinkRepository stores passwordless login links by the hash of their
+// token.
+type MagicLinkRepository interface {
+	Create(ctx context.Context, link *domain.MagicLink) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.MagicLink, error)
+	// MarkConsumed reports whether this call consumed the link, so that two
+	// concurrent requests cannot both use it.
+	MarkConsumed(ctx context.Context, id domain.ID) (bool, error)
+	// ConsumeAllByUserID retires every unused link of the user.
+	ConsumeAllByUserID(ctx context.Context, userID domain.UserID) error
+	CountCreatedByEmailSince(ctx context.Context, email string, since time.Time) (int64, error)
+	ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.MagicLink, error)
+}
+
+type MagicLinkRepositoryGorm struct {
+	db *database.Database
+}
+
+func NewMagicLinkRepository(db *database.Database) *MagicLinkRepositoryGorm {
+	return &MagicLinkRepositoryGorm{db: db}
+}
+
+var _ MagicLinkRepository = (*MagicLinkRepositoryGorm)(nil)
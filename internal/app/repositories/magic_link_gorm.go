@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+)
+
+type MagicLinkModel struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID      string    `gorm:"type:uuid;not null;index"`
+	Email       string    `gorm:"type:varchar(255);not null;index"`
+	TokenHash   string    `gorm:"type:char(64);not null;uniqueIndex"`
+	BindingHash string    `gorm:"type:char(64);not null"`
+	IPAddress   string    `gorm:"type:varchar(45);not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	ConsumedAt  *time.Time
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+func (MagicLinkModel) TableName() string {
+	return "magic_links"
+}
+
+func (m *MagicLinkModel) ToDomain() (*domain.MagicLink, error) {
+	return domain.ReconstructMagicLink(
+		int64(m.ID),
+		m.UserID,
+		m.Email,
+		m.TokenHash,
+		m.BindingHash,
+		m.IPAddress,
+		m.ExpiresAt,
+		m.ConsumedAt,
+		m.CreatedAt,
+	)
+}
+
+func (r *MagicLinkRepositoryGorm) Create(ctx context.Context, link *domain.MagicLink) error {
+	model := &MagicLinkModel{
+		UserID:      link.UserID().String(),
+		Email:       link.Email().String(),
+		TokenHash:   link.TokenHash(),
+		BindingHash: link.BindingHash(),
+		IPAddress:   link.IPAddress().String(),
+		ExpiresAt:   link.ExpiresAt().Time(),
+		CreatedAt:   link.CreatedAt().Time(),
+	}
+
+	return r.db.Conn(ctx).Create(model).Error
+}
+
+func (r *MagicLinkRepositoryGorm) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.MagicLink, error) {
+	var model MagicLinkModel
+	err := r.db.Conn(ctx).Where("token_hash = ?", tokenHash).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+func (r *MagicLinkRepositoryGorm) MarkConsumed(ctx context.Context, id domain.ID) (bool, error) {
+	result := r.db.Conn(ctx).Model(&MagicLinkModel{}).
+		Where("id = ? AND consumed_at IS NULL", id.Value()).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *MagicLinkRepositoryGorm) ConsumeAllByUserID(ctx context.Context, userID domain.UserID) error {
+	return r.db.Conn(ctx).Model(&MagicLinkModel{}).
+		Where("user_id = ? AND consumed_at IS NULL", userID.String()).
+		Update("consumed_at", time.Now()).Error
+}
+
+func (r *MagicLinkRepositoryGorm) CountCreatedByEmailSince(ctx context.Context, email string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Conn(ctx).Model(&MagicLinkModel{}).
+		Where("email = ? AND created_at >= ?", email, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *MagicLinkRepositoryGorm) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.MagicLink, error) {
+	var models []MagicLinkModel
+	err := r.db.Conn(ctx).Where("user_id = ?", userID.String()).
+		Order("created_at DESC, id DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*domain.MagicLink, len(models))
+	for i, model := range models {
+		link, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		links[i] = link
+	}
+
+	return links, nil
+}
@@ -179,6 +179,241 @@ func (_c *MockLoginAttemptRepository_CreateInTx_Call) RunAndReturn(run func(*gor
 	return _c
 }
 
+// DeleteInTx provides a mock function with given fields: tx, ids
+func (_m *MockLoginAttemptRepository) DeleteInTx(tx *gorm.DB, ids []int64) (int64, error) {
+	ret := _m.Called(tx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInTx")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, []int64) (int64, error)); ok {
+		return rf(tx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, []int64) int64); ok {
+		r0 = rf(tx, ids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, []int64) error); ok {
+		r1 = rf(tx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoginAttemptRepository_DeleteInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInTx'
+type MockLoginAttemptRepository_DeleteInTx_Call struct {
+	*mock.Call
+}
+
+// DeleteInTx is a helper method to define mock.On call
+//   - tx *gorm.DB
+//   - ids []int64
+func (_e *MockLoginAttemptRepository_Expecter) DeleteInTx(tx interface{}, ids interface{}) *MockLoginAttemptRepository_DeleteInTx_Call {
+	return &MockLoginAttemptRepository_DeleteInTx_Call{Call: _e.mock.On("DeleteInTx", tx, ids)}
+}
+
+func (_c *MockLoginAttemptRepository_DeleteInTx_Call) Run(run func(tx *gorm.DB, ids []int64)) *MockLoginAttemptRepository_DeleteInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gorm.DB), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_DeleteInTx_Call) Return(_a0 int64, _a1 error) *MockLoginAttemptRepository_DeleteInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_DeleteInTx_Call) RunAndReturn(run func(*gorm.DB, []int64) (int64, error)) *MockLoginAttemptRepository_DeleteInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindIDsAttemptedBefore provides a mock function with given fields: ctx, cutoff, limit
+func (_m *MockLoginAttemptRepository) FindIDsAttemptedBefore(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	ret := _m.Called(ctx, cutoff, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIDsAttemptedBefore")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]int64, error)); ok {
+		return rf(ctx, cutoff, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []int64); ok {
+		r0 = rf(ctx, cutoff, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, cutoff, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoginAttemptRepository_FindIDsAttemptedBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindIDsAttemptedBefore'
+type MockLoginAttemptRepository_FindIDsAttemptedBefore_Call struct {
+	*mock.Call
+}
+
+// FindIDsAttemptedBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cutoff time.Time
+//   - limit int
+func (_e *MockLoginAttemptRepository_Expecter) FindIDsAttemptedBefore(ctx interface{}, cutoff interface{}, limit interface{}) *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call {
+	return &MockLoginAttemptRepository_FindIDsAttemptedBefore_Call{Call: _e.mock.On("FindIDsAttemptedBefore", ctx, cutoff, limit)}
+}
+
+func (_c *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call) Run(run func(ctx context.Context, cutoff time.Time, limit int)) *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call) Return(_a0 []int64, _a1 error) *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]int64, error)) *MockLoginAttemptRepository_FindIDsAttemptedBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastSuccessfulAt provides a mock function with given fields: ctx, username
+func (_m *MockLoginAttemptRepository) GetLastSuccessfulAt(ctx context.Context, username string) (*time.Time, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSuccessfulAt")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*time.Time, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *time.Time); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoginAttemptRepository_GetLastSuccessfulAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSuccessfulAt'
+type MockLoginAttemptRepository_GetLastSuccessfulAt_Call struct {
+	*mock.Call
+}
+
+// GetLastSuccessfulAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockLoginAttemptRepository_Expecter) GetLastSuccessfulAt(ctx interface{}, username interface{}) *MockLoginAttemptRepository_GetLastSuccessfulAt_Call {
+	return &MockLoginAttemptRepository_GetLastSuccessfulAt_Call{Call: _e.mock.On("GetLastSuccessfulAt", ctx, username)}
+}
+
+func (_c *MockLoginAttemptRepository_GetLastSuccessfulAt_Call) Run(run func(ctx context.Context, username string)) *MockLoginAttemptRepository_GetLastSuccessfulAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_GetLastSuccessfulAt_Call) Return(_a0 *time.Time, _a1 error) *MockLoginAttemptRepository_GetLastSuccessfulAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_GetLastSuccessfulAt_Call) RunAndReturn(run func(context.Context, string) (*time.Time, error)) *MockLoginAttemptRepository_GetLastSuccessfulAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUsername provides a mock function with given fields: ctx, username
+func (_m *MockLoginAttemptRepository) ListByUsername(ctx context.Context, username string) ([]*domain.LoginAttempt, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUsername")
+	}
+
+	var r0 []*domain.LoginAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.LoginAttempt, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.LoginAttempt); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.LoginAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLoginAttemptRepository_ListByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUsername'
+type MockLoginAttemptRepository_ListByUsername_Call struct {
+	*mock.Call
+}
+
+// ListByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockLoginAttemptRepository_Expecter) ListByUsername(ctx interface{}, username interface{}) *MockLoginAttemptRepository_ListByUsername_Call {
+	return &MockLoginAttemptRepository_ListByUsername_Call{Call: _e.mock.On("ListByUsername", ctx, username)}
+}
+
+func (_c *MockLoginAttemptRepository_ListByUsername_Call) Run(run func(ctx context.Context, username string)) *MockLoginAttemptRepository_ListByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_ListByUsername_Call) Return(_a0 []*domain.LoginAttempt, _a1 error) *MockLoginAttemptRepository_ListByUsername_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLoginAttemptRepository_ListByUsername_Call) RunAndReturn(run func(context.Context, string) ([]*domain.LoginAttempt, error)) *MockLoginAttemptRepository_ListByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockLoginAttemptRepository creates a new instance of MockLoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockLoginAttemptRepository(t interface {
@@ -9,6 +9,8 @@ import (
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockSessionRepository is an autogenerated mock type for the SessionRepository type
@@ -24,52 +26,6 @@ func (_m *MockSessionRepository) EXPECT() *MockSessionRepository_Expecter {
 	return &MockSessionRepository_Expecter{mock: &_m.Mock}
 }
 
-// CleanupExpiredSessions provides a mock function with given fields: ctx
-func (_m *MockSessionRepository) CleanupExpiredSessions(ctx context.Context) error {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CleanupExpiredSessions")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// MockSessionRepository_CleanupExpiredSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanupExpiredSessions'
-type MockSessionRepository_CleanupExpiredSessions_Call struct {
-	*mock.Call
-}
-
-// CleanupExpiredSessions is a helper method to define mock.On call
-//   - ctx context.Context
-func (_e *MockSessionRepository_Expecter) CleanupExpiredSessions(ctx interface{}) *MockSessionRepository_CleanupExpiredSessions_Call {
-	return &MockSessionRepository_CleanupExpiredSessions_Call{Call: _e.mock.On("CleanupExpiredSessions", ctx)}
-}
-
-func (_c *MockSessionRepository_CleanupExpiredSessions_Call) Run(run func(ctx context.Context)) *MockSessionRepository_CleanupExpiredSessions_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
-	})
-	return _c
-}
-
-func (_c *MockSessionRepository_CleanupExpiredSessions_Call) Return(_a0 error) *MockSessionRepository_CleanupExpiredSessions_Call {
-	_c.Call.Return(_a0)
-	return _c
-}
-
-func (_c *MockSessionRepository_CleanupExpiredSessions_Call) RunAndReturn(run func(context.Context) error) *MockSessionRepository_CleanupExpiredSessions_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // Create provides a mock function with given fields: ctx, session
 func (_m *MockSessionRepository) Create(ctx context.Context, session *domain.Session) (*domain.Session, error) {
 	ret := _m.Called(ctx, session)
@@ -188,6 +144,63 @@ func (_c *MockSessionRepository_CreateInTx_Call) RunAndReturn(run func(*gorm.DB,
 	return _c
 }
 
+// DeleteInTx provides a mock function with given fields: tx, sessionIDs
+func (_m *MockSessionRepository) DeleteInTx(tx *gorm.DB, sessionIDs []domain.SessionID) (int64, error) {
+	ret := _m.Called(tx, sessionIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInTx")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, []domain.SessionID) (int64, error)); ok {
+		return rf(tx, sessionIDs)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, []domain.SessionID) int64); ok {
+		r0 = rf(tx, sessionIDs)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, []domain.SessionID) error); ok {
+		r1 = rf(tx, sessionIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_DeleteInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInTx'
+type MockSessionRepository_DeleteInTx_Call struct {
+	*mock.Call
+}
+
+// DeleteInTx is a helper method to define mock.On call
+//   - tx *gorm.DB
+//   - sessionIDs []domain.SessionID
+func (_e *MockSessionRepository_Expecter) DeleteInTx(tx interface{}, sessionIDs interface{}) *MockSessionRepository_DeleteInTx_Call {
+	return &MockSessionRepository_DeleteInTx_Call{Call: _e.mock.On("DeleteInTx", tx, sessionIDs)}
+}
+
+func (_c *MockSessionRepository_DeleteInTx_Call) Run(run func(tx *gorm.DB, sessionIDs []domain.SessionID)) *MockSessionRepository_DeleteInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gorm.DB), args[1].([]domain.SessionID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_DeleteInTx_Call) Return(_a0 int64, _a1 error) *MockSessionRepository_DeleteInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_DeleteInTx_Call) RunAndReturn(run func(*gorm.DB, []domain.SessionID) (int64, error)) *MockSessionRepository_DeleteInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByRefreshToken provides a mock function with given fields: ctx, refreshToken
 func (_m *MockSessionRepository) FindByRefreshToken(ctx context.Context, refreshToken string) (*domain.Session, error) {
 	ret := _m.Called(ctx, refreshToken)
@@ -247,6 +260,66 @@ func (_c *MockSessionRepository_FindByRefreshToken_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// FindPurgeableIDs provides a mock function with given fields: ctx, cutoff, limit
+func (_m *MockSessionRepository) FindPurgeableIDs(ctx context.Context, cutoff time.Time, limit int) ([]domain.SessionID, error) {
+	ret := _m.Called(ctx, cutoff, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPurgeableIDs")
+	}
+
+	var r0 []domain.SessionID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.SessionID, error)); ok {
+		return rf(ctx, cutoff, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.SessionID); ok {
+		r0 = rf(ctx, cutoff, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SessionID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, cutoff, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_FindPurgeableIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPurgeableIDs'
+type MockSessionRepository_FindPurgeableIDs_Call struct {
+	*mock.Call
+}
+
+// FindPurgeableIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cutoff time.Time
+//   - limit int
+func (_e *MockSessionRepository_Expecter) FindPurgeableIDs(ctx interface{}, cutoff interface{}, limit interface{}) *MockSessionRepository_FindPurgeableIDs_Call {
+	return &MockSessionRepository_FindPurgeableIDs_Call{Call: _e.mock.On("FindPurgeableIDs", ctx, cutoff, limit)}
+}
+
+func (_c *MockSessionRepository_FindPurgeableIDs_Call) Run(run func(ctx context.Context, cutoff time.Time, limit int)) *MockSessionRepository_FindPurgeableIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_FindPurgeableIDs_Call) Return(_a0 []domain.SessionID, _a1 error) *MockSessionRepository_FindPurgeableIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_FindPurgeableIDs_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]domain.SessionID, error)) *MockSessionRepository_FindPurgeableIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetActiveSessionsByUserID provides a mock function with given fields: ctx, userID
 func (_m *MockSessionRepository) GetActiveSessionsByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error) {
 	ret := _m.Called(ctx, userID)
@@ -306,6 +379,65 @@ func (_c *MockSessionRepository_GetActiveSessionsByUserID_Call) RunAndReturn(run
 	return _c
 }
 
+// GetAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *MockSessionRepository) GetAllByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllByUserID")
+	}
+
+	var r0 []*domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID) ([]*domain.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID) []*domain.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_GetAllByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllByUserID'
+type MockSessionRepository_GetAllByUserID_Call struct {
+	*mock.Call
+}
+
+// GetAllByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID domain.UserID
+func (_e *MockSessionRepository_Expecter) GetAllByUserID(ctx interface{}, userID interface{}) *MockSessionRepository_GetAllByUserID_Call {
+	return &MockSessionRepository_GetAllByUserID_Call{Call: _e.mock.On("GetAllByUserID", ctx, userID)}
+}
+
+func (_c *MockSessionRepository_GetAllByUserID_Call) Run(run func(ctx context.Context, userID domain.UserID)) *MockSessionRepository_GetAllByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_GetAllByUserID_Call) Return(_a0 []*domain.Session, _a1 error) *MockSessionRepository_GetAllByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_GetAllByUserID_Call) RunAndReturn(run func(context.Context, domain.UserID) ([]*domain.Session, error)) *MockSessionRepository_GetAllByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBySessionID provides a mock function with given fields: ctx, sessionID
 func (_m *MockSessionRepository) GetBySessionID(ctx context.Context, sessionID domain.SessionID) (*domain.Session, error) {
 	ret := _m.Called(ctx, sessionID)
@@ -365,6 +497,65 @@ func (_c *MockSessionRepository_GetBySessionID_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetLiveSessionsByUserIDInTx provides a mock function with given fields: tx, userID
+func (_m *MockSessionRepository) GetLiveSessionsByUserIDInTx(tx *gorm.DB, userID domain.UserID) ([]*domain.Session, error) {
+	ret := _m.Called(tx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLiveSessionsByUserIDInTx")
+	}
+
+	var r0 []*domain.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, domain.UserID) ([]*domain.Session, error)); ok {
+		return rf(tx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, domain.UserID) []*domain.Session); ok {
+		r0 = rf(tx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, domain.UserID) error); ok {
+		r1 = rf(tx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_GetLiveSessionsByUserIDInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLiveSessionsByUserIDInTx'
+type MockSessionRepository_GetLiveSessionsByUserIDInTx_Call struct {
+	*mock.Call
+}
+
+// GetLiveSessionsByUserIDInTx is a helper method to define mock.On call
+//   - tx *gorm.DB
+//   - userID domain.UserID
+func (_e *MockSessionRepository_Expecter) GetLiveSessionsByUserIDInTx(tx interface{}, userID interface{}) *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call {
+	return &MockSessionRepository_GetLiveSessionsByUserIDInTx_Call{Call: _e.mock.On("GetLiveSessionsByUserIDInTx", tx, userID)}
+}
+
+func (_c *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call) Run(run func(tx *gorm.DB, userID domain.UserID)) *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gorm.DB), args[1].(domain.UserID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call) Return(_a0 []*domain.Session, _a1 error) *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call) RunAndReturn(run func(*gorm.DB, domain.UserID) ([]*domain.Session, error)) *MockSessionRepository_GetLiveSessionsByUserIDInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // InvalidateAllUserSessions provides a mock function with given fields: ctx, userID, excludeSessionID
 func (_m *MockSessionRepository) InvalidateAllUserSessions(ctx context.Context, userID domain.UserID, excludeSessionID domain.SessionID) error {
 	ret := _m.Called(ctx, userID, excludeSessionID)
@@ -460,6 +651,65 @@ func (_c *MockSessionRepository_InvalidateSession_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// Rename provides a mock function with given fields: ctx, userID, sessionID, name
+func (_m *MockSessionRepository) Rename(ctx context.Context, userID domain.UserID, sessionID domain.SessionID, name domain.SessionName) (bool, error) {
+	ret := _m.Called(ctx, userID, sessionID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.SessionID, domain.SessionName) (bool, error)); ok {
+		return rf(ctx, userID, sessionID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.SessionID, domain.SessionName) bool); ok {
+		r0 = rf(ctx, userID, sessionID, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.SessionID, domain.SessionName) error); ok {
+		r1 = rf(ctx, userID, sessionID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepository_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockSessionRepository_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID domain.UserID
+//   - sessionID domain.SessionID
+//   - name domain.SessionName
+func (_e *MockSessionRepository_Expecter) Rename(ctx interface{}, userID interface{}, sessionID interface{}, name interface{}) *MockSessionRepository_Rename_Call {
+	return &MockSessionRepository_Rename_Call{Call: _e.mock.On("Rename", ctx, userID, sessionID, name)}
+}
+
+func (_c *MockSessionRepository_Rename_Call) Run(run func(ctx context.Context, userID domain.UserID, sessionID domain.SessionID, name domain.SessionName)) *MockSessionRepository_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.SessionID), args[3].(domain.SessionName))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepository_Rename_Call) Return(_a0 bool, _a1 error) *MockSessionRepository_Rename_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepository_Rename_Call) RunAndReturn(run func(context.Context, domain.UserID, domain.SessionID, domain.SessionName) (bool, error)) *MockSessionRepository_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, session
 func (_m *MockSessionRepository) Update(ctx context.Context, session *domain.Session) error {
 	ret := _m.Called(ctx, session)
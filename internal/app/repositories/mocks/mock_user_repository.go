@@ -6,7 +6,11 @@ import (
 	context "context"
 	domain "beerdosan-backend/internal/app/domain"
 
+	gorm "gorm.io/gorm"
+
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockUserRepository is an autogenerated mock type for the UserRepository type
@@ -22,6 +26,63 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// CancelDeletion provides a mock function with given fields: ctx, id
+func (_m *MockUserRepository) CancelDeletion(ctx context.Context, id domain.UserID) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelDeletion")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_CancelDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelDeletion'
+type MockUserRepository_CancelDeletion_Call struct {
+	*mock.Call
+}
+
+// CancelDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.UserID
+func (_e *MockUserRepository_Expecter) CancelDeletion(ctx interface{}, id interface{}) *MockUserRepository_CancelDeletion_Call {
+	return &MockUserRepository_CancelDeletion_Call{Call: _e.mock.On("CancelDeletion", ctx, id)}
+}
+
+func (_c *MockUserRepository_CancelDeletion_Call) Run(run func(ctx context.Context, id domain.UserID)) *MockUserRepository_CancelDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CancelDeletion_Call) Return(_a0 bool, _a1 error) *MockUserRepository_CancelDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_CancelDeletion_Call) RunAndReturn(run func(context.Context, domain.UserID) (bool, error)) *MockUserRepository_CancelDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, user
 func (_m *MockUserRepository) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
 	ret := _m.Called(ctx, user)
@@ -81,6 +142,184 @@ func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// CreateInTx provides a mock function with given fields: tx, user
+func (_m *MockUserRepository) CreateInTx(tx *gorm.DB, user *domain.User) (*domain.User, error) {
+	ret := _m.Called(tx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInTx")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.User) (*domain.User, error)); ok {
+		return rf(tx, user)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.User) *domain.User); ok {
+		r0 = rf(tx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, *domain.User) error); ok {
+		r1 = rf(tx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_CreateInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInTx'
+type MockUserRepository_CreateInTx_Call struct {
+	*mock.Call
+}
+
+// CreateInTx is a helper method to define mock.On call
+//   - tx *gorm.DB
+//   - user *domain.User
+func (_e *MockUserRepository_Expecter) CreateInTx(tx interface{}, user interface{}) *MockUserRepository_CreateInTx_Call {
+	return &MockUserRepository_CreateInTx_Call{Call: _e.mock.On("CreateInTx", tx, user)}
+}
+
+func (_c *MockUserRepository_CreateInTx_Call) Run(run func(tx *gorm.DB, user *domain.User)) *MockUserRepository_CreateInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gorm.DB), args[1].(*domain.User))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CreateInTx_Call) Return(_a0 *domain.User, _a1 error) *MockUserRepository_CreateInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_CreateInTx_Call) RunAndReturn(run func(*gorm.DB, *domain.User) (*domain.User, error)) *MockUserRepository_CreateInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindIDsDueForPurge provides a mock function with given fields: ctx, now, limit
+func (_m *MockUserRepository) FindIDsDueForPurge(ctx context.Context, now time.Time, limit int) ([]domain.UserID, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIDsDueForPurge")
+	}
+
+	var r0 []domain.UserID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.UserID, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.UserID); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_FindIDsDueForPurge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindIDsDueForPurge'
+type MockUserRepository_FindIDsDueForPurge_Call struct {
+	*mock.Call
+}
+
+// FindIDsDueForPurge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *MockUserRepository_Expecter) FindIDsDueForPurge(ctx interface{}, now interface{}, limit interface{}) *MockUserRepository_FindIDsDueForPurge_Call {
+	return &MockUserRepository_FindIDsDueForPurge_Call{Call: _e.mock.On("FindIDsDueForPurge", ctx, now, limit)}
+}
+
+func (_c *MockUserRepository_FindIDsDueForPurge_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *MockUserRepository_FindIDsDueForPurge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_FindIDsDueForPurge_Call) Return(_a0 []domain.UserID, _a1 error) *MockUserRepository_FindIDsDueForPurge_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_FindIDsDueForPurge_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]domain.UserID, error)) *MockUserRepository_FindIDsDueForPurge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByEmail")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByEmail'
+type MockUserRepository_GetByEmail_Call struct {
+	*mock.Call
+}
+
+// GetByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockUserRepository_Expecter) GetByEmail(ctx interface{}, email interface{}) *MockUserRepository_GetByEmail_Call {
+	return &MockUserRepository_GetByEmail_Call{Call: _e.mock.On("GetByEmail", ctx, email)}
+}
+
+func (_c *MockUserRepository_GetByEmail_Call) Run(run func(ctx context.Context, email string)) *MockUserRepository_GetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByEmail_Call) Return(_a0 *domain.User, _a1 error) *MockUserRepository_GetByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByEmail_Call) RunAndReturn(run func(context.Context, string) (*domain.User, error)) *MockUserRepository_GetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *MockUserRepository) GetByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
 	ret := _m.Called(ctx, id)
@@ -140,6 +379,65 @@ func (_c *MockUserRepository_GetByID_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetByIDForUpdateInTx provides a mock function with given fields: tx, id
+func (_m *MockUserRepository) GetByIDForUpdateInTx(tx *gorm.DB, id domain.UserID) (*domain.User, error) {
+	ret := _m.Called(tx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdateInTx")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, domain.UserID) (*domain.User, error)); ok {
+		return rf(tx, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, domain.UserID) *domain.User); ok {
+		r0 = rf(tx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, domain.UserID) error); ok {
+		r1 = rf(tx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetByIDForUpdateInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDForUpdateInTx'
+type MockUserRepository_GetByIDForUpdateInTx_Call struct {
+	*mock.Call
+}
+
+// GetByIDForUpdateInTx is a helper method to define mock.On call
+//   - tx *gorm.DB
+//   - id domain.UserID
+func (_e *MockUserRepository_Expecter) GetByIDForUpdateInTx(tx interface{}, id interface{}) *MockUserRepository_GetByIDForUpdateInTx_Call {
+	return &MockUserRepository_GetByIDForUpdateInTx_Call{Call: _e.mock.On("GetByIDForUpdateInTx", tx, id)}
+}
+
+func (_c *MockUserRepository_GetByIDForUpdateInTx_Call) Run(run func(tx *gorm.DB, id domain.UserID)) *MockUserRepository_GetByIDForUpdateInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gorm.DB), args[1].(domain.UserID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByIDForUpdateInTx_Call) Return(_a0 *domain.User, _a1 error) *MockUserRepository_GetByIDForUpdateInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByIDForUpdateInTx_Call) RunAndReturn(run func(*gorm.DB, domain.UserID) (*domain.User, error)) *MockUserRepository_GetByIDForUpdateInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUsername provides a mock function with given fields: ctx, username
 func (_m *MockUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	ret := _m.Called(ctx, username)
@@ -199,6 +497,299 @@ func (_c *MockUserRepository_GetByUsername_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// GetByVerifiedPhoneNumber provides a mock function with given fields: ctx, phoneNumber
+func (_m *MockUserRepository) GetByVerifiedPhoneNumber(ctx context.Context, phoneNumber domain.PhoneNumber) (*domain.User, error) {
+	ret := _m.Called(ctx, phoneNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByVerifiedPhoneNumber")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PhoneNumber) (*domain.User, error)); ok {
+		return rf(ctx, phoneNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PhoneNumber) *domain.User); ok {
+		r0 = rf(ctx, phoneNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PhoneNumber) error); ok {
+		r1 = rf(ctx, phoneNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_GetByVerifiedPhoneNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByVerifiedPhoneNumber'
+type MockUserRepository_GetByVerifiedPhoneNumber_Call struct {
+	*mock.Call
+}
+
+// GetByVerifiedPhoneNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phoneNumber domain.PhoneNumber
+func (_e *MockUserRepository_Expecter) GetByVerifiedPhoneNumber(ctx interface{}, phoneNumber interface{}) *MockUserRepository_GetByVerifiedPhoneNumber_Call {
+	return &MockUserRepository_GetByVerifiedPhoneNumber_Call{Call: _e.mock.On("GetByVerifiedPhoneNumber", ctx, phoneNumber)}
+}
+
+func (_c *MockUserRepository_GetByVerifiedPhoneNumber_Call) Run(run func(ctx context.Context, phoneNumber domain.PhoneNumber)) *MockUserRepository_GetByVerifiedPhoneNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PhoneNumber))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByVerifiedPhoneNumber_Call) Return(_a0 *domain.User, _a1 error) *MockUserRepository_GetByVerifiedPhoneNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByVerifiedPhoneNumber_Call) RunAndReturn(run func(context.Context, domain.PhoneNumber) (*domain.User, error)) *MockUserRepository_GetByVerifiedPhoneNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAfterID provides a mock function with given fields: ctx, afterID, limit
+func (_m *MockUserRepository) ListAfterID(ctx context.Context, afterID domain.UserID, limit int) ([]*domain.User, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAfterID")
+	}
+
+	var r0 []*domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, int) ([]*domain.User, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, int) []*domain.User); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_ListAfterID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAfterID'
+type MockUserRepository_ListAfterID_Call struct {
+	*mock.Call
+}
+
+// ListAfterID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID domain.UserID
+//   - limit int
+func (_e *MockUserRepository_Expecter) ListAfterID(ctx interface{}, afterID interface{}, limit interface{}) *MockUserRepository_ListAfterID_Call {
+	return &MockUserRepository_ListAfterID_Call{Call: _e.mock.On("ListAfterID", ctx, afterID, limit)}
+}
+
+func (_c *MockUserRepository_ListAfterID_Call) Run(run func(ctx context.Context, afterID domain.UserID, limit int)) *MockUserRepository_ListAfterID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListAfterID_Call) Return(_a0 []*domain.User, _a1 error) *MockUserRepository_ListAfterID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_ListAfterID_Call) RunAndReturn(run func(context.Context, domain.UserID, int) ([]*domain.User, error)) *MockUserRepository_ListAfterID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeInTx provides a mock function with given fields: tx, id
+func (_m *MockUserRepository) PurgeInTx(tx *gorm.DB, id domain.UserID) (bool, error) {
+	ret := _m.Called(tx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeInTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, domain.UserID) (bool, error)); ok {
+		return rf(tx, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, domain.UserID) bool); ok {
+		r0 = rf(tx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, domain.UserID) error); ok {
+		r1 = rf(tx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_PurgeInTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeInTx'
+type MockUserRepository_PurgeInTx_Call struct {
+	*mock.Call
+}
+
+// PurgeInTx is a helper method to define mock.On call
+//   - tx *gorm.DB
+//   - id domain.UserID
+func (_e *MockUserRepository_Expecter) PurgeInTx(tx interface{}, id interface{}) *MockUserRepository_PurgeInTx_Call {
+	return &MockUserRepository_PurgeInTx_Call{Call: _e.mock.On("PurgeInTx", tx, id)}
+}
+
+func (_c *MockUserRepository_PurgeInTx_Call) Run(run func(tx *gorm.DB, id domain.UserID)) *MockUserRepository_PurgeInTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*gorm.DB), args[1].(domain.UserID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeInTx_Call) Return(_a0 bool, _a1 error) *MockUserRepository_PurgeInTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeInTx_Call) RunAndReturn(run func(*gorm.DB, domain.UserID) (bool, error)) *MockUserRepository_PurgeInTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplacePasswordHash provides a mock function with given fields: ctx, id, oldHash, newHash
+func (_m *MockUserRepository) ReplacePasswordHash(ctx context.Context, id domain.UserID, oldHash domain.HashedPassword, newHash domain.HashedPassword) (bool, error) {
+	ret := _m.Called(ctx, id, oldHash, newHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplacePasswordHash")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.HashedPassword, domain.HashedPassword) (bool, error)); ok {
+		return rf(ctx, id, oldHash, newHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, domain.HashedPassword, domain.HashedPassword) bool); ok {
+		r0 = rf(ctx, id, oldHash, newHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, domain.HashedPassword, domain.HashedPassword) error); ok {
+		r1 = rf(ctx, id, oldHash, newHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_ReplacePasswordHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplacePasswordHash'
+type MockUserRepository_ReplacePasswordHash_Call struct {
+	*mock.Call
+}
+
+// ReplacePasswordHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.UserID
+//   - oldHash domain.HashedPassword
+//   - newHash domain.HashedPassword
+func (_e *MockUserRepository_Expecter) ReplacePasswordHash(ctx interface{}, id interface{}, oldHash interface{}, newHash interface{}) *MockUserRepository_ReplacePasswordHash_Call {
+	return &MockUserRepository_ReplacePasswordHash_Call{Call: _e.mock.On("ReplacePasswordHash", ctx, id, oldHash, newHash)}
+}
+
+func (_c *MockUserRepository_ReplacePasswordHash_Call) Run(run func(ctx context.Context, id domain.UserID, oldHash domain.HashedPassword, newHash domain.HashedPassword)) *MockUserRepository_ReplacePasswordHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(domain.HashedPassword), args[3].(domain.HashedPassword))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ReplacePasswordHash_Call) Return(_a0 bool, _a1 error) *MockUserRepository_ReplacePasswordHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_ReplacePasswordHash_Call) RunAndReturn(run func(context.Context, domain.UserID, domain.HashedPassword, domain.HashedPassword) (bool, error)) *MockUserRepository_ReplacePasswordHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleDeletion provides a mock function with given fields: ctx, id, purgeAt
+func (_m *MockUserRepository) ScheduleDeletion(ctx context.Context, id domain.UserID, purgeAt time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, purgeAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleDeletion")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, time.Time) (bool, error)); ok {
+		return rf(ctx, id, purgeAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, time.Time) bool); ok {
+		r0 = rf(ctx, id, purgeAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, time.Time) error); ok {
+		r1 = rf(ctx, id, purgeAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserRepository_ScheduleDeletion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleDeletion'
+type MockUserRepository_ScheduleDeletion_Call struct {
+	*mock.Call
+}
+
+// ScheduleDeletion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.UserID
+//   - purgeAt time.Time
+func (_e *MockUserRepository_Expecter) ScheduleDeletion(ctx interface{}, id interface{}, purgeAt interface{}) *MockUserRepository_ScheduleDeletion_Call {
+	return &MockUserRepository_ScheduleDeletion_Call{Call: _e.mock.On("ScheduleDeletion", ctx, id, purgeAt)}
+}
+
+func (_c *MockUserRepository_ScheduleDeletion_Call) Run(run func(ctx context.Context, id domain.UserID, purgeAt time.Time)) *MockUserRepository_ScheduleDeletion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ScheduleDeletion_Call) Return(_a0 bool, _a1 error) *MockUserRepository_ScheduleDeletion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserRepository_ScheduleDeletion_Call) RunAndReturn(run func(context.Context, domain.UserID, time.Time) (bool, error)) *MockUserRepository_ScheduleDeletion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, user
 func (_m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	ret := _m.Called(ctx, user)
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// OTPRepository stores one-time passcodes. Only the newest code of a user for
+// a purpose is live; issuing a new one retires the others.
+type OTPRepository interface {
+	Create(ctx context.Context, otp *domain.OneTimePasscode) error
+	GetLatest(ctx context.Context, userID domain.UserID, purpose domain.OTPPurpose) (*domain.OneTimePasscode, error)
+	// IncrementAttempts counts a guess and returns the new attempt count. The
+	// increment is atomic, so concurrent guesses cannot exceed the limit.
+	IncrementAttempts(ctx context.Context, id domain.ID) (int, error)
+	// MarkConsumed reports whether this call consumed the code.
+	MarkConsumed(ctx context.Context, id domain.ID) (bool, error)
+	ConsumeAllByUserID(ctx context.Context, userID domain.UserID, purpose domain.OTPPurpose) error
+	ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.OneTimePasscode, error)
+}
+
+type OTPRepositoryGorm struct {
+	db *database.Database
+}
+
+func NewOTPRepository(db *database.Database) *OTPRepositoryGorm {
+	return &OTPRepositoryGorm{db: db}
+}
+
+var _ OTPRepository = (*OTPRepositoryGorm)(nil)
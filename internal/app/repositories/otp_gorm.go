@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"beerdosan-backend/internal/app/domain"
+)
+
+type OTPModel struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID      string    `gorm:"type:uuid;not null;index"`
+	Purpose     string    `gorm:"type:varchar(32);not null"`
+	Channel     string    `gorm:"type:varchar(16);not null"`
+	Destination string    `gorm:"type:varchar(255);not null"`
+	CodeHash    string    `gorm:"type:varchar(128);not null"`
+	Attempts    int       `gorm:"not null;default:0"`
+	MaxAttempts int       `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	ConsumedAt  *time.Time
+	CreatedAt   time.Time `gorm:"index"`
+}
+
+func (OTPModel) TableName() string {
+	return "one_time_passcodes"
+}
+
+func (m *OTPModel) ToDomain() (*domain.OneTimePasscode, error) {
+	return domain.ReconstructOneTimePasscode(
+		int64(m.ID),
+		m.UserID,
+		m.Purpose,
+		m.Channel,
+		m.Destination,
+		m.CodeHash,
+		m.Attempts,
+		m.MaxAttempts,
+		m.ExpiresAt,
+		m.ConsumedAt,
+		m.CreatedAt,
+	)
+}
+
+func (r *OTPRepositoryGorm) Create(ctx context.Context, otp *domain.OneTimePasscode) error {
+	model := &OTPModel{
+		UserID:      otp.UserID().String(),
+		Purpose:     otp.Purpose().String(),
+		Channel:     otp.Channel().String(),
+		Destination: otp.Destination().String(),
+		CodeHash:    otp.CodeHash(),
+		MaxAttempts: otp.MaxAttempts(),
+		ExpiresAt:   otp.ExpiresAt().Time(),
+		CreatedAt:   otp.CreatedAt().Time(),
+	}
+
+	return r.db.Conn(ctx).Create(model).Error
+}
+
+// GetLatest returns the newest code, consumed or not, so that callers can
+// throttle resends as well as verify.
+func (r *OTPRepositoryGorm) GetLatest(ctx context.Context, userID domain.UserID, purpose domain.OTPPurpose) (*domain.OneTimePasscode, error) {
+	var model OTPModel
+	err := r.db.Conn(ctx).
+		Where("user_id = ? AND purpose = ?", userID.String(), purpose.String()).
+		Order("created_at DESC, id DESC").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+func (r *OTPRepositoryGorm) IncrementAttempts(ctx context.Context, id domain.ID) (int, error) {
+	var model OTPModel
+	result := r.db.Conn(ctx).Model(&model).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "attempts"}}}).
+		Where("id = ?", id.Value()).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return model.Attempts, nil
+}
+
+func (r *OTPRepositoryGorm) MarkConsumed(ctx context.Context, id domain.ID) (bool, error) {
+	result := r.db.Conn(ctx).Model(&OTPModel{}).
+		Where("id = ? AND consumed_at IS NULL", id.Value()).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *OTPRepositoryGorm) ConsumeAllByUserID(ctx context.Context, userID domain.UserID, purpose domain.OTPPurpose) error {
+	return r.db.Conn(ctx).Model(&OTPModel{}).
+		Where("user_id = ? AND purpose = ? AND consumed_at IS NULL", userID.String(), purpose.String()).
+		Update("consumed_at", time.Now()).Error
+}
+
+func (r *OTPRepositoryGorm) ListByUserID(ctx context.Context, userID domain.UserID) ([]*domain.OneTimePasscode, error) {
+	var models []OTPModel
+	err := r.db.Conn(ctx).Where("user_id = ?", userID.String()).
+		Order("created_at DESC, id DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	otps := make([]*domain.OneTimePasscode, len(models))
+	for i, model := range models {
+		otp, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		otps[i] = otp
+	}
+
+	return otps, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// PasswordHistoryRepository keeps the hashes of a user's recent passwords so
+// that they cannot be reused.
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, userID domain.UserID, hash domain.HashedPassword) error
+	CreateInTx(tx *gorm.DB, userID domain.UserID, hash domain.HashedPassword) error
+	GetRecentByUserID(ctx context.Context, userID domain.UserID, limit int) ([]domain.HashedPassword, error)
+	PruneByUserID(ctx context.Context, userID domain.UserID, keep int) error
+	// ListChangedAtByUserID returns when each remembered password was set,
+	// newest first.
+	ListChangedAtByUserID(ctx context.Context, userID domain.UserID) ([]time.Time, error)
+}
+
+type PasswordHistoryRepositoryGorm struct {
+	db *database.Database
+}
+
+func NewPasswordHistoryRepository(db *database.Database) *PasswordHistoryRepositoryGorm {
+	return &PasswordHistoryRepositoryGorm{db: db}
+}
+
+var _ PasswordHistoryRepository = (*PasswordHistoryRepositoryGorm)(nil)
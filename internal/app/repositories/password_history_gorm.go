@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+)
+
+type PasswordHistoryModel struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID       string    `gorm:"type:uuid;not null;index"`
+	PasswordHash string    `gorm:"type:text;not null"`
+	CreatedAt    time.Time `gorm:"index"`
+}
+
+func (PasswordHistoryModel) TableName() string {
+	return "password_history"
+}
+
+func (r *PasswordHistoryRepositoryGorm) Create(ctx context.Context, userID domain.UserID, hash domain.HashedPassword) error {
+	return r.CreateInTx(r.db.Conn(ctx), userID, hash)
+}
+
+func (r *PasswordHistoryRepositoryGorm) CreateInTx(tx *gorm.DB, userID domain.UserID, hash domain.HashedPassword) error {
+	model := &PasswordHistoryModel{
+		UserID:       userID.String(),
+		PasswordHash: hash.String(),
+	}
+
+	return tx.Create(model).Error
+}
+
+// GetRecentByUserID returns up to limit hashes, newest first.
+func (r *PasswordHistoryRepositoryGorm) GetRecentByUserID(ctx context.Context, userID domain.UserID, limit int) ([]domain.HashedPassword, error) {
+	var hashes []string
+	err := r.db.Conn(ctx).Model(&PasswordHistoryModel{}).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.HashedPassword, 0, len(hashes))
+	for _, hash := range hashes {
+		result = append(result, domain.HashedPassword(hash))
+	}
+	return result, nil
+}
+
+// PruneByUserID deletes all but the keep newest hashes of a user.
+func (r *PasswordHistoryRepositoryGorm) PruneByUserID(ctx context.Context, userID domain.UserID, keep int) error {
+	db := r.db.Conn(ctx)
+
+	newest := db.Model(&PasswordHistoryModel{}).
+		Select("id").
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC, id DESC").
+		Limit(keep)
+
+	return db.Where("user_id = ? AND id NOT IN (?)", userID.String(), newest).
+		Delete(&PasswordHistoryModel{}).Error
+}
+
+func (r *PasswordHistoryRepositoryGorm) ListChangedAtByUserID(ctx context.Context, userID domain.UserID) ([]time.Time, error) {
+	var changedAt []time.Time
+	err := r.db.Conn(ctx).Model(&PasswordHistoryModel{}).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC, id DESC").
+		Pluck("created_at", &changedAt).Error
+	return changedAt, err
+}
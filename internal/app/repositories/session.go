@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"beerdosan-backend/internal/app/domain"
 	"beerdosan-backend/internal/pkg/database"
 
@@ -17,10 +19,20 @@ type SessionRepository interface {
 
 	FindByRefreshToken(ctx context.Context, refreshToken string) (*domain.Session, error)
 	GetActiveSessionsByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error)
+	GetAllByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error)
+	GetLiveSessionsByUserIDInTx(tx *gorm.DB, userID domain.UserID) ([]*domain.Session, error)
 
 	InvalidateSession(ctx context.Context, sessionID domain.SessionID) error
 	InvalidateAllUserSessions(ctx context.Context, userID domain.UserID, excludeSessionID domain.SessionID) error
 	UpdateLastActivity(ctx context.Context, sessionID domain.SessionID) error
+	// Rename sets the name of an active session owned by userID without
+	// counting as activity. It reports whether such a session existed.
+	Rename(ctx context.Context, userID domain.UserID, sessionID domain.SessionID, name domain.SessionName) (bool, error)
+
+	FindPurgeableIDs(ctx context.Context, cutoff time.Time, limit int) ([]domain.SessionID, error)
+	// DeleteInTx deletes the sessions with the given ids and returns how
+	// many it deleted.
+	DeleteInTx(tx *gorm.DB, sessionIDs []domain.SessionID) (int64, error)
 }
 
 type SessionRepositoryGorm struct {
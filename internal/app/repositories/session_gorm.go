@@ -18,7 +18,14 @@ type SessionModel struct {
 	DeviceFingerprint string `gorm:"type:varchar(500);not null"`
 	IPAddress         string `gorm:"type:varchar(45);not null"`
 	UserAgent         string `gorm:"type:text;not null"`
+	Name              string `gorm:"type:varchar(100);not null;default:''"`
+	Browser           string `gorm:"type:varchar(50);not null;default:''"`
+	BrowserVersion    string `gorm:"type:varchar(50);not null;default:''"`
+	OS                string `gorm:"column:os;type:varchar(50);not null;default:''"`
+	OSVersion         string `gorm:"column:os_version;type:varchar(50);not null;default:''"`
+	DeviceType        string `gorm:"type:varchar(20);not null;default:''"`
 	IsActive          bool   `gorm:"default:true"`
+	RememberMe        bool   `gorm:"not null;default:false"`
 	ExpiresAt         time.Time
 	RefreshExpiresAt  time.Time
 	CreatedAt         time.Time
@@ -32,6 +39,11 @@ func (SessionModel) TableName() string {
 }
 
 func (s *SessionModel) ToDomain() (*domain.Session, error) {
+	device, err := s.device()
+	if err != nil {
+		return nil, err
+	}
+
 	return domain.ReconstructSession(
 		s.ID,
 		s.UserID,
@@ -40,7 +52,10 @@ func (s *SessionModel) ToDomain() (*domain.Session, error) {
 		s.DeviceFingerprint,
 		s.IPAddress,
 		s.UserAgent,
+		s.Name,
+		device,
 		s.IsActive,
+		s.RememberMe,
 		s.ExpiresAt,
 		s.RefreshExpiresAt,
 		s.CreatedAt,
@@ -49,6 +64,16 @@ func (s *SessionModel) ToDomain() (*domain.Session, error) {
 	)
 }
 
+// device returns the stored device details, parsing the user agent for rows
+// written before those columns existed.
+func (s *SessionModel) device() (domain.Device, error) {
+	if s.DeviceType == "" {
+		return domain.ParseUserAgent(s.UserAgent), nil
+	}
+
+	return domain.ReconstructDevice(s.Browser, s.BrowserVersion, s.OS, s.OSVersion, s.DeviceType)
+}
+
 func CreateSessionModelFromDomain(session *domain.Session) *SessionModel {
 	return &SessionModel{
 		ID:                session.ID().String(),
@@ -58,7 +83,14 @@ func CreateSessionModelFromDomain(session *domain.Session) *SessionModel {
 		DeviceFingerprint: session.DeviceFingerprint().String(),
 		IPAddress:         session.IPAddress().String(),
 		UserAgent:         session.UserAgent().String(),
+		Name:              session.Name().String(),
+		Browser:           session.Device().Browser(),
+		BrowserVersion:    session.Device().BrowserVersion(),
+		OS:                session.Device().OS(),
+		OSVersion:         session.Device().OSVersion(),
+		DeviceType:        session.Device().Type().String(),
 		IsActive:          session.IsActive(),
+		RememberMe:        session.RememberMe(),
 		ExpiresAt:         session.ExpiresAt().Time(),
 		RefreshExpiresAt:  session.RefreshExpiresAt().Time(),
 		CreatedAt:         session.CreatedAt().Time(),
@@ -75,7 +107,14 @@ func CreateNewSessionModelFromDomain(session *domain.Session) *SessionModel {
 		DeviceFingerprint: session.DeviceFingerprint().String(),
 		IPAddress:         session.IPAddress().String(),
 		UserAgent:         session.UserAgent().String(),
+		Name:              session.Name().String(),
+		Browser:           session.Device().Browser(),
+		BrowserVersion:    session.Device().BrowserVersion(),
+		OS:                session.Device().OS(),
+		OSVersion:         session.Device().OSVersion(),
+		DeviceType:        session.Device().Type().String(),
 		IsActive:          session.IsActive(),
+		RememberMe:        session.RememberMe(),
 		ExpiresAt:         session.ExpiresAt().Time(),
 		RefreshExpiresAt:  session.RefreshExpiresAt().Time(),
 		CreatedAt:         session.CreatedAt().Time(),
@@ -84,7 +123,7 @@ func CreateNewSessionModelFromDomain(session *domain.Session) *SessionModel {
 }
 
 func (r *SessionRepositoryGorm) Create(ctx context.Context, session *domain.Session) (*domain.Session, error) {
-	return r.CreateInTx(r.db.WithContext(ctx), session)
+	return r.CreateInTx(r.db.Conn(ctx), session)
 }
 
 func (r *SessionRepositoryGorm) CreateInTx(tx *gorm.DB, session *domain.Session) (*domain.Session, error) {
@@ -98,7 +137,7 @@ func (r *SessionRepositoryGorm) CreateInTx(tx *gorm.DB, session *domain.Session)
 }
 
 func (r *SessionRepositoryGorm) Update(ctx context.Context, session *domain.Session) error {
-	return r.UpdateInTx(r.db.WithContext(ctx), session)
+	return r.UpdateInTx(r.db.Conn(ctx), session)
 }
 
 func (r *SessionRepositoryGorm) UpdateInTx(tx *gorm.DB, session *domain.Session) error {
@@ -108,7 +147,7 @@ func (r *SessionRepositoryGorm) UpdateInTx(tx *gorm.DB, session *domain.Session)
 
 func (r *SessionRepositoryGorm) FindByRefreshToken(ctx context.Context, refreshToken string) (*domain.Session, error) {
 	var model SessionModel
-	err := r.db.WithContext(ctx).Where("refresh_token_value = ? AND is_active = true", refreshToken).First(&model).Error
+	err := r.db.Conn(ctx).Where("refresh_token_value = ? AND is_active = true", refreshToken).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -121,7 +160,7 @@ func (r *SessionRepositoryGorm) FindByRefreshToken(ctx context.Context, refreshT
 
 func (r *SessionRepositoryGorm) GetBySessionID(ctx context.Context, sessionID domain.SessionID) (*domain.Session, error) {
 	var model SessionModel
-	err := r.db.WithContext(ctx).Where("id = ?", sessionID.String()).First(&model).Error
+	err := r.db.Conn(ctx).Where("id = ?", sessionID.String()).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -134,7 +173,30 @@ func (r *SessionRepositoryGorm) GetBySessionID(ctx context.Context, sessionID do
 
 func (r *SessionRepositoryGorm) GetActiveSessionsByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error) {
 	var models []SessionModel
-	err := r.db.WithContext(ctx).Where("user_id = ? AND is_active = true", userID.String()).
+	err := r.db.Conn(ctx).Where("user_id = ? AND is_active = true", userID.String()).
+		Order("created_at DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.Session, len(models))
+	for i, model := range models {
+		session, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		sessions[i] = session
+	}
+
+	return sessions, nil
+}
+
+// GetAllByUserID returns every stored session of the user, including ended
+// ones, newest first.
+func (r *SessionRepositoryGorm) GetAllByUserID(ctx context.Context, userID domain.UserID) ([]*domain.Session, error) {
+	var models []SessionModel
+	err := r.db.Conn(ctx).Where("user_id = ?", userID.String()).
 		Order("created_at DESC").
 		Find(&models).Error
 	if err != nil {
@@ -153,14 +215,37 @@ func (r *SessionRepositoryGorm) GetActiveSessionsByUserID(ctx context.Context, u
 	return sessions, nil
 }
 
+// GetLiveSessionsByUserIDInTx returns the user's active sessions whose refresh
+// token has not expired, least recently active first.
+func (r *SessionRepositoryGorm) GetLiveSessionsByUserIDInTx(tx *gorm.DB, userID domain.UserID) ([]*domain.Session, error) {
+	var models []SessionModel
+	err := tx.Where("user_id = ? AND is_active = true AND refresh_expires_at > ?", userID.String(), time.Now()).
+		Order("updated_at ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.Session, len(models))
+	for i, model := range models {
+		session, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		sessions[i] = session
+	}
+
+	return sessions, nil
+}
+
 func (r *SessionRepositoryGorm) InvalidateSession(ctx context.Context, sessionID domain.SessionID) error {
-	return r.db.WithContext(ctx).Model(&SessionModel{}).
+	return r.db.Conn(ctx).Model(&SessionModel{}).
 		Where("id = ?", sessionID.String()).
 		Update("is_active", false).Error
 }
 
 func (r *SessionRepositoryGorm) InvalidateAllUserSessions(ctx context.Context, userID domain.UserID, excludeSessionID domain.SessionID) error {
-	query := r.db.WithContext(ctx).Model(&SessionModel{}).
+	query := r.db.Conn(ctx).Model(&SessionModel{}).
 		Where("user_id = ? AND is_active = true", userID.String())
 
 	if excludeSessionID.String() != "" {
@@ -171,7 +256,46 @@ func (r *SessionRepositoryGorm) InvalidateAllUserSessions(ctx context.Context, u
 }
 
 func (r *SessionRepositoryGorm) UpdateLastActivity(ctx context.Context, sessionID domain.SessionID) error {
-	return r.db.WithContext(ctx).Model(&SessionModel{}).
+	return r.db.Conn(ctx).Model(&SessionModel{}).
 		Where("id = ? AND is_active = true", sessionID.String()).
 		Update("updated_at", time.Now()).Error
 }
+
+func (r *SessionRepositoryGorm) Rename(ctx context.Context, userID domain.UserID, sessionID domain.SessionID, name domain.SessionName) (bool, error) {
+	// UpdateColumn leaves updated_at alone; eviction orders sessions by it.
+	result := r.db.Conn(ctx).Model(&SessionModel{}).
+		Where("id = ? AND user_id = ? AND is_active = true", sessionID.String(), userID.String()).
+		UpdateColumn("name", name.String())
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindPurgeableIDs returns sessions that were deactivated, or whose refresh
+// token expired, before cutoff.
+func (r *SessionRepositoryGorm) FindPurgeableIDs(ctx context.Context, cutoff time.Time, limit int) ([]domain.SessionID, error) {
+	var ids []string
+	err := r.db.Conn(ctx).Model(&SessionModel{}).
+		Where("(is_active = false AND updated_at < ?) OR refresh_expires_at < ?", cutoff, cutoff).
+		Order("updated_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessionIDs := make([]domain.SessionID, len(ids))
+	for i, id := range ids {
+		sessionIDs[i] = domain.SessionID(id)
+	}
+
+	return sessionIDs, nil
+}
+
+func (r *SessionRepositoryGorm) DeleteInTx(tx *gorm.DB, sessionIDs []domain.SessionID) (int64, error) {
+	ids := make([]string, len(sessionIDs))
+	for i, sessionID := range sessionIDs {
+		ids[i] = sessionID.String()
+	}
+
+	result := tx.Where("id IN ?", ids).Delete(&SessionModel{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,97 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+func newMockDatabase(t *testing.T) (*database.Database, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	require.NoError(t, err)
+
+	return database.NewFromGorm(db), mock
+}
+
+func newTestSession(t *testing.T) *domain.Session {
+	t.Helper()
+
+	now := time.Now()
+	session, err := domain.NewSession(
+		domain.NewUserID(),
+		"header.payload.signature",
+		"fingerprint",
+		"192.0.2.1",
+		"Mozilla/5.0",
+		now.Add(15*time.Minute),
+		now.Add(24*time.Hour),
+	)
+	require.NoError(t, err)
+
+	return session
+}
+
+func TestSessionCreateIsRolledBackWithAmbientTransaction(t *testing.T) {
+	// Arrange
+	db, mock := newMockDatabase(t)
+	repo := repositories.NewSessionRepository(db)
+	txManager := database.NewTransactionManager(db)
+	errAbort := errors.New("abort")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "sessions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	// Act
+	err := txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.Create(ctx, newTestSession(t)); err != nil {
+			return err
+		}
+		return errAbort
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errAbort)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionCreateIsCommittedWithAmbientTransaction(t *testing.T) {
+	// Arrange
+	db, mock := newMockDatabase(t)
+	repo := repositories.NewSessionRepository(db)
+	txManager := database.NewTransactionManager(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "sessions"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Act
+	err := txManager.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := repo.Create(ctx, newTestSession(t))
+		return err
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,15 +2,43 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"beerdosan-backend/internal/app/domain"
 	"beerdosan-backend/internal/pkg/database"
+
+	"gorm.io/gorm"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) (*domain.User, error)
+	CreateInTx(tx *gorm.DB, user *domain.User) (*domain.User, error)
 	GetByID(ctx context.Context, id domain.UserID) (*domain.User, error)
+	GetByIDForUpdateInTx(tx *gorm.DB, id domain.UserID) (*domain.User, error)
 	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetByVerifiedPhoneNumber(ctx context.Context, phoneNumber domain.PhoneNumber) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
+	// ReplacePasswordHash stores newHash if the stored hash is still oldHash,
+	// leaving every other column alone. It reports whether it did.
+	ReplacePasswordHash(ctx context.Context, id domain.UserID, oldHash, newHash domain.HashedPassword) (bool, error)
+	// ScheduleDeletion marks the user deleted, to be purged at purgeAt,
+	// leaving every other column alone. It reports whether the user exists.
+	ScheduleDeletion(ctx context.Context, id domain.UserID, purgeAt time.Time) (bool, error)
+	// CancelDeletion reactivates the user if their deletion is still
+	// pending, leaving every other column alone. It reports whether it did.
+	CancelDeletion(ctx context.Context, id domain.UserID) (bool, error)
+	// ListAfterID returns up to limit users ordered by id, starting after
+	// afterID; pass "" for the first page.
+	ListAfterID(ctx context.Context, afterID domain.UserID, limit int) ([]*domain.User, error)
+
+	// FindIDsDueForPurge returns users whose scheduled deletion time has
+	// passed.
+	FindIDsDueForPurge(ctx context.Context, now time.Time, limit int) ([]domain.UserID, error)
+	// PurgeInTx anonymises a user scheduled for deletion, soft deletes the
+	// row and deletes everything else stored about them. It reports whether
+	// the user was purged.
+	PurgeInTx(tx *gorm.DB, id domain.UserID) (bool, error)
 }
 
 type UserRepositoryGorm struct {
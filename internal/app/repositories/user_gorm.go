@@ -6,19 +6,29 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"beerdosan-backend/internal/app/domain"
 )
 
 type UserModel struct {
 	ID        string `gorm:"type:uuid;primaryKey"`
-	Username  string `gorm:"type:varchar(50);uniqueIndex;not null"`
-	Email     string `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Username  string `gorm:"type:varchar(50);uniqueIndex:idx_users_username_lower,expression:LOWER(username);not null"`
+	Email     string `gorm:"type:varchar(255);uniqueIndex:idx_users_email_lower,expression:LOWER(email);not null"`
 	FirstName string `gorm:"type:varchar(100);not null"`
 	LastName  string `gorm:"type:varchar(100);not null"`
 	Password  string `gorm:"type:text;not null"`
 	Role      string `gorm:"type:varchar(20);default:'user'"`
 	Status    string `gorm:"type:varchar(20);default:'active'"`
+
+	PhoneNumber         *string `gorm:"type:varchar(16)"`
+	PhoneNumberVerified bool    `gorm:"not null;default:false"`
+
+	PasswordChangedAt  time.Time `gorm:"not null"`
+	MustChangePassword bool      `gorm:"not null;default:false"`
+
+	DeletionScheduledAt *time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -38,6 +48,11 @@ func (u *UserModel) ToDomain() (*domain.User, error) {
 		u.Password,
 		u.Role,
 		u.Status,
+		phoneNumberFromModel(u.PhoneNumber),
+		u.PhoneNumberVerified,
+		u.MustChangePassword,
+		u.PasswordChangedAt,
+		u.DeletionScheduledAt,
 		u.CreatedAt,
 		u.UpdatedAt,
 	)
@@ -53,6 +68,15 @@ func CreateModelFromDomain(user *domain.User) *UserModel {
 		Password:  user.Password().String(),
 		Role:      user.Role().String(),
 		Status:    user.Status().String(),
+
+		PhoneNumber:         phoneNumberToModel(user.PhoneNumber()),
+		PhoneNumberVerified: user.PhoneNumberVerified(),
+
+		PasswordChangedAt:  user.PasswordChangedAt(),
+		MustChangePassword: user.MustChangePassword(),
+
+		DeletionScheduledAt: user.DeletionScheduledAt(),
+
 		CreatedAt: user.CreatedAt().Time(),
 		UpdatedAt: user.UpdatedAt().Time(),
 	}
@@ -67,15 +91,45 @@ func CreateNewModelFromDomain(user *domain.User) *UserModel {
 		Password:  user.Password().String(),
 		Role:      user.Role().String(),
 		Status:    user.Status().String(),
+
+		PhoneNumber:         phoneNumberToModel(user.PhoneNumber()),
+		PhoneNumberVerified: user.PhoneNumberVerified(),
+
+		PasswordChangedAt:  user.PasswordChangedAt(),
+		MustChangePassword: user.MustChangePassword(),
+
+		DeletionScheduledAt: user.DeletionScheduledAt(),
+
 		CreatedAt: user.CreatedAt().Time(),
 		UpdatedAt: user.UpdatedAt().Time(),
 	}
 }
 
+// The column is NULL rather than empty for users without a phone number, so
+// that the unique index on verified numbers ignores them.
+func phoneNumberToModel(phoneNumber domain.PhoneNumber) *string {
+	if phoneNumber.IsEmpty() {
+		return nil
+	}
+	value := phoneNumber.String()
+	return &value
+}
+
+func phoneNumberFromModel(phoneNumber *string) string {
+	if phoneNumber == nil {
+		return ""
+	}
+	return *phoneNumber
+}
+
 func (r *UserRepositoryGorm) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
+	return r.CreateInTx(r.db.Conn(ctx), user)
+}
+
+func (r *UserRepositoryGorm) CreateInTx(tx *gorm.DB, user *domain.User) (*domain.User, error) {
 	model := CreateNewModelFromDomain(user)
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+	if err := tx.Create(model).Error; err != nil {
 		return nil, err
 	}
 
@@ -84,7 +138,22 @@ func (r *UserRepositoryGorm) Create(ctx context.Context, user *domain.User) (*do
 
 func (r *UserRepositoryGorm) GetByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
 	var model UserModel
-	err := r.db.WithContext(ctx).First(&model, "id = ?", id.String()).Error
+	err := r.db.Conn(ctx).First(&model, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+// GetByIDForUpdateInTx loads the user with a row lock held until tx ends, which
+// serializes concurrent writers that lock the same user first.
+func (r *UserRepositoryGorm) GetByIDForUpdateInTx(tx *gorm.DB, id domain.UserID) (*domain.User, error) {
+	var model UserModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, "id = ?", id.String()).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -97,12 +166,94 @@ func (r *UserRepositoryGorm) GetByID(ctx context.Context, id domain.UserID) (*do
 
 func (r *UserRepositoryGorm) Update(ctx context.Context, user *domain.User) error {
 	model := CreateModelFromDomain(user)
-	return r.db.WithContext(ctx).Save(model).Error
+	return r.db.Conn(ctx).Save(model).Error
+}
+
+func (r *UserRepositoryGorm) ReplacePasswordHash(ctx context.Context, id domain.UserID, oldHash, newHash domain.HashedPassword) (bool, error) {
+	result := r.db.Conn(ctx).Model(&UserModel{}).
+		Where("id = ? AND password = ?", id.String(), oldHash.String()).
+		Update("password", newHash.String())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *UserRepositoryGorm) ScheduleDeletion(ctx context.Context, id domain.UserID, purgeAt time.Time) (bool, error) {
+	result := r.db.Conn(ctx).Model(&UserModel{}).
+		Where("id = ?", id.String()).
+		Updates(map[string]interface{}{
+			"status":                domain.StatusDeleted.String(),
+			"deletion_scheduled_at": purgeAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *UserRepositoryGorm) CancelDeletion(ctx context.Context, id domain.UserID) (bool, error) {
+	result := r.db.Conn(ctx).Model(&UserModel{}).
+		Where("id = ? AND status = ? AND deletion_scheduled_at IS NOT NULL", id.String(), domain.StatusDeleted.String()).
+		Updates(map[string]interface{}{
+			"status":                domain.StatusActive.String(),
+			"deletion_scheduled_at": nil,
+		})
+	return result.RowsAffected > 0, result.Error
 }
 
+func (r *UserRepositoryGorm) ListAfterID(ctx context.Context, afterID domain.UserID, limit int) ([]*domain.User, error) {
+	query := r.db.Conn(ctx).Order("id").Limit(limit)
+	if afterID != "" {
+		query = query.Where("id > ?", afterID.String())
+	}
+
+	var models []UserModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, len(models))
+	for i, model := range models {
+		user, err := model.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		users[i] = user
+	}
+
+	return users, nil
+}
+
+// GetByUsername matches case-insensitively, like the unique index on
+// LOWER(username).
 func (r *UserRepositoryGorm) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	var model UserModel
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&model).Error
+	err := r.db.Conn(ctx).Where("LOWER(username) = LOWER(?)", username).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+// GetByVerifiedPhoneNumber ignores users who added the number but have not
+// verified it.
+func (r *UserRepositoryGorm) GetByVerifiedPhoneNumber(ctx context.Context, phoneNumber domain.PhoneNumber) (*domain.User, error) {
+	var model UserModel
+	err := r.db.Conn(ctx).Where("phone_number = ? AND phone_number_verified", phoneNumber.String()).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.ToDomain()
+}
+
+// GetByEmail matches the address case-insensitively, as users rarely type it
+// the way they registered it.
+func (r *UserRepositoryGorm) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var model UserModel
+	err := r.db.Conn(ctx).Where("LOWER(email) = LOWER(?)", email).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -112,3 +263,61 @@ func (r *UserRepositoryGorm) GetByUsername(ctx context.Context, username string)
 
 	return model.ToDomain()
 }
+
+// FindIDsDueForPurge skips rows already purged, which are soft deleted.
+func (r *UserRepositoryGorm) FindIDsDueForPurge(ctx context.Context, now time.Time, limit int) ([]domain.UserID, error) {
+	var ids []string
+	err := r.db.Conn(ctx).Model(&UserModel{}).
+		Where("status = ? AND deletion_scheduled_at <= ?", domain.StatusDeleted.String(), now).
+		Order("deletion_scheduled_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]domain.UserID, len(ids))
+	for i, id := range ids {
+		userIDs[i] = domain.UserID(id)
+	}
+
+	return userIDs, nil
+}
+
+// PurgeInTx keeps the row, under placeholder values, so that the id is never
+// reused. It does nothing for a user whose deletion was cancelled meanwhile.
+func (r *UserRepositoryGorm) PurgeInTx(tx *gorm.DB, id domain.UserID) (bool, error) {
+	var model UserModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ? AND deletion_scheduled_at IS NOT NULL", domain.StatusDeleted.String()).
+		First(&model, "id = ?", id.String()).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Login attempts are recorded by username, not user id.
+	if err := tx.Where("username = ?", model.Username).Delete(&LoginAttemptModel{}).Error; err != nil {
+		return false, err
+	}
+	for _, dependent := range []interface{}{&SessionModel{}, &PasswordHistoryModel{}, &MagicLinkModel{}, &OTPModel{}, &EmailChangeModel{}} {
+		if err := tx.Where("user_id = ?", id.String()).Delete(dependent).Error; err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Model(&UserModel{}).Where("id = ?", id.String()).Updates(map[string]interface{}{
+		"username":              "deleted-" + id.String(),
+		"email":                 id.String() + "@deleted.invalid",
+		"first_name":            "Deleted",
+		"last_name":             "User",
+		"password":              "",
+		"phone_number":          nil,
+		"phone_number_verified": false,
+		"deletion_scheduled_at": nil,
+		"deleted_at":            time.Now(),
+	}).Error
+	return err == nil, err
+}
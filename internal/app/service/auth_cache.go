@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/cache"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+const (
+	invalidateUserPrefix    = "user:"
+	invalidateSessionPrefix = "session:"
+)
+
+// AuthCacheConfig bounds the user and session caches consulted by
+// ValidateToken. A size of zero disables the corresponding cache.
+type AuthCacheConfig struct {
+	MaxUsers    int
+	MaxSessions int
+	TTL         time.Duration
+}
+
+func DefaultAuthCacheConfig() AuthCacheConfig {
+	return AuthCacheConfig{
+		MaxUsers:    10000,
+		MaxSessions: 10000,
+		TTL:         30 * time.Second,
+	}
+}
+
+// InvalidationPublisher tells other replicas to drop cache entries; see
+// database.Notifier.
+type InvalidationPublisher interface {
+	Notify(ctx context.Context, payload string) error
+}
+
+// AuthCache holds users and sessions by ID so that authenticated requests do
+// not hit the database on every call. Entries are dropped when this process
+// changes them, when another replica announces a change through the
+// publisher, and at the latest after the TTL.
+type AuthCache struct {
+	users     *cache.LRU[domain.UserID, *domain.User]
+	sessions  *cache.LRU[domain.SessionID, *domain.Session]
+	publisher InvalidationPublisher
+}
+
+// NewAuthCache creates the cache. publisher may be nil, in which case
+// invalidations stay local to this process.
+func NewAuthCache(cfg AuthCacheConfig, publisher InvalidationPublisher) *AuthCache {
+	return &AuthCache{
+		users:     cache.NewLRU[domain.UserID, *domain.User]("auth_users", cfg.MaxUsers, cfg.TTL),
+		sessions:  cache.NewLRU[domain.SessionID, *domain.Session]("auth_sessions", cfg.MaxSessions, cfg.TTL),
+		publisher: publisher,
+	}
+}
+
+// InvalidateSession drops a session locally and on every other replica. Inside
+// a transaction both happen once it commits, so that no replica re-caches the
+// session as it was before the change.
+func (c *AuthCache) InvalidateSession(ctx context.Context, sessionID domain.SessionID) {
+	c.publish(ctx, invalidateSessionPrefix+sessionID.String())
+	database.AfterCommit(ctx, func() {
+		c.sessions.Delete(sessionID)
+	})
+}
+
+// InvalidateUser drops a user and all of their sessions locally and on every
+// other replica, after the surrounding transaction commits if there is one.
+func (c *AuthCache) InvalidateUser(ctx context.Context, userID domain.UserID) {
+	c.publish(ctx, invalidateUserPrefix+userID.String())
+	database.AfterCommit(ctx, func() {
+		c.dropUser(userID)
+	})
+}
+
+// HandleNotification applies an invalidation published by any replica.
+func (c *AuthCache) HandleNotification(payload string) {
+	switch {
+	case strings.HasPrefix(payload, invalidateUserPrefix):
+		c.dropUser(domain.UserID(strings.TrimPrefix(payload, invalidateUserPrefix)))
+	case strings.HasPrefix(payload, invalidateSessionPrefix):
+		c.sessions.Delete(domain.SessionID(strings.TrimPrefix(payload, invalidateSessionPrefix)))
+	}
+}
+
+// Purge drops everything, e.g. after invalidations may have been missed.
+func (c *AuthCache) Purge() {
+	c.users.Purge()
+	c.sessions.Purge()
+}
+
+func (c *AuthCache) dropUser(userID domain.UserID) {
+	c.users.Delete(userID)
+	c.sessions.DeleteFunc(func(_ domain.SessionID, session *domain.Session) bool {
+		return session.UserID() == userID
+	})
+}
+
+func (c *AuthCache) publish(ctx context.Context, payload string) {
+	if c.publisher == nil {
+		return
+	}
+	if err := c.publisher.Notify(ctx, payload); err != nil {
+		// Other replicas fall back to the TTL.
+		log.Printf("[WARN] failed to publish cache invalidation %s: %v", payload, err)
+	}
+}
@@ -2,20 +2,82 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"beerdosan-backend/internal/app/domain"
 	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/pkg/database"
 )
 
 type AuthService interface {
-	ValidateCredentials(ctx context.Context, username, password string) (*domain.User, error)
-	CreateSession(ctx context.Context, userID domain.UserID, deviceInfo, ipAddress string) (*domain.Session, error)
+	// ValidateCredentials accepts a username or an email address as the
+	// identifier.
+	ValidateCredentials(ctx context.Context, identifier, password string) (*domain.User, error)
+	// LoginAttemptKey is the name login attempts for identifier are recorded
+	// and rate-limited under: the account's username when identifier names
+	// one, so that its email and username share a limit, and identifier
+	// itself otherwise.
+	LoginAttemptKey(ctx context.Context, identifier string) string
+	CreateSession(ctx context.Context, userID domain.UserID, deviceInfo, ipAddress string, rememberMe bool) (*domain.Session, error)
 	ValidateSession(ctx context.Context, sessionID domain.SessionID) (*domain.Session, error)
 	InvalidateSession(ctx context.Context, sessionID domain.SessionID) error
 	InvalidateAllUserSessions(ctx context.Context, userID domain.UserID, excludeSessionID domain.SessionID) error
+	// InvalidateUser drops cached copies of the user after it was changed.
+	InvalidateUser(ctx context.Context, userID domain.UserID)
 	UpdateSessionActivity(ctx context.Context, sessionID domain.SessionID) error
 	RecordLoginAttempt(ctx context.Context, username, ipAddress string, success bool, failureReason string) error
 	CheckRateLimit(ctx context.Context, username, ipAddress string) error
 	ValidateToken(ctx context.Context, token string) (*AuthClaims, error)
+	PasswordChangeRequired(user *domain.User) bool
+	AccessScopes(user *domain.User) []domain.Scope
+}
+
+// SessionLimitConfig caps how many live sessions a user may hold. A limit of
+// zero or less means unlimited.
+type SessionLimitConfig struct {
+	MaxActiveSessions int
+	RoleLimits        map[domain.UserRole]int
+	Policy            domain.SessionLimitPolicy
+}
+
+func DefaultSessionLimitConfig() SessionLimitConfig {
+	return SessionLimitConfig{
+		MaxActiveSessions: 0,
+		RoleLimits:        map[domain.UserRole]int{},
+		Policy:            domain.SessionLimitPolicyReject,
+	}
+}
+
+// LimitFor returns the session limit for role, falling back to
+// MaxActiveSessions when the role has no override.
+func (c SessionLimitConfig) LimitFor(role domain.UserRole) int {
+	if limit, ok := c.RoleLimits[role]; ok {
+		return limit
+	}
+	return c.MaxActiveSessions
+}
+
+// PasswordExpiryConfig sets how long a password stays valid. A max age of zero
+// or less means passwords never expire.
+type PasswordExpiryConfig struct {
+	MaxAge     time.Duration
+	RoleMaxAge map[domain.UserRole]time.Duration
+}
+
+func DefaultPasswordExpiryConfig() PasswordExpiryConfig {
+	return PasswordExpiryConfig{
+		MaxAge:     0,
+		RoleMaxAge: map[domain.UserRole]time.Duration{},
+	}
+}
+
+// MaxAgeFor returns the password max age for role, falling back to MaxAge
+// when the role has no override.
+func (c PasswordExpiryConfig) MaxAgeFor(role domain.UserRole) time.Duration {
+	if maxAge, ok := c.RoleMaxAge[role]; ok {
+		return maxAge
+	}
+	return c.MaxAge
 }
 
 type AuthServiceImpl struct {
@@ -24,6 +86,10 @@ type AuthServiceImpl struct {
 	loginAttemptRepo repositories.LoginAttemptRepository
 	passwordService  PasswordService
 	jwtService       JWTService
+	transactionMgr   database.TransactionManagerInterface
+	sessionLimits    SessionLimitConfig
+	passwordExpiry   PasswordExpiryConfig
+	cache            *AuthCache
 }
 
 func NewAuthService(
@@ -32,13 +98,28 @@ func NewAuthService(
 	loginAttemptRepo repositories.LoginAttemptRepository,
 	passwordService PasswordService,
 	jwtService JWTService,
+	transactionMgr database.TransactionManagerInterface,
+	sessionLimits SessionLimitConfig,
+	passwordExpiry PasswordExpiryConfig,
+	authCache *AuthCache,
 ) *AuthServiceImpl {
+	if sessionLimits.Policy == "" {
+		sessionLimits.Policy = domain.SessionLimitPolicyReject
+	}
+	if authCache == nil {
+		authCache = NewAuthCache(AuthCacheConfig{}, nil)
+	}
+
 	return &AuthServiceImpl{
 		userRepo:         userRepo,
 		sessionRepo:      sessionRepo,
 		loginAttemptRepo: loginAttemptRepo,
 		passwordService:  passwordService,
 		jwtService:       jwtService,
+		transactionMgr:   transactionMgr,
+		sessionLimits:    sessionLimits,
+		passwordExpiry:   passwordExpiry,
+		cache:            authCache,
 	}
 }
 
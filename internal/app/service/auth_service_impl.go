@@ -4,24 +4,49 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"beerdosan-backend/internal/app/domain"
+
+	"gorm.io/gorm"
 )
 
 type AuthClaims struct {
-	UserID      int64  `json:"user_id"`
-	Username    string `json:"username"`
-	Email       string `json:"email"`
-	SessionID   int64  `json:"session_id"`
-	Role        string `json:"role"`
-	UserUUID    string `json:"user_uuid"`
-	SessionUUID string `json:"session_uuid"`
-}
-
-func (s *AuthServiceImpl) ValidateCredentials(ctx context.Context, username, password string) (*domain.User, error) {
-	log.Printf("[DEBUG] ValidateCredentials called: username=%s", username)
-	user, err := s.userRepo.GetByUsername(ctx, username)
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Role        string   `json:"role"`
+	Scopes      []string `json:"scopes"`
+	UserUUID    string   `json:"user_uuid"`
+	SessionUUID string   `json:"session_uuid"`
+	// AuthTime is when the user last entered a credential. It is zero for
+	// tokens issued before the auth_time claim existed.
+	AuthTime time.Time `json:"auth_time"`
+}
+
+// AuthenticatedWithin reports whether the user authenticated no longer than
+// maxAge ago. Tokens without an authentication time never qualify.
+func (c *AuthClaims) AuthenticatedWithin(maxAge time.Duration) bool {
+	if c.AuthTime.IsZero() {
+		return false
+	}
+	return time.Since(c.AuthTime) <= maxAge
+}
+
+// HasScope reports whether the token grants scope, either directly or through
+// full access.
+func (c *AuthClaims) HasScope(scope domain.Scope) bool {
+	for _, s := range c.Scopes {
+		if s == scope.String() || s == domain.ScopeFullAccess.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *AuthServiceImpl) ValidateCredentials(ctx context.Context, identifier, password string) (*domain.User, error) {
+	log.Printf("[DEBUG] ValidateCredentials called: identifier=%s", identifier)
+	user, err := s.findUserByIdentifier(ctx, identifier)
 	if err != nil {
 		return nil, domain.ErrInvalidCredentials
 	}
@@ -34,65 +59,179 @@ func (s *AuthServiceImpl) ValidateCredentials(ctx context.Context, username, pas
 		return nil, domain.ErrInvalidCredentials
 	}
 
+	s.rehashPassword(ctx, user, password)
+
 	return user, nil
 }
 
-func (s *AuthServiceImpl) CreateSession(ctx context.Context, userID domain.UserID, deviceInfo, ipAddress string) (*domain.Session, error) {
-	log.Printf("[DEBUG] CreateSession called: userID=%s deviceInfo=%s ipAddress=%s", userID, deviceInfo, ipAddress)
+func (s *AuthServiceImpl) LoginAttemptKey(ctx context.Context, identifier string) string {
+	user, err := s.findUserByIdentifier(ctx, identifier)
+	if err != nil || user == nil {
+		return identifier
+	}
+	return user.Username().String()
+}
 
-	user, err := s.userRepo.GetByID(ctx, userID)
+// findUserByIdentifier looks an identifier with an @ up as an email address
+// first, falling back to the username for accounts created before usernames
+// were barred from containing one.
+func (s *AuthServiceImpl) findUserByIdentifier(ctx context.Context, identifier string) (*domain.User, error) {
+	if strings.Contains(identifier, "@") {
+		if email, err := domain.NewEmail(identifier); err == nil {
+			user, err := s.userRepo.GetByEmail(ctx, email.String())
+			if err != nil || user != nil {
+				return user, err
+			}
+		}
+	}
+	return s.userRepo.GetByUsername(ctx, domain.NormalizeUsername(identifier))
+}
+
+// rehashPassword upgrades the stored hash to the configured algorithm and cost
+// while the plain password is at hand. Only the hash is written, and only if
+// it was not changed meanwhile. Failures only delay the upgrade to a later
+// login.
+func (s *AuthServiceImpl) rehashPassword(ctx context.Context, user *domain.User, plainPassword string) {
+	oldHash := user.Password()
+	rehashed, err := user.RehashPassword(plainPassword)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user for session creation: %w", err)
+		log.Printf("[WARN] failed to rehash password: userID=%s err=%v", user.ID(), err)
+		return
 	}
-	if user == nil {
-		return nil, domain.ErrUserNotFound
+	if !rehashed {
+		return
 	}
 
-	deviceFingerprint, err := domain.GenerateDeviceFingerprint(deviceInfo, ipAddress)
+	replaced, err := s.userRepo.ReplacePasswordHash(ctx, user.ID(), oldHash, user.Password())
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate device fingerprint: %w", err)
+		log.Printf("[WARN] failed to store rehashed password: userID=%s err=%v", user.ID(), err)
+		return
+	}
+	if !replaced {
+		return
 	}
 
-	expiresAt := time.Now().Add(15 * time.Minute)
-	refreshExpiresAt := time.Now().Add(7 * 24 * time.Hour)
+	s.cache.InvalidateUser(ctx, user.ID())
+}
 
-	sessionID := domain.NewSessionID()
+func (s *AuthServiceImpl) CreateSession(ctx context.Context, userID domain.UserID, deviceInfo, ipAddress string, rememberMe bool) (*domain.Session, error) {
+	log.Printf("[DEBUG] CreateSession called: userID=%s deviceInfo=%s ipAddress=%s rememberMe=%v", userID, deviceInfo, ipAddress, rememberMe)
 
-	accessToken, err := s.jwtService.GenerateAccessToken(userID, sessionID, user.Role())
+	deviceFingerprint, err := domain.GenerateDeviceFingerprint(deviceInfo, ipAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, fmt.Errorf("failed to generate device fingerprint: %w", err)
 	}
 
-	refreshToken, err := s.jwtService.GenerateRefreshToken(userID, sessionID)
+	var (
+		createdSession  *domain.Session
+		evictedSessions []domain.SessionID
+	)
+	err = s.transactionMgr.ExecuteWithOptions(ctx, nil, func(tx *gorm.DB) error {
+		// Locking the user row makes concurrent logins for the same user take
+		// turns, so the session count below cannot go stale before the insert.
+		user, err := s.userRepo.GetByIDForUpdateInTx(tx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user for session creation: %w", err)
+		}
+		if user == nil {
+			return domain.ErrUserNotFound
+		}
+
+		evicted, err := s.enforceSessionLimit(tx, user)
+		if err != nil {
+			return err
+		}
+		evictedSessions = evicted
+
+		expiresAt := time.Now().Add(s.jwtService.AccessTokenDuration())
+		refreshExpiresAt := time.Now().Add(s.jwtService.RefreshTokenDuration(rememberMe))
+
+		sessionID := domain.NewSessionID()
+		authTime := time.Now()
+
+		accessToken, err := s.jwtService.GenerateAccessToken(userID, sessionID, user.Role(), authTime, s.AccessScopes(user))
+		if err != nil {
+			return fmt.Errorf("failed to generate access token: %w", err)
+		}
+
+		refreshToken, err := s.jwtService.GenerateRefreshToken(userID, sessionID, user.Role(), authTime, rememberMe)
+		if err != nil {
+			return fmt.Errorf("failed to generate refresh token: %w", err)
+		}
+
+		session, err := domain.ReconstructSession(
+			sessionID.String(),
+			userID.String(),
+			accessToken.String(),
+			refreshToken.String(),
+			deviceFingerprint.String(),
+			ipAddress,
+			deviceInfo,
+			"",
+			domain.ParseUserAgent(deviceInfo),
+			true,
+			rememberMe,
+			expiresAt,
+			refreshExpiresAt,
+			time.Now(),
+			time.Now(),
+			time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create session: %w", err)
+		}
+
+		createdSession, err = s.sessionRepo.CreateInTx(tx, session)
+		if err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
-	session, err := domain.ReconstructSession(
-		sessionID.String(),
-		userID.String(),
-		accessToken.String(),
-		refreshToken.String(),
-		deviceFingerprint.String(),
-		ipAddress,
-		deviceInfo,
-		true,
-		expiresAt,
-		refreshExpiresAt,
-		time.Now(),
-		time.Now(),
-		time.Now(),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+	for _, sessionID := range evictedSessions {
+		s.cache.InvalidateSession(ctx, sessionID)
 	}
 
-	createdSession, err := s.sessionRepo.Create(ctx, session)
+	return createdSession, nil
+}
+
+// enforceSessionLimit makes room for one more session for user, either by
+// rejecting the login or by deactivating the least recently active sessions.
+// It must run inside the transaction that holds the user's row lock.
+func (s *AuthServiceImpl) enforceSessionLimit(tx *gorm.DB, user *domain.User) ([]domain.SessionID, error) {
+	limit := s.sessionLimits.LimitFor(user.Role())
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	sessions, err := s.sessionRepo.GetLiveSessionsByUserIDInTx(tx, user.ID())
 	if err != nil {
-		return nil, fmt.Errorf("failed to save session: %w", err)
+		return nil, fmt.Errorf("failed to count active sessions: %w", err)
 	}
 
-	return createdSession, nil
+	excess := len(sessions) - limit + 1
+	if excess <= 0 {
+		return nil, nil
+	}
+
+	if !s.sessionLimits.Policy.IsEvictOldest() {
+		return nil, domain.ErrSessionLimitReached
+	}
+
+	evicted := make([]domain.SessionID, 0, excess)
+	for _, session := range sessions[:excess] {
+		session.Deactivate()
+		if err := s.sessionRepo.UpdateInTx(tx, session); err != nil {
+			return nil, fmt.Errorf("failed to evict session %s: %w", session.ID(), err)
+		}
+		evicted = append(evicted, session.ID())
+	}
+
+	return evicted, nil
 }
 
 func (s *AuthServiceImpl) ValidateSession(ctx context.Context, sessionID domain.SessionID) (*domain.Session, error) {
@@ -111,12 +250,28 @@ func (s *AuthServiceImpl) ValidateSession(ctx context.Context, sessionID domain.
 
 func (s *AuthServiceImpl) InvalidateSession(ctx context.Context, sessionID domain.SessionID) error {
 	log.Printf("[DEBUG] InvalidateSession called: sessionID=%s", sessionID)
-	return s.sessionRepo.InvalidateSession(ctx, sessionID)
+	if err := s.sessionRepo.InvalidateSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	s.cache.InvalidateSession(ctx, sessionID)
+	return nil
 }
 
+// InvalidateAllUserSessions also drops the cached user, since callers use it
+// after changes to the user itself such as a new password or deactivation.
 func (s *AuthServiceImpl) InvalidateAllUserSessions(ctx context.Context, userID domain.UserID, excludeSessionID domain.SessionID) error {
 	log.Printf("[DEBUG] InvalidateAllUserSessions called: userID=%s excludeSessionID=%s", userID, excludeSessionID)
-	return s.sessionRepo.InvalidateAllUserSessions(ctx, userID, excludeSessionID)
+	if err := s.sessionRepo.InvalidateAllUserSessions(ctx, userID, excludeSessionID); err != nil {
+		return err
+	}
+
+	s.cache.InvalidateUser(ctx, userID)
+	return nil
+}
+
+func (s *AuthServiceImpl) InvalidateUser(ctx context.Context, userID domain.UserID) {
+	s.cache.InvalidateUser(ctx, userID)
 }
 
 func (s *AuthServiceImpl) UpdateSessionActivity(ctx context.Context, sessionID domain.SessionID) error {
@@ -161,6 +316,21 @@ func (s *AuthServiceImpl) CheckRateLimit(ctx context.Context, username, ipAddres
 	return nil
 }
 
+// PasswordChangeRequired reports whether user must set a new password before
+// getting full access, because an administrator demanded it or the password
+// is older than the max age for the user's role.
+func (s *AuthServiceImpl) PasswordChangeRequired(user *domain.User) bool {
+	return user.MustChangePassword() || user.PasswordExpired(s.passwordExpiry.MaxAgeFor(user.Role()))
+}
+
+// AccessScopes returns the scopes to grant in access tokens issued to user.
+func (s *AuthServiceImpl) AccessScopes(user *domain.User) []domain.Scope {
+	if s.PasswordChangeRequired(user) {
+		return []domain.Scope{domain.ScopePasswordChange}
+	}
+	return []domain.Scope{domain.ScopeFullAccess}
+}
+
 func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*AuthClaims, error) {
 	jwtToken, err := domain.NewJWT(token)
 	if err != nil {
@@ -179,7 +349,7 @@ func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*Aut
 	userID := domain.UserID(claims.UserID)
 	sessionID := domain.SessionID(claims.SessionID)
 
-	userDomain, err := s.userRepo.GetByID(ctx, userID)
+	userDomain, err := s.cachedUser(ctx, userID)
 	if err != nil {
 		return nil, domain.ErrTokenInvalid
 	}
@@ -188,7 +358,7 @@ func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*Aut
 		return nil, domain.ErrTokenInvalid
 	}
 
-	sessionDomain, err := s.ValidateSession(ctx, sessionID)
+	sessionDomain, err := s.cachedValidSession(ctx, sessionID)
 	if err != nil {
 		return nil, domain.ErrInvalidSession
 	}
@@ -197,16 +367,56 @@ func (s *AuthServiceImpl) ValidateToken(ctx context.Context, token string) (*Aut
 		return nil, domain.ErrInvalidSession
 	}
 
-	userIDInt64 := uuidToInt64(userID.String())
-	sessionIDInt64 := uuidToInt64(sessionID.String())
+	// Legacy tokens carry no role.
+	role := claims.Role
+	if claims.Legacy {
+		role = userDomain.Role().String()
+	}
+
+	scopes := make([]string, 0, len(claims.Scopes))
+	for _, scope := range claims.Scopes {
+		scopes = append(scopes, scope.String())
+	}
 
 	return &AuthClaims{
-		UserID:      userIDInt64,
 		Username:    userDomain.Username().String(),
 		Email:       userDomain.Email().String(),
-		SessionID:   sessionIDInt64,
-		Role:        claims.Role,
+		Role:        role,
+		Scopes:      scopes,
 		UserUUID:    userID.String(),
 		SessionUUID: sessionID.String(),
+		AuthTime:    claims.AuthTime,
 	}, nil
 }
+
+func (s *AuthServiceImpl) cachedUser(ctx context.Context, userID domain.UserID) (*domain.User, error) {
+	if user, ok := s.cache.users.Get(userID); ok {
+		return user, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return user, err
+	}
+
+	s.cache.users.Set(userID, user)
+	return user, nil
+}
+
+// cachedValidSession is ValidateSession backed by the cache. A cached session
+// that no longer looks valid is re-read, because a refresh moves its expiry
+// without invalidating the cache.
+func (s *AuthServiceImpl) cachedValidSession(ctx context.Context, sessionID domain.SessionID) (*domain.Session, error) {
+	if session, ok := s.cache.sessions.Get(sessionID); ok && session.IsValid() {
+		return session, nil
+	}
+
+	session, err := s.ValidateSession(ctx, sessionID)
+	if err != nil {
+		s.cache.sessions.Delete(sessionID)
+		return nil, err
+	}
+
+	s.cache.sessions.Set(sessionID, session)
+	return session, nil
+}
@@ -1,13 +1,17 @@
 package service
 
 import (
+	"time"
+
 	"beerdosan-backend/internal/app/domain"
 )
 
 type JWTService interface {
-	GenerateAccessToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole) (domain.JWT, error)
-	GenerateRefreshToken(userID domain.UserID, sessionID domain.SessionID) (domain.JWT, error)
+	GenerateAccessToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, scopes []domain.Scope) (domain.JWT, error)
+	GenerateRefreshToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, rememberMe bool) (domain.JWT, error)
 	ValidateToken(token domain.JWT) (*domain.TokenClaims, error)
-	RefreshAccessToken(refreshToken domain.JWT) (domain.JWT, error)
+	RefreshAccessToken(refreshToken domain.JWT, scopes []domain.Scope) (domain.JWT, error)
 	RevokeToken(token domain.JWT) error
+	AccessTokenDuration() time.Duration
+	RefreshTokenDuration(rememberMe bool) time.Duration
 }
@@ -1,9 +1,8 @@
 package service
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
-	"strconv"
+	"time"
+
 	"beerdosan-backend/internal/app/domain"
 	"beerdosan-backend/internal/pkg/jwt"
 )
@@ -18,16 +17,8 @@ func NewJWTService(jwtService jwt.JWTService) JWTService {
 	}
 }
 
-func uuidToInt64(uuid string) int64 {
-	hash := sha256.Sum256([]byte(uuid))
-	return int64(binary.BigEndian.Uint64(hash[:8]))
-}
-
-func (s *jwtServiceImpl) GenerateAccessToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole) (domain.JWT, error) {
-	userIDInt64 := uuidToInt64(userID.String())
-	sessionIDInt64 := uuidToInt64(sessionID.String())
-
-	token, _, err := s.jwtService.GenerateAccessToken(userIDInt64, userID.String(), "", sessionIDInt64, sessionID.String())
+func (s *jwtServiceImpl) GenerateAccessToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, scopes []domain.Scope) (domain.JWT, error) {
+	token, _, err := s.jwtService.GenerateAccessToken(userID.String(), sessionID.String(), role.String(), authTime, scopeStrings(scopes))
 	if err != nil {
 		return "", err
 	}
@@ -35,11 +26,8 @@ func (s *jwtServiceImpl) GenerateAccessToken(userID domain.UserID, sessionID dom
 	return domain.JWT(token), nil
 }
 
-func (s *jwtServiceImpl) GenerateRefreshToken(userID domain.UserID, sessionID domain.SessionID) (domain.JWT, error) {
-	userIDInt64 := uuidToInt64(userID.String())
-	sessionIDInt64 := uuidToInt64(sessionID.String())
-
-	token, _, err := s.jwtService.GenerateRefreshToken(userIDInt64, userID.String(), sessionID.String(), sessionIDInt64)
+func (s *jwtServiceImpl) GenerateRefreshToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, rememberMe bool) (domain.JWT, error) {
+	token, _, err := s.jwtService.GenerateRefreshToken(userID.String(), sessionID.String(), role.String(), authTime, rememberMe)
 	if err != nil {
 		return "", err
 	}
@@ -53,32 +41,37 @@ func (s *jwtServiceImpl) ValidateToken(token domain.JWT) (*domain.TokenClaims, e
 		return nil, err
 	}
 
-	userID := claims.Username
-	sessionID := claims.Fingerprint
-
-	if sessionID == "" {
-		sessionID = claims.Email
+	scopes := make([]domain.Scope, 0, len(claims.Scopes()))
+	for _, scope := range claims.Scopes() {
+		scopes = append(scopes, domain.Scope(scope))
 	}
 
-	if userID == "" {
-		userID = strconv.FormatInt(claims.UserID, 10)
-	}
-	if sessionID == "" {
-		sessionID = strconv.FormatInt(claims.SessionID, 10)
+	// Legacy tokens predate scopes and were only ever issued for full access.
+	if claims.IsLegacy() {
+		scopes = []domain.Scope{domain.ScopeFullAccess}
 	}
 
 	return &domain.TokenClaims{
-		UserID:    userID,
-		SessionID: sessionID,
-		Role:      claims.TokenType,
+		UserID:    claims.UserID(),
+		SessionID: claims.SessionID,
+		Role:      claims.Role,
+		Scopes:    scopes,
 		TokenType: claims.TokenType,
+		Version:   claims.Version,
+		Legacy:    claims.IsLegacy(),
 		IssuedAt:  claims.IssuedAt.Time,
+		AuthTime:  claims.AuthenticatedAt(),
 		ExpiresAt: claims.ExpiresAt.Time,
 	}, nil
 }
 
-func (s *jwtServiceImpl) RefreshAccessToken(refreshToken domain.JWT) (domain.JWT, error) {
-	token, _, err := s.jwtService.RefreshAccessToken(refreshToken.String())
+func (s *jwtServiceImpl) RefreshAccessToken(refreshToken domain.JWT, scopes []domain.Scope) (domain.JWT, error) {
+	claims, err := s.jwtService.ValidateRefreshToken(refreshToken.String())
+	if err != nil {
+		return "", err
+	}
+
+	token, _, err := s.jwtService.GenerateAccessToken(claims.UserID(), claims.SessionID, claims.Role, claims.AuthenticatedAt(), scopeStrings(scopes))
 	if err != nil {
 		return "", err
 	}
@@ -93,3 +86,19 @@ func (s *jwtServiceImpl) RevokeToken(token domain.JWT) error {
 	// or updating the session status
 	return nil
 }
+
+func (s *jwtServiceImpl) AccessTokenDuration() time.Duration {
+	return s.jwtService.AccessTokenDuration()
+}
+
+func (s *jwtServiceImpl) RefreshTokenDuration(rememberMe bool) time.Duration {
+	return s.jwtService.RefreshTokenDuration(rememberMe)
+}
+
+func scopeStrings(scopes []domain.Scope) []string {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		out = append(out, scope.String())
+	}
+	return out
+}
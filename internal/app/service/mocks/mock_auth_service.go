@@ -24,65 +24,65 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
-// CheckRateLimit provides a mock function with given fields: ctx, username, ipAddress
-func (_m *MockAuthService) CheckRateLimit(ctx context.Context, username string, ipAddress string) error {
-	ret := _m.Called(ctx, username, ipAddress)
+// AccessScopes provides a mock function with given fields: user
+func (_m *MockAuthService) AccessScopes(user *domain.User) []domain.Scope {
+	ret := _m.Called(user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckRateLimit")
+		panic("no return value specified for AccessScopes")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, username, ipAddress)
+	var r0 []domain.Scope
+	if rf, ok := ret.Get(0).(func(*domain.User) []domain.Scope); ok {
+		r0 = rf(user)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Scope)
+		}
 	}
 
 	return r0
 }
 
-// MockAuthService_CheckRateLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckRateLimit'
-type MockAuthService_CheckRateLimit_Call struct {
+// MockAuthService_AccessScopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessScopes'
+type MockAuthService_AccessScopes_Call struct {
 	*mock.Call
 }
 
-// CheckRateLimit is a helper method to define mock.On call
-//   - ctx context.Context
-//   - username string
-//   - ipAddress string
-func (_e *MockAuthService_Expecter) CheckRateLimit(ctx interface{}, username interface{}, ipAddress interface{}) *MockAuthService_CheckRateLimit_Call {
-	return &MockAuthService_CheckRateLimit_Call{Call: _e.mock.On("CheckRateLimit", ctx, username, ipAddress)}
+// AccessScopes is a helper method to define mock.On call
+//   - user *domain.User
+func (_e *MockAuthService_Expecter) AccessScopes(user interface{}) *MockAuthService_AccessScopes_Call {
+	return &MockAuthService_AccessScopes_Call{Call: _e.mock.On("AccessScopes", user)}
 }
 
-func (_c *MockAuthService_CheckRateLimit_Call) Run(run func(ctx context.Context, username string, ipAddress string)) *MockAuthService_CheckRateLimit_Call {
+func (_c *MockAuthService_AccessScopes_Call) Run(run func(user *domain.User)) *MockAuthService_AccessScopes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(*domain.User))
 	})
 	return _c
 }
 
-func (_c *MockAuthService_CheckRateLimit_Call) Return(_a0 error) *MockAuthService_CheckRateLimit_Call {
+func (_c *MockAuthService_AccessScopes_Call) Return(_a0 []domain.Scope) *MockAuthService_AccessScopes_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockAuthService_CheckRateLimit_Call) RunAndReturn(run func(context.Context, string, string) error) *MockAuthService_CheckRateLimit_Call {
+func (_c *MockAuthService_AccessScopes_Call) RunAndReturn(run func(*domain.User) []domain.Scope) *MockAuthService_AccessScopes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CleanupExpiredSessions provides a mock function with given fields: ctx
-func (_m *MockAuthService) CleanupExpiredSessions(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// CheckRateLimit provides a mock function with given fields: ctx, username, ipAddress
+func (_m *MockAuthService) CheckRateLimit(ctx context.Context, username string, ipAddress string) error {
+	ret := _m.Called(ctx, username, ipAddress)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CleanupExpiredSessions")
+		panic("no return value specified for CheckRateLimit")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, ipAddress)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -90,37 +90,39 @@ func (_m *MockAuthService) CleanupExpiredSessions(ctx context.Context) error {
 	return r0
 }
 
-// MockAuthService_CleanupExpiredSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanupExpiredSessions'
-type MockAuthService_CleanupExpiredSessions_Call struct {
+// MockAuthService_CheckRateLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckRateLimit'
+type MockAuthService_CheckRateLimit_Call struct {
 	*mock.Call
 }
 
-// CleanupExpiredSessions is a helper method to define mock.On call
+// CheckRateLimit is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockAuthService_Expecter) CleanupExpiredSessions(ctx interface{}) *MockAuthService_CleanupExpiredSessions_Call {
-	return &MockAuthService_CleanupExpiredSessions_Call{Call: _e.mock.On("CleanupExpiredSessions", ctx)}
+//   - username string
+//   - ipAddress string
+func (_e *MockAuthService_Expecter) CheckRateLimit(ctx interface{}, username interface{}, ipAddress interface{}) *MockAuthService_CheckRateLimit_Call {
+	return &MockAuthService_CheckRateLimit_Call{Call: _e.mock.On("CheckRateLimit", ctx, username, ipAddress)}
 }
 
-func (_c *MockAuthService_CleanupExpiredSessions_Call) Run(run func(ctx context.Context)) *MockAuthService_CleanupExpiredSessions_Call {
+func (_c *MockAuthService_CheckRateLimit_Call) Run(run func(ctx context.Context, username string, ipAddress string)) *MockAuthService_CheckRateLimit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockAuthService_CleanupExpiredSessions_Call) Return(_a0 error) *MockAuthService_CleanupExpiredSessions_Call {
+func (_c *MockAuthService_CheckRateLimit_Call) Return(_a0 error) *MockAuthService_CheckRateLimit_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockAuthService_CleanupExpiredSessions_Call) RunAndReturn(run func(context.Context) error) *MockAuthService_CleanupExpiredSessions_Call {
+func (_c *MockAuthService_CheckRateLimit_Call) RunAndReturn(run func(context.Context, string, string) error) *MockAuthService_CheckRateLimit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateSession provides a mock function with given fields: ctx, userID, deviceInfo, ipAddress
-func (_m *MockAuthService) CreateSession(ctx context.Context, userID domain.UserID, deviceInfo string, ipAddress string) (*domain.Session, error) {
-	ret := _m.Called(ctx, userID, deviceInfo, ipAddress)
+// CreateSession provides a mock function with given fields: ctx, userID, deviceInfo, ipAddress, rememberMe
+func (_m *MockAuthService) CreateSession(ctx context.Context, userID domain.UserID, deviceInfo string, ipAddress string, rememberMe bool) (*domain.Session, error) {
+	ret := _m.Called(ctx, userID, deviceInfo, ipAddress, rememberMe)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSession")
@@ -128,19 +130,19 @@ func (_m *MockAuthService) CreateSession(ctx context.Context, userID domain.User
 
 	var r0 *domain.Session
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string) (*domain.Session, error)); ok {
-		return rf(ctx, userID, deviceInfo, ipAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string, bool) (*domain.Session, error)); ok {
+		return rf(ctx, userID, deviceInfo, ipAddress, rememberMe)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string) *domain.Session); ok {
-		r0 = rf(ctx, userID, deviceInfo, ipAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserID, string, string, bool) *domain.Session); ok {
+		r0 = rf(ctx, userID, deviceInfo, ipAddress, rememberMe)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, string, string) error); ok {
-		r1 = rf(ctx, userID, deviceInfo, ipAddress)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserID, string, string, bool) error); ok {
+		r1 = rf(ctx, userID, deviceInfo, ipAddress, rememberMe)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -158,13 +160,14 @@ type MockAuthService_CreateSession_Call struct {
 //   - userID domain.UserID
 //   - deviceInfo string
 //   - ipAddress string
-func (_e *MockAuthService_Expecter) CreateSession(ctx interface{}, userID interface{}, deviceInfo interface{}, ipAddress interface{}) *MockAuthService_CreateSession_Call {
-	return &MockAuthService_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, userID, deviceInfo, ipAddress)}
+//   - rememberMe bool
+func (_e *MockAuthService_Expecter) CreateSession(ctx interface{}, userID interface{}, deviceInfo interface{}, ipAddress interface{}, rememberMe interface{}) *MockAuthService_CreateSession_Call {
+	return &MockAuthService_CreateSession_Call{Call: _e.mock.On("CreateSession", ctx, userID, deviceInfo, ipAddress, rememberMe)}
 }
 
-func (_c *MockAuthService_CreateSession_Call) Run(run func(ctx context.Context, userID domain.UserID, deviceInfo string, ipAddress string)) *MockAuthService_CreateSession_Call {
+func (_c *MockAuthService_CreateSession_Call) Run(run func(ctx context.Context, userID domain.UserID, deviceInfo string, ipAddress string, rememberMe bool)) *MockAuthService_CreateSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(domain.UserID), args[2].(string), args[3].(string), args[4].(bool))
 	})
 	return _c
 }
@@ -174,7 +177,7 @@ func (_c *MockAuthService_CreateSession_Call) Return(_a0 *domain.Session, _a1 er
 	return _c
 }
 
-func (_c *MockAuthService_CreateSession_Call) RunAndReturn(run func(context.Context, domain.UserID, string, string) (*domain.Session, error)) *MockAuthService_CreateSession_Call {
+func (_c *MockAuthService_CreateSession_Call) RunAndReturn(run func(context.Context, domain.UserID, string, string, bool) (*domain.Session, error)) *MockAuthService_CreateSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -274,6 +277,133 @@ func (_c *MockAuthService_InvalidateSession_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// InvalidateUser provides a mock function with given fields: ctx, userID
+func (_m *MockAuthService) InvalidateUser(ctx context.Context, userID domain.UserID) {
+	_m.Called(ctx, userID)
+}
+
+// MockAuthService_InvalidateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateUser'
+type MockAuthService_InvalidateUser_Call struct {
+	*mock.Call
+}
+
+// InvalidateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID domain.UserID
+func (_e *MockAuthService_Expecter) InvalidateUser(ctx interface{}, userID interface{}) *MockAuthService_InvalidateUser_Call {
+	return &MockAuthService_InvalidateUser_Call{Call: _e.mock.On("InvalidateUser", ctx, userID)}
+}
+
+func (_c *MockAuthService_InvalidateUser_Call) Run(run func(ctx context.Context, userID domain.UserID)) *MockAuthService_InvalidateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserID))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_InvalidateUser_Call) Return() *MockAuthService_InvalidateUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAuthService_InvalidateUser_Call) RunAndReturn(run func(context.Context, domain.UserID)) *MockAuthService_InvalidateUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// LoginAttemptKey provides a mock function with given fields: ctx, identifier
+func (_m *MockAuthService) LoginAttemptKey(ctx context.Context, identifier string) string {
+	ret := _m.Called(ctx, identifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginAttemptKey")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, identifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockAuthService_LoginAttemptKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginAttemptKey'
+type MockAuthService_LoginAttemptKey_Call struct {
+	*mock.Call
+}
+
+// LoginAttemptKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - identifier string
+func (_e *MockAuthService_Expecter) LoginAttemptKey(ctx interface{}, identifier interface{}) *MockAuthService_LoginAttemptKey_Call {
+	return &MockAuthService_LoginAttemptKey_Call{Call: _e.mock.On("LoginAttemptKey", ctx, identifier)}
+}
+
+func (_c *MockAuthService_LoginAttemptKey_Call) Run(run func(ctx context.Context, identifier string)) *MockAuthService_LoginAttemptKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_LoginAttemptKey_Call) Return(_a0 string) *MockAuthService_LoginAttemptKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_LoginAttemptKey_Call) RunAndReturn(run func(context.Context, string) string) *MockAuthService_LoginAttemptKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PasswordChangeRequired provides a mock function with given fields: user
+func (_m *MockAuthService) PasswordChangeRequired(user *domain.User) bool {
+	ret := _m.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PasswordChangeRequired")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*domain.User) bool); ok {
+		r0 = rf(user)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockAuthService_PasswordChangeRequired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PasswordChangeRequired'
+type MockAuthService_PasswordChangeRequired_Call struct {
+	*mock.Call
+}
+
+// PasswordChangeRequired is a helper method to define mock.On call
+//   - user *domain.User
+func (_e *MockAuthService_Expecter) PasswordChangeRequired(user interface{}) *MockAuthService_PasswordChangeRequired_Call {
+	return &MockAuthService_PasswordChangeRequired_Call{Call: _e.mock.On("PasswordChangeRequired", user)}
+}
+
+func (_c *MockAuthService_PasswordChangeRequired_Call) Run(run func(user *domain.User)) *MockAuthService_PasswordChangeRequired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*domain.User))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_PasswordChangeRequired_Call) Return(_a0 bool) *MockAuthService_PasswordChangeRequired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuthService_PasswordChangeRequired_Call) RunAndReturn(run func(*domain.User) bool) *MockAuthService_PasswordChangeRequired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RecordLoginAttempt provides a mock function with given fields: ctx, username, ipAddress, success, failureReason
 func (_m *MockAuthService) RecordLoginAttempt(ctx context.Context, username string, ipAddress string, success bool, failureReason string) error {
 	ret := _m.Called(ctx, username, ipAddress, success, failureReason)
@@ -371,9 +501,9 @@ func (_c *MockAuthService_UpdateSessionActivity_Call) RunAndReturn(run func(cont
 	return _c
 }
 
-// ValidateCredentials provides a mock function with given fields: ctx, username, password
-func (_m *MockAuthService) ValidateCredentials(ctx context.Context, username string, password string) (*domain.User, error) {
-	ret := _m.Called(ctx, username, password)
+// ValidateCredentials provides a mock function with given fields: ctx, identifier, password
+func (_m *MockAuthService) ValidateCredentials(ctx context.Context, identifier string, password string) (*domain.User, error) {
+	ret := _m.Called(ctx, identifier, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateCredentials")
@@ -382,10 +512,10 @@ func (_m *MockAuthService) ValidateCredentials(ctx context.Context, username str
 	var r0 *domain.User
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
-		return rf(ctx, username, password)
+		return rf(ctx, identifier, password)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
-		r0 = rf(ctx, username, password)
+		r0 = rf(ctx, identifier, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
@@ -393,7 +523,7 @@ func (_m *MockAuthService) ValidateCredentials(ctx context.Context, username str
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, username, password)
+		r1 = rf(ctx, identifier, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -408,13 +538,13 @@ type MockAuthService_ValidateCredentials_Call struct {
 
 // ValidateCredentials is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
+//   - identifier string
 //   - password string
-func (_e *MockAuthService_Expecter) ValidateCredentials(ctx interface{}, username interface{}, password interface{}) *MockAuthService_ValidateCredentials_Call {
-	return &MockAuthService_ValidateCredentials_Call{Call: _e.mock.On("ValidateCredentials", ctx, username, password)}
+func (_e *MockAuthService_Expecter) ValidateCredentials(ctx interface{}, identifier interface{}, password interface{}) *MockAuthService_ValidateCredentials_Call {
+	return &MockAuthService_ValidateCredentials_Call{Call: _e.mock.On("ValidateCredentials", ctx, identifier, password)}
 }
 
-func (_c *MockAuthService_ValidateCredentials_Call) Run(run func(ctx context.Context, username string, password string)) *MockAuthService_ValidateCredentials_Call {
+func (_c *MockAuthService_ValidateCredentials_Call) Run(run func(ctx context.Context, identifier string, password string)) *MockAuthService_ValidateCredentials_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
@@ -6,6 +6,8 @@ import (
 	domain "beerdosan-backend/internal/app/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockJWTService is an autogenerated mock type for the JWTService type
@@ -21,9 +23,54 @@ func (_m *MockJWTService) EXPECT() *MockJWTService_Expecter {
 	return &MockJWTService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateAccessToken provides a mock function with given fields: userID, sessionID, role
-func (_m *MockJWTService) GenerateAccessToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole) (domain.JWT, error) {
-	ret := _m.Called(userID, sessionID, role)
+// AccessTokenDuration provides a mock function with no fields
+func (_m *MockJWTService) AccessTokenDuration() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessTokenDuration")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// MockJWTService_AccessTokenDuration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessTokenDuration'
+type MockJWTService_AccessTokenDuration_Call struct {
+	*mock.Call
+}
+
+// AccessTokenDuration is a helper method to define mock.On call
+func (_e *MockJWTService_Expecter) AccessTokenDuration() *MockJWTService_AccessTokenDuration_Call {
+	return &MockJWTService_AccessTokenDuration_Call{Call: _e.mock.On("AccessTokenDuration")}
+}
+
+func (_c *MockJWTService_AccessTokenDuration_Call) Run(run func()) *MockJWTService_AccessTokenDuration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJWTService_AccessTokenDuration_Call) Return(_a0 time.Duration) *MockJWTService_AccessTokenDuration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJWTService_AccessTokenDuration_Call) RunAndReturn(run func() time.Duration) *MockJWTService_AccessTokenDuration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateAccessToken provides a mock function with given fields: userID, sessionID, role, authTime, scopes
+func (_m *MockJWTService) GenerateAccessToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, scopes []domain.Scope) (domain.JWT, error) {
+	ret := _m.Called(userID, sessionID, role, authTime, scopes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateAccessToken")
@@ -31,17 +78,17 @@ func (_m *MockJWTService) GenerateAccessToken(userID domain.UserID, sessionID do
 
 	var r0 domain.JWT
 	var r1 error
-	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID, domain.UserRole) (domain.JWT, error)); ok {
-		return rf(userID, sessionID, role)
+	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, []domain.Scope) (domain.JWT, error)); ok {
+		return rf(userID, sessionID, role, authTime, scopes)
 	}
-	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID, domain.UserRole) domain.JWT); ok {
-		r0 = rf(userID, sessionID, role)
+	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, []domain.Scope) domain.JWT); ok {
+		r0 = rf(userID, sessionID, role, authTime, scopes)
 	} else {
 		r0 = ret.Get(0).(domain.JWT)
 	}
 
-	if rf, ok := ret.Get(1).(func(domain.UserID, domain.SessionID, domain.UserRole) error); ok {
-		r1 = rf(userID, sessionID, role)
+	if rf, ok := ret.Get(1).(func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, []domain.Scope) error); ok {
+		r1 = rf(userID, sessionID, role, authTime, scopes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,13 +105,15 @@ type MockJWTService_GenerateAccessToken_Call struct {
 //   - userID domain.UserID
 //   - sessionID domain.SessionID
 //   - role domain.UserRole
-func (_e *MockJWTService_Expecter) GenerateAccessToken(userID interface{}, sessionID interface{}, role interface{}) *MockJWTService_GenerateAccessToken_Call {
-	return &MockJWTService_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", userID, sessionID, role)}
+//   - authTime time.Time
+//   - scopes []domain.Scope
+func (_e *MockJWTService_Expecter) GenerateAccessToken(userID interface{}, sessionID interface{}, role interface{}, authTime interface{}, scopes interface{}) *MockJWTService_GenerateAccessToken_Call {
+	return &MockJWTService_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", userID, sessionID, role, authTime, scopes)}
 }
 
-func (_c *MockJWTService_GenerateAccessToken_Call) Run(run func(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole)) *MockJWTService_GenerateAccessToken_Call {
+func (_c *MockJWTService_GenerateAccessToken_Call) Run(run func(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, scopes []domain.Scope)) *MockJWTService_GenerateAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(domain.UserID), args[1].(domain.SessionID), args[2].(domain.UserRole))
+		run(args[0].(domain.UserID), args[1].(domain.SessionID), args[2].(domain.UserRole), args[3].(time.Time), args[4].([]domain.Scope))
 	})
 	return _c
 }
@@ -74,14 +123,14 @@ func (_c *MockJWTService_GenerateAccessToken_Call) Return(_a0 domain.JWT, _a1 er
 	return _c
 }
 
-func (_c *MockJWTService_GenerateAccessToken_Call) RunAndReturn(run func(domain.UserID, domain.SessionID, domain.UserRole) (domain.JWT, error)) *MockJWTService_GenerateAccessToken_Call {
+func (_c *MockJWTService_GenerateAccessToken_Call) RunAndReturn(run func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, []domain.Scope) (domain.JWT, error)) *MockJWTService_GenerateAccessToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GenerateRefreshToken provides a mock function with given fields: userID, sessionID
-func (_m *MockJWTService) GenerateRefreshToken(userID domain.UserID, sessionID domain.SessionID) (domain.JWT, error) {
-	ret := _m.Called(userID, sessionID)
+// GenerateRefreshToken provides a mock function with given fields: userID, sessionID, role, authTime, rememberMe
+func (_m *MockJWTService) GenerateRefreshToken(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, rememberMe bool) (domain.JWT, error) {
+	ret := _m.Called(userID, sessionID, role, authTime, rememberMe)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateRefreshToken")
@@ -89,17 +138,17 @@ func (_m *MockJWTService) GenerateRefreshToken(userID domain.UserID, sessionID d
 
 	var r0 domain.JWT
 	var r1 error
-	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID) (domain.JWT, error)); ok {
-		return rf(userID, sessionID)
+	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, bool) (domain.JWT, error)); ok {
+		return rf(userID, sessionID, role, authTime, rememberMe)
 	}
-	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID) domain.JWT); ok {
-		r0 = rf(userID, sessionID)
+	if rf, ok := ret.Get(0).(func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, bool) domain.JWT); ok {
+		r0 = rf(userID, sessionID, role, authTime, rememberMe)
 	} else {
 		r0 = ret.Get(0).(domain.JWT)
 	}
 
-	if rf, ok := ret.Get(1).(func(domain.UserID, domain.SessionID) error); ok {
-		r1 = rf(userID, sessionID)
+	if rf, ok := ret.Get(1).(func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, bool) error); ok {
+		r1 = rf(userID, sessionID, role, authTime, rememberMe)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,13 +164,16 @@ type MockJWTService_GenerateRefreshToken_Call struct {
 // GenerateRefreshToken is a helper method to define mock.On call
 //   - userID domain.UserID
 //   - sessionID domain.SessionID
-func (_e *MockJWTService_Expecter) GenerateRefreshToken(userID interface{}, sessionID interface{}) *MockJWTService_GenerateRefreshToken_Call {
-	return &MockJWTService_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", userID, sessionID)}
+//   - role domain.UserRole
+//   - authTime time.Time
+//   - rememberMe bool
+func (_e *MockJWTService_Expecter) GenerateRefreshToken(userID interface{}, sessionID interface{}, role interface{}, authTime interface{}, rememberMe interface{}) *MockJWTService_GenerateRefreshToken_Call {
+	return &MockJWTService_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", userID, sessionID, role, authTime, rememberMe)}
 }
 
-func (_c *MockJWTService_GenerateRefreshToken_Call) Run(run func(userID domain.UserID, sessionID domain.SessionID)) *MockJWTService_GenerateRefreshToken_Call {
+func (_c *MockJWTService_GenerateRefreshToken_Call) Run(run func(userID domain.UserID, sessionID domain.SessionID, role domain.UserRole, authTime time.Time, rememberMe bool)) *MockJWTService_GenerateRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(domain.UserID), args[1].(domain.SessionID))
+		run(args[0].(domain.UserID), args[1].(domain.SessionID), args[2].(domain.UserRole), args[3].(time.Time), args[4].(bool))
 	})
 	return _c
 }
@@ -131,14 +183,14 @@ func (_c *MockJWTService_GenerateRefreshToken_Call) Return(_a0 domain.JWT, _a1 e
 	return _c
 }
 
-func (_c *MockJWTService_GenerateRefreshToken_Call) RunAndReturn(run func(domain.UserID, domain.SessionID) (domain.JWT, error)) *MockJWTService_GenerateRefreshToken_Call {
+func (_c *MockJWTService_GenerateRefreshToken_Call) RunAndReturn(run func(domain.UserID, domain.SessionID, domain.UserRole, time.Time, bool) (domain.JWT, error)) *MockJWTService_GenerateRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RefreshAccessToken provides a mock function with given fields: refreshToken
-func (_m *MockJWTService) RefreshAccessToken(refreshToken domain.JWT) (domain.JWT, error) {
-	ret := _m.Called(refreshToken)
+// RefreshAccessToken provides a mock function with given fields: refreshToken, scopes
+func (_m *MockJWTService) RefreshAccessToken(refreshToken domain.JWT, scopes []domain.Scope) (domain.JWT, error) {
+	ret := _m.Called(refreshToken, scopes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshAccessToken")
@@ -146,17 +198,17 @@ func (_m *MockJWTService) RefreshAccessToken(refreshToken domain.JWT) (domain.JW
 
 	var r0 domain.JWT
 	var r1 error
-	if rf, ok := ret.Get(0).(func(domain.JWT) (domain.JWT, error)); ok {
-		return rf(refreshToken)
+	if rf, ok := ret.Get(0).(func(domain.JWT, []domain.Scope) (domain.JWT, error)); ok {
+		return rf(refreshToken, scopes)
 	}
-	if rf, ok := ret.Get(0).(func(domain.JWT) domain.JWT); ok {
-		r0 = rf(refreshToken)
+	if rf, ok := ret.Get(0).(func(domain.JWT, []domain.Scope) domain.JWT); ok {
+		r0 = rf(refreshToken, scopes)
 	} else {
 		r0 = ret.Get(0).(domain.JWT)
 	}
 
-	if rf, ok := ret.Get(1).(func(domain.JWT) error); ok {
-		r1 = rf(refreshToken)
+	if rf, ok := ret.Get(1).(func(domain.JWT, []domain.Scope) error); ok {
+		r1 = rf(refreshToken, scopes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -171,13 +223,14 @@ type MockJWTService_RefreshAccessToken_Call struct {
 
 // RefreshAccessToken is a helper method to define mock.On call
 //   - refreshToken domain.JWT
-func (_e *MockJWTService_Expecter) RefreshAccessToken(refreshToken interface{}) *MockJWTService_RefreshAccessToken_Call {
-	return &MockJWTService_RefreshAccessToken_Call{Call: _e.mock.On("RefreshAccessToken", refreshToken)}
+//   - scopes []domain.Scope
+func (_e *MockJWTService_Expecter) RefreshAccessToken(refreshToken interface{}, scopes interface{}) *MockJWTService_RefreshAccessToken_Call {
+	return &MockJWTService_RefreshAccessToken_Call{Call: _e.mock.On("RefreshAccessToken", refreshToken, scopes)}
 }
 
-func (_c *MockJWTService_RefreshAccessToken_Call) Run(run func(refreshToken domain.JWT)) *MockJWTService_RefreshAccessToken_Call {
+func (_c *MockJWTService_RefreshAccessToken_Call) Run(run func(refreshToken domain.JWT, scopes []domain.Scope)) *MockJWTService_RefreshAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(domain.JWT))
+		run(args[0].(domain.JWT), args[1].([]domain.Scope))
 	})
 	return _c
 }
@@ -187,7 +240,53 @@ func (_c *MockJWTService_RefreshAccessToken_Call) Return(_a0 domain.JWT, _a1 err
 	return _c
 }
 
-func (_c *MockJWTService_RefreshAccessToken_Call) RunAndReturn(run func(domain.JWT) (domain.JWT, error)) *MockJWTService_RefreshAccessToken_Call {
+func (_c *MockJWTService_RefreshAccessToken_Call) RunAndReturn(run func(domain.JWT, []domain.Scope) (domain.JWT, error)) *MockJWTService_RefreshAccessToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshTokenDuration provides a mock function with given fields: rememberMe
+func (_m *MockJWTService) RefreshTokenDuration(rememberMe bool) time.Duration {
+	ret := _m.Called(rememberMe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokenDuration")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(bool) time.Duration); ok {
+		r0 = rf(rememberMe)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// MockJWTService_RefreshTokenDuration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokenDuration'
+type MockJWTService_RefreshTokenDuration_Call struct {
+	*mock.Call
+}
+
+// RefreshTokenDuration is a helper method to define mock.On call
+//   - rememberMe bool
+func (_e *MockJWTService_Expecter) RefreshTokenDuration(rememberMe interface{}) *MockJWTService_RefreshTokenDuration_Call {
+	return &MockJWTService_RefreshTokenDuration_Call{Call: _e.mock.On("RefreshTokenDuration", rememberMe)}
+}
+
+func (_c *MockJWTService_RefreshTokenDuration_Call) Run(run func(rememberMe bool)) *MockJWTService_RefreshTokenDuration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *MockJWTService_RefreshTokenDuration_Call) Return(_a0 time.Duration) *MockJWTService_RefreshTokenDuration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJWTService_RefreshTokenDuration_Call) RunAndReturn(run func(bool) time.Duration) *MockJWTService_RefreshTokenDuration_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,6 +6,8 @@ import (
 	domain "beerdosan-backend/internal/app/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	service "beerdosan-backend/internal/app/service"
 )
 
 // MockPasswordService is an autogenerated mock type for the PasswordService type
@@ -21,6 +23,53 @@ func (_m *MockPasswordService) EXPECT() *MockPasswordService_Expecter {
 	return &MockPasswordService_Expecter{mock: &_m.Mock}
 }
 
+// EstimateStrength provides a mock function with given fields: password, userInputs
+func (_m *MockPasswordService) EstimateStrength(password string, userInputs []string) service.PasswordStrengthEstimate {
+	ret := _m.Called(password, userInputs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateStrength")
+	}
+
+	var r0 service.PasswordStrengthEstimate
+	if rf, ok := ret.Get(0).(func(string, []string) service.PasswordStrengthEstimate); ok {
+		r0 = rf(password, userInputs)
+	} else {
+		r0 = ret.Get(0).(service.PasswordStrengthEstimate)
+	}
+
+	return r0
+}
+
+// MockPasswordService_EstimateStrength_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateStrength'
+type MockPasswordService_EstimateStrength_Call struct {
+	*mock.Call
+}
+
+// EstimateStrength is a helper method to define mock.On call
+//   - password string
+//   - userInputs []string
+func (_e *MockPasswordService_Expecter) EstimateStrength(password interface{}, userInputs interface{}) *MockPasswordService_EstimateStrength_Call {
+	return &MockPasswordService_EstimateStrength_Call{Call: _e.mock.On("EstimateStrength", password, userInputs)}
+}
+
+func (_c *MockPasswordService_EstimateStrength_Call) Run(run func(password string, userInputs []string)) *MockPasswordService_EstimateStrength_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_EstimateStrength_Call) Return(_a0 service.PasswordStrengthEstimate) *MockPasswordService_EstimateStrength_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordService_EstimateStrength_Call) RunAndReturn(run func(string, []string) service.PasswordStrengthEstimate) *MockPasswordService_EstimateStrength_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateRandomPassword provides a mock function with given fields: length
 func (_m *MockPasswordService) GenerateRandomPassword(length int) (string, error) {
 	ret := _m.Called(length)
@@ -189,17 +238,63 @@ func (_c *MockPasswordService_Hash_Call) RunAndReturn(run func(string) (domain.H
 	return _c
 }
 
-// ValidateStrength provides a mock function with given fields: password
-func (_m *MockPasswordService) ValidateStrength(password string) error {
-	ret := _m.Called(password)
+// Policy provides a mock function with given fields: role
+func (_m *MockPasswordService) Policy(role domain.UserRole) service.PasswordPolicy {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Policy")
+	}
+
+	var r0 service.PasswordPolicy
+	if rf, ok := ret.Get(0).(func(domain.UserRole) service.PasswordPolicy); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(service.PasswordPolicy)
+	}
+
+	return r0
+}
+
+// MockPasswordService_Policy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Policy'
+type MockPasswordService_Policy_Call struct {
+	*mock.Call
+}
+
+// Policy is a helper method to define mock.On call
+//   - role domain.UserRole
+func (_e *MockPasswordService_Expecter) Policy(role interface{}) *MockPasswordService_Policy_Call {
+	return &MockPasswordService_Policy_Call{Call: _e.mock.On("Policy", role)}
+}
+
+func (_c *MockPasswordService_Policy_Call) Run(run func(role domain.UserRole)) *MockPasswordService_Policy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(domain.UserRole))
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_Policy_Call) Return(_a0 service.PasswordPolicy) *MockPasswordService_Policy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordService_Policy_Call) RunAndReturn(run func(domain.UserRole) service.PasswordPolicy) *MockPasswordService_Policy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateStrength provides a mock function with given fields: password, role, userInputs
+func (_m *MockPasswordService) ValidateStrength(password string, role domain.UserRole, userInputs []string) error {
+	ret := _m.Called(password, role, userInputs)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateStrength")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(password)
+	if rf, ok := ret.Get(0).(func(string, domain.UserRole, []string) error); ok {
+		r0 = rf(password, role, userInputs)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -214,13 +309,15 @@ type MockPasswordService_ValidateStrength_Call struct {
 
 // ValidateStrength is a helper method to define mock.On call
 //   - password string
-func (_e *MockPasswordService_Expecter) ValidateStrength(password interface{}) *MockPasswordService_ValidateStrength_Call {
-	return &MockPasswordService_ValidateStrength_Call{Call: _e.mock.On("ValidateStrength", password)}
+//   - role domain.UserRole
+//   - userInputs []string
+func (_e *MockPasswordService_Expecter) ValidateStrength(password interface{}, role interface{}, userInputs interface{}) *MockPasswordService_ValidateStrength_Call {
+	return &MockPasswordService_ValidateStrength_Call{Call: _e.mock.On("ValidateStrength", password, role, userInputs)}
 }
 
-func (_c *MockPasswordService_ValidateStrength_Call) Run(run func(password string)) *MockPasswordService_ValidateStrength_Call {
+func (_c *MockPasswordService_ValidateStrength_Call) Run(run func(password string, role domain.UserRole, userInputs []string)) *MockPasswordService_ValidateStrength_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(domain.UserRole), args[2].([]string))
 	})
 	return _c
 }
@@ -230,7 +327,7 @@ func (_c *MockPasswordService_ValidateStrength_Call) Return(_a0 error) *MockPass
 	return _c
 }
 
-func (_c *MockPasswordService_ValidateStrength_Call) RunAndReturn(run func(string) error) *MockPasswordService_ValidateStrength_Call {
+func (_c *MockPasswordService_ValidateStrength_Call) RunAndReturn(run func(string, domain.UserRole, []string) error) *MockPasswordService_ValidateStrength_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7,7 +7,35 @@ import (
 type PasswordService interface {
 	Hash(password string) (domain.HashedPassword, error)
 	Verify(password string, hashedPassword domain.HashedPassword) error
-	ValidateStrength(password string) error
+	// ValidateStrength checks password against the policy for role.
+	// userInputs are the user's own data, such as username and email, that
+	// the password should not be built from.
+	ValidateStrength(password string, role domain.UserRole, userInputs ...string) error
+	Policy(role domain.UserRole) PasswordPolicy
+	EstimateStrength(password string, userInputs ...string) PasswordStrengthEstimate
 	GenerateRandomPassword(length int) (string, error)
 	GenerateSecureToken(length int) (string, error)
 }
+
+// PasswordPolicy are the rules a new password for a given role must meet.
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireNumbers   bool
+	RequireSymbols   bool
+	MinScore         int
+	// HistorySize is how many recent passwords a new one must differ from.
+	HistorySize int
+}
+
+// PasswordStrengthEstimate rates a password from 0 (too guessable) to 4 (very
+// unguessable) and explains how to improve it.
+type PasswordStrengthEstimate struct {
+	Score        int
+	Guesses      float64
+	GuessesLog10 float64
+	Warning      string
+	Suggestions  []string
+}
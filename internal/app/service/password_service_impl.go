@@ -34,8 +34,22 @@ func (s *passwordServiceImpl) Verify(plainPassword string, hashedPassword domain
 	return nil
 }
 
-func (s *passwordServiceImpl) ValidateStrength(password string) error {
-	return s.passwordService.ValidatePassword(password)
+func (s *passwordServiceImpl) ValidateStrength(password string, role domain.UserRole, userInputs ...string) error {
+	return s.passwordService.ValidatePasswordForRole(password, role.String(), userInputs...)
+}
+
+func (s *passwordServiceImpl) Policy(role domain.UserRole) PasswordPolicy {
+	policy := s.passwordService.Policy(role.String())
+	return PasswordPolicy{
+		MinLength:        policy.MinLength,
+		MaxLength:        policy.MaxLength,
+		RequireUppercase: policy.RequireUppercase,
+		RequireLowercase: policy.RequireLowercase,
+		RequireNumbers:   policy.RequireNumbers,
+		RequireSymbols:   policy.RequireSymbols,
+		MinScore:         policy.MinScore,
+		HistorySize:      policy.HistorySize,
+	}
 }
 
 func (s *passwordServiceImpl) GenerateRandomPassword(length int) (string, error) {
@@ -54,3 +68,14 @@ func (s *passwordServiceImpl) GenerateSecureToken(length int) (string, error) {
 
 	return base64.URLEncoding.EncodeToString([]byte(password)), nil
 }
+
+func (s *passwordServiceImpl) EstimateStrength(password string, userInputs ...string) PasswordStrengthEstimate {
+	estimate := s.passwordService.EstimateStrength(password, userInputs...)
+	return PasswordStrengthEstimate{
+		Score:        estimate.Score,
+		Guesses:      estimate.Guesses,
+		GuessesLog10: estimate.GuessesLog10,
+		Warning:      estimate.Warning,
+		Suggestions:  estimate.Suggestions,
+	}
+}
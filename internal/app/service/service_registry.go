@@ -2,6 +2,7 @@ package service
 
 import (
 	"beerdosan-backend/internal/app/repositories"
+	"beerdosan-backend/internal/pkg/database"
 	"beerdosan-backend/internal/pkg/jwt"
 	"beerdosan-backend/internal/pkg/password"
 )
@@ -18,6 +19,10 @@ func NewServiceRegistry(
 	loginAttemptRepo repositories.LoginAttemptRepository,
 	jwtService jwt.JWTService,
 	passwordService password.PasswordService,
+	transactionMgr database.TransactionManagerInterface,
+	sessionLimits SessionLimitConfig,
+	passwordExpiry PasswordExpiryConfig,
+	authCache *AuthCache,
 ) *ServiceRegistry {
 	pwdService := NewPasswordService(passwordService)
 
@@ -29,6 +34,10 @@ func NewServiceRegistry(
 		loginAttemptRepo,
 		pwdService,
 		jwtSvc,
+		transactionMgr,
+		sessionLimits,
+		passwordExpiry,
+		authCache,
 	)
 
 	return &ServiceRegistry{
@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+	"beerdosan-backend/internal/pkg/sliceutil"
+)
+
+// AccountDeletionConfig controls self-service account deletion. A deleted
+// account is purged once GracePeriod has passed; logging in before then
+// restores it.
+type AccountDeletionConfig struct {
+	GracePeriod time.Duration
+}
+
+func DefaultAccountDeletionConfig() AccountDeletionConfig {
+	return AccountDeletionConfig{
+		GracePeriod: 30 * 24 * time.Hour,
+	}
+}
+
+type AccountDeletionOutput struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// DeleteAccount schedules the user's account for deletion and ends all of its
+// sessions.
+func (uc *AuthUseCaseImpl) DeleteAccount(ctx context.Context, userID domain.UserID) (*AccountDeletionOutput, error) {
+	purgeAt := time.Now().Add(uc.accountDeletion.GracePeriod)
+
+	// Only the deletion columns are written, so changes committed since the
+	// user was authenticated are kept.
+	err := uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		scheduled, err := uc.userRepo.ScheduleDeletion(ctx, userID, purgeAt)
+		if err != nil {
+			return err
+		}
+		if !scheduled {
+			return domain.ErrUserNotFound
+		}
+		return uc.authService.InvalidateAllUserSessions(ctx, userID, "")
+	})
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "ACCOUNT_DELETION_FAILED", "failed to schedule account deletion").Wrap(err)
+	}
+
+	return &AccountDeletionOutput{DeletionScheduledAt: purgeAt}, nil
+}
+
+// canLogInOrRestore reports whether user may log in, counting an account
+// whose pending deletion the login would cancel.
+func canLogInOrRestore(user *domain.User) bool {
+	return user.CanLogin() || user.DeletionScheduled()
+}
+
+// cancelAccountDeletion restores an account whose deletion is pending. Login
+// flows call it in the transaction that starts the session, once the user has
+// proved who they are, with deletionScheduled read before the transaction so
+// that a retried transaction restores the account again.
+func (uc *AuthUseCaseImpl) cancelAccountDeletion(ctx context.Context, user *domain.User, deletionScheduled bool) error {
+	if !deletionScheduled {
+		return nil
+	}
+
+	if _, err := uc.userRepo.CancelDeletion(ctx, user.ID()); err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "USER_UPDATE_FAILED", "failed to cancel account deletion").Wrap(err)
+	}
+	user.CancelDeletion()
+
+	database.AfterCommit(ctx, func() {
+		log.Printf("[INFO] account deletion cancelled by login: user_id=%s", user.ID())
+	})
+	return nil
+}
+
+// PersonalDataExport is everything stored about a user, as handed to them on
+// request.
+type PersonalDataExport struct {
+	ExportedAt    time.Time                  `json:"exported_at"`
+	Profile       PersonalDataProfile        `json:"profile"`
+	Sessions      []PersonalDataSession      `json:"sessions"`
+	LoginAttempts []PersonalDataLoginAttempt `json:"login_attempts"`
+	AuditEntries  []PersonalDataAuditEntry   `json:"audit_entries"`
+}
+
+type PersonalDataProfile struct {
+	ID                  domain.UserID   `json:"id"`
+	Username            string          `json:"username"`
+	Email               string          `json:"email"`
+	FirstName           string          `json:"first_name"`
+	LastName            string          `json:"last_name"`
+	PhoneNumber         string          `json:"phone_number"`
+	PhoneNumberVerified bool            `json:"phone_number_verified"`
+	Role                domain.UserRole `json:"role"`
+	Status              domain.Status   `json:"status"`
+	PasswordChangedAt   time.Time       `json:"password_changed_at"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+}
+
+type PersonalDataSession struct {
+	ID           domain.SessionID `json:"id"`
+	Name         string           `json:"name"`
+	UserAgent    string           `json:"user_agent"`
+	IPAddress    string           `json:"ip_address"`
+	RememberMe   bool             `json:"remember_me"`
+	IsActive     bool             `json:"is_active"`
+	CreatedAt    time.Time        `json:"created_at"`
+	LastActivity time.Time        `json:"last_activity"`
+}
+
+type PersonalDataLoginAttempt struct {
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	Success       bool      `json:"success"`
+	FailureReason *string   `json:"failure_reason,omitempty"`
+	AttemptedAt   time.Time `json:"attempted_at"`
+}
+
+// PersonalDataAuditEntry is a security event on the account: a password
+// change, a magic link or a one-time passcode.
+type PersonalDataAuditEntry struct {
+	Action     string    `json:"action"`
+	Detail     string    `json:"detail,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ExportPersonalData collects the user's profile, sessions, login attempts and
+// audit entries. Secrets such as password and token hashes are left out.
+func (uc *AuthUseCaseImpl) ExportPersonalData(ctx context.Context, userID domain.UserID) (*PersonalDataExport, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	sessions, err := uc.sessionRepo.GetAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "SESSION_FETCH_FAILED", "failed to get user sessions").Wrap(err)
+	}
+
+	attempts, err := uc.loginAttemptRepo.ListByUsername(ctx, user.Username().String())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "LOGIN_ATTEMPT_FETCH_FAILED", "failed to get login attempts").Wrap(err)
+	}
+
+	auditEntries, err := uc.auditEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PersonalDataExport{
+		ExportedAt: time.Now(),
+		Profile: PersonalDataProfile{
+			ID:                  user.ID(),
+			Username:            user.Username().String(),
+			Email:               user.Email().String(),
+			FirstName:           user.FirstName().String(),
+			LastName:            user.LastName().String(),
+			PhoneNumber:         user.PhoneNumber().String(),
+			PhoneNumberVerified: user.PhoneNumberVerified(),
+			Role:                user.Role(),
+			Status:              user.Status(),
+			PasswordChangedAt:   user.PasswordChangedAt(),
+			CreatedAt:           user.CreatedAt().Time(),
+			UpdatedAt:           user.UpdatedAt().Time(),
+		},
+		Sessions: sliceutil.Map(sessions, func(session *domain.Session) PersonalDataSession {
+			return PersonalDataSession{
+				ID:           session.ID(),
+				Name:         session.Name().String(),
+				UserAgent:    session.DeviceInfo(),
+				IPAddress:    session.IPAddress().String(),
+				RememberMe:   session.RememberMe(),
+				IsActive:     session.IsActive(),
+				CreatedAt:    session.CreatedAt().Time(),
+				LastActivity: session.LastActivity().Time(),
+			}
+		}),
+		LoginAttempts: sliceutil.Map(attempts, func(attempt *domain.LoginAttempt) PersonalDataLoginAttempt {
+			return PersonalDataLoginAttempt{
+				IPAddress:     attempt.IPAddress().String(),
+				UserAgent:     attempt.UserAgent().String(),
+				Success:       attempt.Success(),
+				FailureReason: attempt.FailureReason(),
+				AttemptedAt:   attempt.AttemptedAt().Time(),
+			}
+		}),
+		AuditEntries: auditEntries,
+	}, nil
+}
+
+// auditEntries merges the user's password changes, magic links and one-time
+// passcodes, newest first.
+func (uc *AuthUseCaseImpl) auditEntries(ctx context.Context, userID domain.UserID) ([]PersonalDataAuditEntry, error) {
+	passwordChanges, err := uc.passwordHistory.ListChangedAtByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "PASSWORD_HISTORY_FETCH_FAILED", "failed to get password history").Wrap(err)
+	}
+
+	magicLinks, err := uc.magicLinkRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_FETCH_FAILED", "failed to get magic links").Wrap(err)
+	}
+
+	otps, err := uc.otpRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_FETCH_FAILED", "failed to get one-time passcodes").Wrap(err)
+	}
+
+	entries := make([]PersonalDataAuditEntry, 0, len(passwordChanges)+len(magicLinks)+len(otps))
+	for _, changedAt := range passwordChanges {
+		entries = append(entries, PersonalDataAuditEntry{
+			Action:     "password_changed",
+			OccurredAt: changedAt,
+		})
+	}
+	for _, link := range magicLinks {
+		entries = append(entries, PersonalDataAuditEntry{
+			Action:     "magic_link_requested",
+			Detail:     link.Email().String(),
+			IPAddress:  link.IPAddress().String(),
+			OccurredAt: link.CreatedAt().Time(),
+		})
+		if consumedAt := link.ConsumedAt(); consumedAt != nil {
+			entries = append(entries, PersonalDataAuditEntry{
+				Action:     "magic_link_used",
+				Detail:     link.Email().String(),
+				OccurredAt: *consumedAt,
+			})
+		}
+	}
+	for _, otp := range otps {
+		entries = append(entries, PersonalDataAuditEntry{
+			Action:     "otp_sent",
+			Detail:     otp.Purpose().String() + " code to " + otp.Destination().String(),
+			OccurredAt: otp.CreatedAt().Time(),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OccurredAt.After(entries[j].OccurredAt)
+	})
+
+	return entries, nil
+}
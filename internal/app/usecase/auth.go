@@ -4,28 +4,67 @@ import (
 	"context"
 
 	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/readmodel"
 	"beerdosan-backend/internal/app/repositories"
 	"beerdosan-backend/internal/app/service"
 	"beerdosan-backend/internal/pkg/database"
+	"beerdosan-backend/internal/pkg/mailer"
+	"beerdosan-backend/internal/pkg/sender"
 )
 
 type AuthUseCase interface {
 	Login(ctx context.Context, req LoginInput) (*LoginOutput, error)
+	RequestMagicLink(ctx context.Context, req MagicLinkInput) (*MagicLinkOutput, error)
+	ConsumeMagicLink(ctx context.Context, req ConsumeMagicLinkInput) (*LoginOutput, error)
+	RequestLoginOTP(ctx context.Context, req OTPLoginInput) (*OTPOutput, error)
+	VerifyLoginOTP(ctx context.Context, req OTPVerifyInput) (*LoginOutput, error)
+	ChangePhoneNumber(ctx context.Context, userID domain.UserID, phoneNumber string) (*OTPOutput, error)
+	VerifyPhoneNumber(ctx context.Context, userID domain.UserID, code string) error
+	RequestReauthenticationOTP(ctx context.Context, userID domain.UserID, channel string) (*OTPOutput, error)
+	Reauthenticate(ctx context.Context, req ReauthenticateInput) (*LoginOutput, error)
 	Logout(ctx context.Context, userID domain.UserID, sessionID domain.SessionID) error
 	RefreshToken(ctx context.Context, req RefreshTokenInput) (*RefreshTokenOutput, error)
-	GetUserProfile(ctx context.Context, userID domain.UserID) (*GetUserProfileOutput, error)
-	GetUserSessions(ctx context.Context, userID domain.UserID) ([]GetUserSessionsOutput, error)
+	GetUserProfile(ctx context.Context, userID domain.UserID) (*readmodel.UserProfile, error)
+	UpdateProfile(ctx context.Context, userID domain.UserID, req UpdateProfileInput) (*readmodel.UserProfile, error)
+	RequestEmailChange(ctx context.Context, userID domain.UserID, newEmail string) (*EmailChangeOutput, error)
+	ConfirmEmailChange(ctx context.Context, token string) error
+	CreateInvitation(ctx context.Context, req CreateInvitationInput) (*InvitationOutput, error)
+	ListInvitations(ctx context.Context, page, limit int) (*InvitationListOutput, error)
+	RevokeInvitation(ctx context.Context, id int64) error
+	AcceptInvitation(ctx context.Context, req AcceptInvitationInput) (*UserInfo, error)
+	ExportPersonalData(ctx context.Context, userID domain.UserID) (*PersonalDataExport, error)
+	DeleteAccount(ctx context.Context, userID domain.UserID) (*AccountDeletionOutput, error)
+	GetUserSessions(ctx context.Context, userID domain.UserID, currentSessionID domain.SessionID) ([]GetUserSessionsOutput, error)
+	RenameSession(ctx context.Context, userID domain.UserID, sessionID domain.SessionID, name string) error
 	RevokeSession(ctx context.Context, userID domain.UserID, sessionID domain.SessionID) error
 	RevokeAllSessions(ctx context.Context, userID domain.UserID, excludeSessionID domain.SessionID) error
 	ChangePassword(ctx context.Context, userID domain.UserID, oldPassword, newPassword string) error
+	RequirePasswordChange(ctx context.Context, userID domain.UserID) error
+	GetPasswordPolicy(ctx context.Context, role domain.UserRole) (*PasswordPolicyOutput, error)
+	EstimatePasswordStrength(ctx context.Context, req PasswordStrengthInput) (*PasswordStrengthOutput, error)
+	IntrospectToken(ctx context.Context, token string) (*IntrospectTokenOutput, error)
+	RevokeToken(ctx context.Context, token string) error
 }
 type AuthUseCaseImpl struct {
-	authService     service.AuthService
-	jwtService      service.JWTService
-	passwordService service.PasswordService
-	userRepo        repositories.UserRepository
-	sessionRepo     repositories.SessionRepository
-	transactionMgr  *database.TransactionManager
+	authService      service.AuthService
+	jwtService       service.JWTService
+	passwordService  service.PasswordService
+	userRepo         repositories.UserRepository
+	sessionRepo      repositories.SessionRepository
+	loginAttemptRepo repositories.LoginAttemptRepository
+	passwordHistory  repositories.PasswordHistoryRepository
+	magicLinkRepo    repositories.MagicLinkRepository
+	mailer           mailer.Mailer
+	magicLinks       MagicLinkConfig
+	otpRepo          repositories.OTPRepository
+	otpSenders       OTPSenders
+	otp              OTPConfig
+	accountDeletion  AccountDeletionConfig
+	emailChangeRepo  repositories.EmailChangeRepository
+	emailChanges     EmailChangeConfig
+	invitationRepo   repositories.InvitationRepository
+	invitations      InvitationConfig
+	transactionMgr   *database.TransactionManager
 }
 
 func NewAuthUseCase(
@@ -34,16 +73,45 @@ func NewAuthUseCase(
 	passwordService service.PasswordService,
 	userRepo repositories.UserRepository,
 	sessionRepo repositories.SessionRepository,
+	loginAttemptRepo repositories.LoginAttemptRepository,
+	passwordHistory repositories.PasswordHistoryRepository,
+	magicLinkRepo repositories.MagicLinkRepository,
+	mailer mailer.Mailer,
+	magicLinks MagicLinkConfig,
+	otpRepo repositories.OTPRepository,
+	otpSenders OTPSenders,
+	otp OTPConfig,
+	accountDeletion AccountDeletionConfig,
+	emailChangeRepo repositories.EmailChangeRepository,
+	emailChanges EmailChangeConfig,
+	invitationRepo repositories.InvitationRepository,
+	invitations InvitationConfig,
 	transactionMgr *database.TransactionManager,
 ) *AuthUseCaseImpl {
 	return &AuthUseCaseImpl{
-		authService:     authService,
-		jwtService:      jwtService,
-		passwordService: passwordService,
-		userRepo:        userRepo,
-		sessionRepo:     sessionRepo,
-		transactionMgr:  transactionMgr,
+		authService:      authService,
+		jwtService:       jwtService,
+		passwordService:  passwordService,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		passwordHistory:  passwordHistory,
+		magicLinkRepo:    magicLinkRepo,
+		mailer:           mailer,
+		magicLinks:       magicLinks,
+		otpRepo:          otpRepo,
+		otpSenders:       otpSenders,
+		otp:              otp,
+		accountDeletion:  accountDeletion,
+		emailChangeRepo:  emailChangeRepo,
+		emailChanges:     emailChanges,
+		invitationRepo:   invitationRepo,
+		invitations:      invitations,
+		transactionMgr:   transactionMgr,
 	}
 }
 
+// OTPSenders delivers one-time passcodes on each channel.
+type OTPSenders map[domain.OTPChannel]sender.Sender
+
 var _ AuthUseCase = (*AuthUseCaseImpl)(nil)
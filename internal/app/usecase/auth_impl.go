@@ -2,22 +2,25 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
 	"beerdosan-backend/internal/pkg/sliceutil"
 )
 
 type UserInfo struct {
 	ID       domain.UserID         `json:"id"`
 	Username domain.NonEmptyString `json:"username"`
-	Email    domain.NonEmptyString `json:"email"`
+	Email    domain.Email          `json:"email"`
 	Role     domain.UserRole       `json:"role"`
 	Status   domain.Status         `json:"status"`
 }
 
 type LoginInput struct {
-	Username   string `json:"username"`
+	// Identifier is a username or an email address.
+	Identifier string `json:"identifier"`
 	Password   string `json:"password"`
 	DeviceInfo string `json:"device_info"`
 	IPAddress  string `json:"ip_address"`
@@ -25,68 +28,65 @@ type LoginInput struct {
 }
 
 type LoginOutput struct {
-	AccessToken  string    `json:"access_token"`
-	RefreshToken string    `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	User         UserInfo  `json:"user"`
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	User             UserInfo  `json:"user"`
+	// PasswordChangeRequired is set when the access token only allows
+	// changing the password.
+	PasswordChangeRequired bool `json:"password_change_required"`
 }
 
 func (uc *AuthUseCaseImpl) Login(ctx context.Context, req LoginInput) (*LoginOutput, error) {
-	if err := uc.authService.CheckRateLimit(ctx, req.Username, req.IPAddress); err != nil {
-		_ = uc.authService.RecordLoginAttempt(ctx, req.Username, req.IPAddress, false, "rate_limited")
+	// Attempts are keyed by the account's username whichever identifier was
+	// typed, and by the canonical identifier for unknown accounts, so that
+	// neither the email nor a change of case gets its own rate limit.
+	identifier := domain.NormalizeLoginIdentifier(req.Identifier)
+	attemptKey := uc.authService.LoginAttemptKey(ctx, identifier)
+	if err := uc.authService.CheckRateLimit(ctx, attemptKey, req.IPAddress); err != nil {
+		_ = uc.authService.RecordLoginAttempt(ctx, attemptKey, req.IPAddress, false, "rate_limited")
 		return nil, err
 	}
 
-	user, err := uc.authService.ValidateCredentials(ctx, req.Username, req.Password)
+	user, err := uc.authService.ValidateCredentials(ctx, identifier, req.Password)
 	if err != nil {
-		_ = uc.authService.RecordLoginAttempt(ctx, req.Username, req.IPAddress, false, "invalid_credentials")
+		_ = uc.authService.RecordLoginAttempt(ctx, attemptKey, req.IPAddress, false, "invalid_credentials")
 		return nil, err
 	}
+	username := user.Username().String()
 
-	if !user.CanLogin() {
-		_ = uc.authService.RecordLoginAttempt(ctx, req.Username, req.IPAddress, false, "account_disabled")
+	if !canLogInOrRestore(user) {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "account_disabled")
 		return nil, domain.ErrAccountLocked
 	}
 
+	deletionScheduled := user.DeletionScheduled()
 	var response *LoginOutput
-	err = uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
-		session, err := uc.authService.CreateSession(ctx, user.ID(), req.DeviceInfo, req.IPAddress)
-		if err != nil {
-			return domain.DefineError(domain.ErrCatSystem, "SESSION_CREATE_FAILED", "failed to create session").Wrap(err)
-		}
-
-		accessToken, err := uc.jwtService.GenerateAccessToken(user.ID(), session.ID(), user.Role())
-		if err != nil {
-			return domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate access token").Wrap(err)
+	err = uc.transactionMgr.ExecuteInTransactionWithRetry(ctx, database.DefaultRetryOptions(), func(ctx context.Context) error {
+		if err := uc.cancelAccountDeletion(ctx, user, deletionScheduled); err != nil {
+			return err
 		}
 
-		refreshToken, err := uc.jwtService.GenerateRefreshToken(user.ID(), session.ID())
+		output, err := uc.startSession(ctx, user, req.DeviceInfo, req.IPAddress, req.RememberMe)
 		if err != nil {
-			return domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate refresh token").Wrap(err)
+			return err
 		}
+		response = output
 
-		if err := uc.authService.RecordLoginAttempt(ctx, req.Username, req.IPAddress, true, ""); err != nil {
+		if err := uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, true, ""); err != nil {
 			// Log error but don't fail the login
 			// In production, you might want to use a proper logger
 			// TODO: Use proper logger
 		}
 
-		response = &LoginOutput{
-			AccessToken:  accessToken.String(),
-			RefreshToken: refreshToken.String(),
-			ExpiresAt:    time.Now().Add(15 * time.Minute), // Access token expires in 15 minutes
-			User: UserInfo{
-				ID:       user.ID(),
-				Username: user.Username(),
-				Email:    user.Email(),
-				Role:     user.Role(),
-				Status:   user.Status(),
-			},
-		}
-
 		return nil
 	})
 
+	if errors.Is(err, domain.ErrSessionLimitReached) {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "session_limit_reached")
+		return nil, err
+	}
 	if err != nil {
 		return nil, domain.DefineError(domain.ErrCatSystem, "LOGIN_FAILED", "login process failed").Wrap(err)
 	}
@@ -94,6 +94,49 @@ func (uc *AuthUseCaseImpl) Login(ctx context.Context, req LoginInput) (*LoginOut
 	return response, nil
 }
 
+// startSession creates a session for an authenticated user and issues its
+// tokens.
+func (uc *AuthUseCaseImpl) startSession(ctx context.Context, user *domain.User, deviceInfo, ipAddress string, rememberMe bool) (*LoginOutput, error) {
+	session, err := uc.authService.CreateSession(ctx, user.ID(), deviceInfo, ipAddress, rememberMe)
+	if errors.Is(err, domain.ErrSessionLimitReached) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "SESSION_CREATE_FAILED", "failed to create session").Wrap(err)
+	}
+
+	return uc.sessionTokens(user, session, time.Now())
+}
+
+// sessionTokens issues a new token pair for session recording that the user
+// last authenticated at authTime.
+func (uc *AuthUseCaseImpl) sessionTokens(user *domain.User, session *domain.Session, authTime time.Time) (*LoginOutput, error) {
+	accessToken, err := uc.jwtService.GenerateAccessToken(user.ID(), session.ID(), user.Role(), authTime, uc.authService.AccessScopes(user))
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate access token").Wrap(err)
+	}
+
+	refreshToken, err := uc.jwtService.GenerateRefreshToken(user.ID(), session.ID(), user.Role(), authTime, session.RememberMe())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate refresh token").Wrap(err)
+	}
+
+	return &LoginOutput{
+		AccessToken:            accessToken.String(),
+		RefreshToken:           refreshToken.String(),
+		ExpiresAt:              time.Now().Add(uc.jwtService.AccessTokenDuration()),
+		RefreshExpiresAt:       session.RefreshExpiresAt().Time(),
+		PasswordChangeRequired: uc.authService.PasswordChangeRequired(user),
+		User: UserInfo{
+			ID:       user.ID(),
+			Username: user.Username(),
+			Email:    user.Email(),
+			Role:     user.Role(),
+			Status:   user.Status(),
+		},
+	}, nil
+}
+
 func (uc *AuthUseCaseImpl) Logout(ctx context.Context, userID domain.UserID, sessionID domain.SessionID) error {
 	session, err := uc.authService.ValidateSession(ctx, sessionID)
 	if err != nil {
@@ -118,10 +161,14 @@ type RefreshTokenInput struct {
 }
 
 type RefreshTokenOutput struct {
-	AccessToken  string    `json:"access_token"`
-	RefreshToken string    `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	User         UserInfo  `json:"user"`
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	User             UserInfo  `json:"user"`
+	// PasswordChangeRequired is set when the access token only allows
+	// changing the password.
+	PasswordChangeRequired bool `json:"password_change_required"`
 }
 
 func (uc *AuthUseCaseImpl) RefreshToken(ctx context.Context, req RefreshTokenInput) (*RefreshTokenOutput, error) {
@@ -162,12 +209,14 @@ func (uc *AuthUseCaseImpl) RefreshToken(ctx context.Context, req RefreshTokenInp
 		return nil, domain.ErrAccountLocked
 	}
 
-	newAccessToken, err := uc.jwtService.GenerateAccessToken(user.ID(), session.ID(), user.Role())
+	// Refreshing keeps the original authentication time; only reauthenticating
+	// moves it forward.
+	newAccessToken, err := uc.jwtService.GenerateAccessToken(user.ID(), session.ID(), user.Role(), claims.AuthTime, uc.authService.AccessScopes(user))
 	if err != nil {
 		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate access token").Wrap(err)
 	}
 
-	newRefreshToken, err := uc.jwtService.GenerateRefreshToken(user.ID(), session.ID())
+	newRefreshToken, err := uc.jwtService.GenerateRefreshToken(user.ID(), session.ID(), user.Role(), claims.AuthTime, session.RememberMe())
 	if err != nil {
 		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate refresh token").Wrap(err)
 	}
@@ -178,9 +227,11 @@ func (uc *AuthUseCaseImpl) RefreshToken(ctx context.Context, req RefreshTokenInp
 	}
 
 	return &RefreshTokenOutput{
-		AccessToken:  newAccessToken.String(),
-		RefreshToken: newRefreshToken.String(),
-		ExpiresAt:    time.Now().Add(15 * time.Minute),
+		AccessToken:            newAccessToken.String(),
+		RefreshToken:           newRefreshToken.String(),
+		ExpiresAt:              time.Now().Add(uc.jwtService.AccessTokenDuration()),
+		RefreshExpiresAt:       session.RefreshExpiresAt().Time(),
+		PasswordChangeRequired: uc.authService.PasswordChangeRequired(user),
 		User: UserInfo{
 			ID:       user.ID(),
 			Username: user.Username(),
@@ -191,58 +242,75 @@ func (uc *AuthUseCaseImpl) RefreshToken(ctx context.Context, req RefreshTokenInp
 	}, nil
 }
 
-type GetUserProfileOutput struct {
-	User UserInfo `json:"user"`
-}
-
-func (uc *AuthUseCaseImpl) GetUserProfile(ctx context.Context, userID domain.UserID) (*GetUserProfileOutput, error) {
-	user, err := uc.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return nil, domain.ErrUserNotFound.Wrap(err)
-	}
-
-	return &GetUserProfileOutput{
-		User: UserInfo{
-			ID:       user.ID(),
-			Username: user.Username(),
-			Email:    user.Email(),
-			Role:     user.Role(),
-			Status:   user.Status(),
-		},
-	}, nil
+type SessionDeviceOutput struct {
+	Browser        string            `json:"browser"`
+	BrowserVersion string            `json:"browser_version"`
+	OS             string            `json:"os"`
+	OSVersion      string            `json:"os_version"`
+	DeviceType     domain.DeviceType `json:"device_type"`
 }
 
 type GetUserSessionsOutput struct {
-	ID           domain.SessionID `json:"id"`
-	UserID       domain.UserID    `json:"user_id"`
-	DeviceInfo   string           `json:"device_info"`
-	IPAddress    string           `json:"ip_address"`
-	LastActivity time.Time        `json:"last_activity"`
-	CreatedAt    time.Time        `json:"created_at"`
-	IsActive     bool             `json:"is_active"`
+	ID           domain.SessionID    `json:"id"`
+	UserID       domain.UserID       `json:"user_id"`
+	Name         string              `json:"name"`
+	Device       SessionDeviceOutput `json:"device"`
+	UserAgent    string              `json:"user_agent"`
+	IPAddress    string              `json:"ip_address"`
+	LastActivity time.Time           `json:"last_activity"`
+	CreatedAt    time.Time           `json:"created_at"`
+	IsActive     bool                `json:"is_active"`
+	IsCurrent    bool                `json:"is_current"`
 }
 
-func (uc *AuthUseCaseImpl) GetUserSessions(ctx context.Context, userID domain.UserID) ([]GetUserSessionsOutput, error) {
+func (uc *AuthUseCaseImpl) GetUserSessions(ctx context.Context, userID domain.UserID, currentSessionID domain.SessionID) ([]GetUserSessionsOutput, error) {
 	sessions, err := uc.sessionRepo.GetActiveSessionsByUserID(ctx, userID)
 	if err != nil {
 		return nil, domain.DefineError(domain.ErrCatSystem, "SESSION_FETCH_FAILED", "failed to get user sessions").Wrap(err)
 	}
 
-		sessionInfos := sliceutil.Map(sessions, func(session *domain.Session) GetUserSessionsOutput {
+	sessionInfos := sliceutil.Map(sessions, func(session *domain.Session) GetUserSessionsOutput {
+		device := session.Device()
 		return GetUserSessionsOutput{
-			ID:           session.ID(),
-			UserID:       session.UserID(),
-			DeviceInfo:   session.DeviceInfo(),
+			ID:     session.ID(),
+			UserID: session.UserID(),
+			Name:   session.Name().String(),
+			Device: SessionDeviceOutput{
+				Browser:        device.Browser(),
+				BrowserVersion: device.BrowserVersion(),
+				OS:             device.OS(),
+				OSVersion:      device.OSVersion(),
+				DeviceType:     device.Type(),
+			},
+			UserAgent:    session.DeviceInfo(),
 			IPAddress:    session.IPAddress().String(),
 			LastActivity: session.LastActivity().Time(),
 			CreatedAt:    session.CreatedAt().Time(),
 			IsActive:     session.IsActive(),
+			IsCurrent:    session.ID() == currentSessionID,
 		}
 	})
 
 	return sessionInfos, nil
 }
 
+func (uc *AuthUseCaseImpl) RenameSession(ctx context.Context, userID domain.UserID, sessionID domain.SessionID, name string) error {
+	sessionName, err := domain.NewSessionName(name)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatValidation, "INVALID_SESSION_NAME", "session name must be at most 100 characters").Wrap(err)
+	}
+
+	renamed, err := uc.sessionRepo.Rename(ctx, userID, sessionID, sessionName)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "SESSION_UPDATE_FAILED", "failed to rename session").Wrap(err)
+	}
+	if !renamed {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
 func (uc *AuthUseCaseImpl) RevokeSession(ctx context.Context, userID domain.UserID, sessionID domain.SessionID) error {
 	session, err := uc.authService.ValidateSession(ctx, sessionID)
 	if err != nil {
@@ -269,20 +337,25 @@ func (uc *AuthUseCaseImpl) RevokeAllSessions(ctx context.Context, userID domain.
 }
 
 func (uc *AuthUseCaseImpl) ChangePassword(ctx context.Context, userID domain.UserID, oldPassword, newPassword string) error {
-	if err := uc.passwordService.ValidateStrength(newPassword); err != nil {
-		return domain.DefineError(domain.ErrCatValidation, "INVALID_PASSWORD", "password validation failed").Wrap(err)
-	}
-
-	return uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+	return uc.transactionMgr.ExecuteInTransactionWithRetry(ctx, database.DefaultRetryOptions(), func(ctx context.Context) error {
 		user, err := uc.userRepo.GetByID(ctx, userID)
 		if err != nil {
 			return domain.ErrUserNotFound.Wrap(err)
 		}
 
+		userInputs := []string{user.Username().String(), user.Email().String(), user.FirstName().String(), user.LastName().String()}
+		if err := uc.passwordService.ValidateStrength(newPassword, user.Role(), userInputs...); err != nil {
+			return domain.DefineError(domain.ErrCatValidation, "INVALID_PASSWORD", "password validation failed").Wrap(err)
+		}
+
 		if !user.VerifyPassword(oldPassword) {
 			return domain.DefineError(domain.ErrCatAuth, "INVALID_PASSWORD", "old password is incorrect")
 		}
 
+		if err := uc.checkPasswordReuse(ctx, user, newPassword); err != nil {
+			return err
+		}
+
 		if err := user.ChangePassword(newPassword); err != nil {
 			return domain.DefineError(domain.ErrCatSystem, "PASSWORD_CHANGE_FAILED", "failed to change password").Wrap(err)
 		}
@@ -291,6 +364,10 @@ func (uc *AuthUseCaseImpl) ChangePassword(ctx context.Context, userID domain.Use
 			return domain.DefineError(domain.ErrCatSystem, "USER_UPDATE_FAILED", "failed to save user").Wrap(err)
 		}
 
+		if err := uc.recordPasswordHistory(ctx, user); err != nil {
+			return err
+		}
+
 		if err := uc.authService.InvalidateAllUserSessions(ctx, userID, domain.SessionID("")); err != nil {
 			// Log error but don't fail the password change
 			// TODO: Use proper logger
@@ -299,3 +376,243 @@ func (uc *AuthUseCaseImpl) ChangePassword(ctx context.Context, userID domain.Use
 		return nil
 	})
 }
+
+type PasswordPolicyOutput struct {
+	Role             domain.UserRole `json:"role"`
+	MinLength        int             `json:"min_length"`
+	MaxLength        int             `json:"max_length"`
+	RequireUppercase bool            `json:"require_uppercase"`
+	RequireLowercase bool            `json:"require_lowercase"`
+	RequireNumbers   bool            `json:"require_numbers"`
+	RequireSymbols   bool            `json:"require_symbols"`
+	MinScore         int             `json:"min_score"`
+	HistorySize      int             `json:"history_size"`
+}
+
+// GetPasswordPolicy returns the rules a new password for role must meet.
+func (uc *AuthUseCaseImpl) GetPasswordPolicy(ctx context.Context, role domain.UserRole) (*PasswordPolicyOutput, error) {
+	policy := uc.passwordService.Policy(role)
+
+	return &PasswordPolicyOutput{
+		Role:             role,
+		MinLength:        policy.MinLength,
+		MaxLength:        policy.MaxLength,
+		RequireUppercase: policy.RequireUppercase,
+		RequireLowercase: policy.RequireLowercase,
+		RequireNumbers:   policy.RequireNumbers,
+		RequireSymbols:   policy.RequireSymbols,
+		MinScore:         policy.MinScore,
+		HistorySize:      policy.HistorySize,
+	}, nil
+}
+
+type PasswordStrengthInput struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type PasswordStrengthOutput struct {
+	Score        int      `json:"score"`
+	Guesses      float64  `json:"guesses"`
+	GuessesLog10 float64  `json:"guesses_log10"`
+	Warning      string   `json:"warning"`
+	Suggestions  []string `json:"suggestions"`
+}
+
+// EstimatePasswordStrength rates a candidate password. Username and email,
+// when given, count against passwords built from them.
+func (uc *AuthUseCaseImpl) EstimatePasswordStrength(ctx context.Context, req PasswordStrengthInput) (*PasswordStrengthOutput, error) {
+	var userInputs []string
+	for _, input := range []string{req.Username, req.Email} {
+		if input != "" {
+			userInputs = append(userInputs, input)
+		}
+	}
+
+	estimate := uc.passwordService.EstimateStrength(req.Password, userInputs...)
+
+	suggestions := estimate.Suggestions
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	return &PasswordStrengthOutput{
+		Score:        estimate.Score,
+		Guesses:      estimate.Guesses,
+		GuessesLog10: estimate.GuessesLog10,
+		Warning:      estimate.Warning,
+		Suggestions:  suggestions,
+	}, nil
+}
+
+// RequirePasswordChange makes the user set a new password before they get full
+// access again. Their sessions are ended so that the next login picks up the
+// restriction.
+func (uc *AuthUseCaseImpl) RequirePasswordChange(ctx context.Context, userID domain.UserID) error {
+	return uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return domain.ErrUserNotFound.Wrap(err)
+		}
+		if user == nil {
+			return domain.ErrUserNotFound
+		}
+
+		user.RequirePasswordChange()
+
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return domain.DefineError(domain.ErrCatSystem, "USER_UPDATE_FAILED", "failed to save user").Wrap(err)
+		}
+
+		if err := uc.authService.InvalidateAllUserSessions(ctx, userID, domain.SessionID("")); err != nil {
+			return domain.DefineError(domain.ErrCatSystem, "SESSION_INVALIDATE_FAILED", "failed to invalidate sessions").Wrap(err)
+		}
+
+		return nil
+	})
+}
+
+// checkPasswordReuse returns ErrPasswordReused when newPassword matches the
+// user's current password or one kept in the password history. Every password
+// change or reset must call it before replacing the hash.
+func (uc *AuthUseCaseImpl) checkPasswordReuse(ctx context.Context, user *domain.User, newPassword string) error {
+	historySize := uc.passwordService.Policy(user.Role()).HistorySize
+	if historySize <= 0 {
+		return nil
+	}
+
+	hashes, err := uc.passwordHistory.GetRecentByUserID(ctx, user.ID(), historySize)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "PASSWORD_HISTORY_FETCH_FAILED", "failed to get password history").Wrap(err)
+	}
+
+	for _, hash := range append(hashes, user.Password()) {
+		if uc.passwordService.Verify(newPassword, hash) == nil {
+			return domain.ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// recordPasswordHistory stores the user's new hash and drops the entries that
+// fall outside the history size of the user's password policy.
+func (uc *AuthUseCaseImpl) recordPasswordHistory(ctx context.Context, user *domain.User) error {
+	historySize := uc.passwordService.Policy(user.Role()).HistorySize
+	if historySize <= 0 {
+		return nil
+	}
+
+	if err := uc.passwordHistory.Create(ctx, user.ID(), user.Password()); err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "PASSWORD_HISTORY_SAVE_FAILED", "failed to save password history").Wrap(err)
+	}
+
+	if err := uc.passwordHistory.PruneByUserID(ctx, user.ID(), historySize); err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "PASSWORD_HISTORY_PRUNE_FAILED", "failed to prune password history").Wrap(err)
+	}
+
+	return nil
+}
+
+type IntrospectTokenOutput struct {
+	Active    bool             `json:"active"`
+	UserID    domain.UserID    `json:"user_id"`
+	Username  string           `json:"username"`
+	Role      domain.UserRole  `json:"role"`
+	Scopes    []domain.Scope   `json:"scopes"`
+	SessionID domain.SessionID `json:"session_id"`
+	TokenType domain.TokenType `json:"token_type"`
+	IssuedAt  time.Time        `json:"issued_at"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+// IntrospectToken reports whether token is currently usable. Beyond the
+// signature and expiry it requires the backing session to be active and the
+// user to still be allowed to log in. Tokens that fail any check are reported
+// as inactive without saying why, as RFC 7662 requires.
+func (uc *AuthUseCaseImpl) IntrospectToken(ctx context.Context, token string) (*IntrospectTokenOutput, error) {
+	inactive := &IntrospectTokenOutput{Active: false}
+
+	claims, session, err := uc.resolveTokenSession(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil || session == nil || !session.IsActive() {
+		return inactive, nil
+	}
+
+	if claims.IsRefreshToken() && !session.CanRefresh() {
+		return inactive, nil
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, session.UserID())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+	}
+	if user == nil || !user.CanLogin() {
+		return inactive, nil
+	}
+
+	return &IntrospectTokenOutput{
+		Active:    true,
+		UserID:    user.ID(),
+		Username:  user.Username().String(),
+		Role:      user.Role(),
+		Scopes:    claims.Scopes,
+		SessionID: session.ID(),
+		TokenType: domain.TokenType(claims.TokenType),
+		IssuedAt:  claims.IssuedAt,
+		ExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+// RevokeToken deactivates the session behind an access or refresh token, which
+// invalidates every token issued for it. Unknown or already invalid tokens are
+// not an error, per RFC 7009.
+func (uc *AuthUseCaseImpl) RevokeToken(ctx context.Context, token string) error {
+	claims, session, err := uc.resolveTokenSession(ctx, token)
+	if err != nil {
+		return err
+	}
+	if claims == nil || session == nil || !session.IsActive() {
+		return nil
+	}
+
+	if err := uc.authService.InvalidateSession(ctx, session.ID()); err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "SESSION_REVOKE_FAILED", "failed to revoke session").Wrap(err)
+	}
+
+	return nil
+}
+
+// resolveTokenSession verifies token and loads the session it was issued for.
+// It returns nil claims when the token itself is invalid and a nil session
+// when the session does not exist or belongs to another user; only storage
+// failures are returned as errors.
+func (uc *AuthUseCaseImpl) resolveTokenSession(ctx context.Context, token string) (*domain.TokenClaims, *domain.Session, error) {
+	jwtToken, err := domain.NewJWT(token)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	claims, err := uc.jwtService.ValidateToken(jwtToken)
+	if err != nil || claims.IsExpired() {
+		return nil, nil, nil
+	}
+
+	sessionID, err := domain.NewSessionIDFromString(claims.SessionID)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	session, err := uc.sessionRepo.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, nil, domain.DefineError(domain.ErrCatSystem, "SESSION_FETCH_FAILED", "failed to get session").Wrap(err)
+	}
+	if session == nil || session.UserID().String() != claims.UserID {
+		return claims, nil, nil
+	}
+
+	return claims, session, nil
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/mailer"
+)
+
+// EmailChangeConfig controls email address changes. URL is the frontend page
+// that receives the token in its "token" query parameter and posts it to the
+// confirm endpoint.
+type EmailChangeConfig struct {
+	TTL time.Duration
+	URL string
+}
+
+func DefaultEmailChangeConfig() EmailChangeConfig {
+	return EmailChangeConfig{
+		TTL: 24 * time.Hour,
+		URL: "http://localhost:3000/auth/confirm-email",
+	}
+}
+
+type EmailChangeOutput struct {
+	PendingEmail string    `json:"pending_email"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// RequestEmailChange sends a confirmation link to the new address and a notice
+// to the current one. The account keeps its current address until the link
+// is followed; requesting again replaces any earlier link.
+func (uc *AuthUseCaseImpl) RequestEmailChange(ctx context.Context, userID domain.UserID, email string) (*EmailChangeOutput, error) {
+	normalized, err := domain.NewEmail(email)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_EMAIL", "invalid email address").Wrap(err)
+	}
+	newEmail := normalized.String()
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	oldEmail := user.Email().String()
+	if oldEmail == newEmail {
+		return nil, domain.DefineError(domain.ErrCatValidation, "EMAIL_UNCHANGED", "new email address is the current one")
+	}
+	if err := uc.checkEmailAvailable(ctx, userID, newEmail); err != nil {
+		return nil, err
+	}
+
+	token, err := domain.GenerateEmailChangeToken()
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate email change link").Wrap(err)
+	}
+
+	expiresAt := time.Now().Add(uc.emailChanges.TTL)
+	change, err := domain.NewEmailChange(userID, oldEmail, newEmail, token, expiresAt)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_CREATE_FAILED", "failed to create email change").Wrap(err)
+	}
+
+	err = uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.emailChangeRepo.ConsumeAllByUserID(ctx, userID); err != nil {
+			return err
+		}
+		return uc.emailChangeRepo.Create(ctx, change)
+	})
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_CREATE_FAILED", "failed to save email change").Wrap(err)
+	}
+
+	linkURL, err := uc.emailChangeURL(token)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_CREATE_FAILED", "failed to build email change link").Wrap(err)
+	}
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Use this link within %s to make this the email address of your account:\n\n%s\n\n"+
+			"If you did not ask for this, you can ignore this email.\n",
+			humanDuration(uc.emailChanges.TTL), linkURL),
+	})
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_SEND_FAILED", "failed to send email change link").Wrap(err)
+	}
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      oldEmail,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf("Someone asked to change the email address of your account to %s. "+
+			"The change happens only once the new address is confirmed.\n\n"+
+			"If this was not you, change your password and sign out your other sessions.\n",
+			newEmail),
+	})
+	if err != nil {
+		// The confirmation has gone out; a lost notice should not undo it.
+		log.Printf("[WARN] email change notice not sent: user_id=%s error=%v", userID, err)
+	}
+
+	return &EmailChangeOutput{PendingEmail: newEmail, ExpiresAt: expiresAt}, nil
+}
+
+// ConfirmEmailChange switches the account to the address the token was sent
+// to. Unused magic links and sign-in and reauthentication codes are retired,
+// since they may have been sent to the old address.
+func (uc *AuthUseCaseImpl) ConfirmEmailChange(ctx context.Context, token string) error {
+	changeToken, err := domain.NewEmailChangeToken(token)
+	if err != nil {
+		return domain.ErrEmailChangeInvalid.Wrap(err)
+	}
+
+	change, err := uc.emailChangeRepo.GetByTokenHash(ctx, changeToken.Hash())
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_FETCH_FAILED", "failed to get email change").Wrap(err)
+	}
+	if change == nil || !change.CanConfirm() {
+		return domain.ErrEmailChangeInvalid
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, change.UserID())
+	if err != nil {
+		return domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil || user.Email().String() != change.OldEmail().String() {
+		return domain.ErrEmailChangeInvalid
+	}
+
+	newEmail := change.NewEmail().String()
+	if err := uc.checkEmailAvailable(ctx, user.ID(), newEmail); err != nil {
+		return err
+	}
+
+	err = uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		consumed, err := uc.emailChangeRepo.MarkConsumed(ctx, change.ID())
+		if err != nil {
+			return domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_UPDATE_FAILED", "failed to consume email change").Wrap(err)
+		}
+		if !consumed {
+			return domain.ErrEmailChangeInvalid
+		}
+
+		if err := user.ChangeEmail(newEmail); err != nil {
+			return err
+		}
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if err := uc.magicLinkRepo.ConsumeAllByUserID(ctx, user.ID()); err != nil {
+			return err
+		}
+		for _, purpose := range []domain.OTPPurpose{domain.OTPPurposeLogin, domain.OTPPurposeReauthentication} {
+			if err := uc.otpRepo.ConsumeAllByUserID(ctx, user.ID(), purpose); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrEmailChangeInvalid) {
+		return err
+	}
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "EMAIL_CHANGE_FAILED", "failed to change email address").Wrap(err)
+	}
+
+	uc.authService.InvalidateUser(ctx, user.ID())
+
+	log.Printf("[INFO] email address changed: user_id=%s", user.ID())
+	return nil
+}
+
+func (uc *AuthUseCaseImpl) checkEmailAvailable(ctx context.Context, userID domain.UserID, email string) error {
+	owner, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+	}
+	if owner != nil && owner.ID() != userID {
+		return domain.ErrEmailTaken
+	}
+	return nil
+}
+
+func (uc *AuthUseCaseImpl) emailChangeURL(token domain.EmailChangeToken) (string, error) {
+	u, err := url.Parse(uc.emailChanges.URL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("token", token.String())
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
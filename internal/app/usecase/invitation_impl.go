@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/mailer"
+	"beerdosan-backend/internal/pkg/sliceutil"
+)
+
+// InvitationConfig controls invitations. URL is the frontend page that
+// receives the token in its "token" query parameter and posts it, with the
+// chosen username and password, to the accept endpoint.
+type InvitationConfig struct {
+	TTL time.Duration
+	URL string
+}
+
+func DefaultInvitationConfig() InvitationConfig {
+	return InvitationConfig{
+		TTL: 7 * 24 * time.Hour,
+		URL: "http://localhost:3000/auth/accept-invitation",
+	}
+}
+
+type CreateInvitationInput struct {
+	Email     string        `json:"email"`
+	Role      string        `json:"role"`
+	InvitedBy domain.UserID `json:"-"`
+}
+
+type InvitationOutput struct {
+	ID         int64                   `json:"id"`
+	Email      domain.Email            `json:"email"`
+	Role       domain.UserRole         `json:"role"`
+	InvitedBy  domain.UserID           `json:"invited_by"`
+	Status     domain.InvitationStatus `json:"status"`
+	ExpiresAt  time.Time               `json:"expires_at"`
+	AcceptedAt *time.Time              `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time              `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+func newInvitationOutput(invitation *domain.Invitation) InvitationOutput {
+	return InvitationOutput{
+		ID:         invitation.ID().Value(),
+		Email:      invitation.Email(),
+		Role:       invitation.Role(),
+		InvitedBy:  invitation.InvitedBy(),
+		Status:     invitation.Status(),
+		ExpiresAt:  invitation.ExpiresAt().Time(),
+		AcceptedAt: invitation.AcceptedAt(),
+		RevokedAt:  invitation.RevokedAt(),
+		CreatedAt:  invitation.CreatedAt().Time(),
+	}
+}
+
+// CreateInvitation emails a link with which the person at the address can
+// create an account with the given role. Inviting an address again revokes
+// the earlier invitations to it.
+func (uc *AuthUseCaseImpl) CreateInvitation(ctx context.Context, req CreateInvitationInput) (*InvitationOutput, error) {
+	email, err := domain.NewEmail(req.Email)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_EMAIL", "invalid email address").Wrap(err)
+	}
+
+	role, err := domain.NewUserRole(req.Role)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_ROLE", "role must be admin, user or guest").Wrap(err)
+	}
+
+	if err := uc.checkEmailAvailable(ctx, "", email.String()); err != nil {
+		return nil, err
+	}
+
+	token, err := domain.GenerateInvitationToken()
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate invitation").Wrap(err)
+	}
+
+	invitation, err := domain.NewInvitation(email.String(), role, req.InvitedBy, token, time.Now().Add(uc.invitations.TTL))
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_CREATE_FAILED", "failed to create invitation").Wrap(err)
+	}
+
+	err = uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.invitationRepo.RevokeOpenByEmail(ctx, email); err != nil {
+			return err
+		}
+		created, err := uc.invitationRepo.Create(ctx, invitation)
+		if err != nil {
+			return err
+		}
+		invitation = created
+		return nil
+	})
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_CREATE_FAILED", "failed to save invitation").Wrap(err)
+	}
+
+	linkURL, err := uc.invitationURL(token)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_CREATE_FAILED", "failed to build invitation link").Wrap(err)
+	}
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      email.String(),
+		Subject: "You have been invited",
+		Body: fmt.Sprintf("You have been invited to create an account. Use this link within %s to choose a username and password:\n\n%s\n\n"+
+			"If you were not expecting this, you can ignore this email.\n",
+			humanDuration(uc.invitations.TTL), linkURL),
+	})
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_SEND_FAILED", "failed to send invitation").Wrap(err)
+	}
+
+	log.Printf("[INFO] invitation created: invitation_id=%d role=%s invited_by=%s", invitation.ID().Value(), role, req.InvitedBy)
+
+	output := newInvitationOutput(invitation)
+	return &output, nil
+}
+
+type InvitationListOutput struct {
+	Invitations []InvitationOutput `json:"invitations"`
+	Total       int64              `json:"total"`
+}
+
+func (uc *AuthUseCaseImpl) ListInvitations(ctx context.Context, page, limit int) (*InvitationListOutput, error) {
+	invitations, total, err := uc.invitationRepo.List(ctx, (page-1)*limit, limit)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_FETCH_FAILED", "failed to list invitations").Wrap(err)
+	}
+
+	return &InvitationListOutput{
+		Invitations: sliceutil.Map(invitations, newInvitationOutput),
+		Total:       total,
+	}, nil
+}
+
+func (uc *AuthUseCaseImpl) RevokeInvitation(ctx context.Context, id int64) error {
+	invitationID, err := domain.NewID(id)
+	if err != nil {
+		return domain.ErrInvitationNotFound.Wrap(err)
+	}
+
+	invitation, err := uc.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "INVITATION_FETCH_FAILED", "failed to get invitation").Wrap(err)
+	}
+	if invitation == nil {
+		return domain.ErrInvitationNotFound
+	}
+
+	revoked, err := uc.invitationRepo.Revoke(ctx, invitationID)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "INVITATION_UPDATE_FAILED", "failed to revoke invitation").Wrap(err)
+	}
+	if !revoked {
+		return domain.DefineError(domain.ErrCatBusiness, "INVITATION_NOT_OPEN", "invitation was already accepted or revoked")
+	}
+
+	return nil
+}
+
+type AcceptInvitationInput struct {
+	Token     string `json:"token"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Password  string `json:"password"`
+}
+
+// AcceptInvitation creates the invitee's account with the invited address and
+// role. Accepting the invitation and creating the user happen in one
+// transaction, so a failure leaves the invitation open.
+func (uc *AuthUseCaseImpl) AcceptInvitation(ctx context.Context, req AcceptInvitationInput) (*UserInfo, error) {
+	token, err := domain.NewInvitationToken(req.Token)
+	if err != nil {
+		return nil, domain.ErrInvitationInvalid.Wrap(err)
+	}
+
+	invitation, err := uc.invitationRepo.GetByTokenHash(ctx, token.Hash())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_FETCH_FAILED", "failed to get invitation").Wrap(err)
+	}
+	if invitation == nil || !invitation.CanAccept() {
+		return nil, domain.ErrInvitationInvalid
+	}
+
+	email := invitation.Email().String()
+	userInputs := []string{req.Username, email, req.FirstName, req.LastName}
+	if err := uc.passwordService.ValidateStrength(req.Password, invitation.Role(), userInputs...); err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_PASSWORD", "password validation failed").Wrap(err)
+	}
+
+	user, err := domain.NewUser(req.Username, email, req.FirstName, req.LastName, req.Password)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_USER", err.Error()).Wrap(err)
+	}
+	user.AssignRole(invitation.Role())
+
+	existing, err := uc.userRepo.GetByUsername(ctx, user.Username().String())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+	}
+	if existing != nil {
+		return nil, domain.ErrUsernameTaken
+	}
+	if err := uc.checkEmailAvailable(ctx, "", email); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactionMgr.ExecuteWithOptions(ctx, nil, func(tx *gorm.DB) error {
+		accepted, err := uc.invitationRepo.MarkAcceptedInTx(tx, invitation.ID())
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return domain.ErrInvitationInvalid
+		}
+
+		created, err := uc.userRepo.CreateInTx(tx, user)
+		if err != nil {
+			return err
+		}
+		user = created
+
+		if uc.passwordService.Policy(user.Role()).HistorySize > 0 {
+			return uc.passwordHistory.CreateInTx(tx, user.ID(), user.Password())
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrInvitationInvalid) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "INVITATION_ACCEPT_FAILED", "failed to create account").Wrap(err)
+	}
+
+	log.Printf("[INFO] invitation accepted: invitation_id=%d user_id=%s", invitation.ID().Value(), user.ID())
+
+	return &UserInfo{
+		ID:       user.ID(),
+		Username: user.Username(),
+		Email:    user.Email(),
+		Role:     user.Role(),
+		Status:   user.Status(),
+	}, nil
+}
+
+func (uc *AuthUseCaseImpl) invitationURL(token domain.InvitationToken) (string, error) {
+	u, err := url.Parse(uc.invitations.URL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("token", token.String())
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+	"beerdosan-backend/internal/pkg/mailer"
+)
+
+// MagicLinkConfig controls passwordless login links. URL is the frontend page
+// that receives the token in its "token" query parameter and posts it to the
+// consume endpoint. At most MaxPerWindow links are sent to one address per
+// Window; zero or less means unlimited.
+type MagicLinkConfig struct {
+	TTL          time.Duration
+	URL          string
+	MaxPerWindow int
+	Window       time.Duration
+}
+
+func DefaultMagicLinkConfig() MagicLinkConfig {
+	return MagicLinkConfig{
+		TTL:          15 * time.Minute,
+		URL:          "http://localhost:3000/auth/magic-link",
+		MaxPerWindow: 3,
+		Window:       time.Hour,
+	}
+}
+
+type MagicLinkInput struct {
+	Email     string `json:"email"`
+	IPAddress string `json:"ip_address"`
+}
+
+type MagicLinkOutput struct {
+	// BindingToken must be kept in the requesting browser and sent back when
+	// the link is consumed.
+	BindingToken string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// RequestMagicLink emails a login link to the user with the given address.
+// The outcome looks the same whether or not the address belongs to a user,
+// or the address is over its rate limit, so that it cannot be used to find
+// accounts.
+func (uc *AuthUseCaseImpl) RequestMagicLink(ctx context.Context, req MagicLinkInput) (*MagicLinkOutput, error) {
+	binding, err := domain.GenerateMagicLinkToken()
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate magic link").Wrap(err)
+	}
+
+	output := &MagicLinkOutput{
+		BindingToken: binding.String(),
+		ExpiresAt:    time.Now().Add(uc.magicLinks.TTL),
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+	}
+	if user == nil || !(user.CanLogin() || user.DeletionScheduled()) {
+		return output, nil
+	}
+
+	if uc.magicLinks.MaxPerWindow > 0 {
+		count, err := uc.magicLinkRepo.CountCreatedByEmailSince(ctx, user.Email().String(), time.Now().Add(-uc.magicLinks.Window))
+		if err != nil {
+			return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_COUNT_FAILED", "failed to count magic links").Wrap(err)
+		}
+		if count >= int64(uc.magicLinks.MaxPerWindow) {
+			log.Printf("[WARN] magic link rate limit reached: user_id=%s ip_address=%s", user.ID(), req.IPAddress)
+			return output, nil
+		}
+	}
+
+	token, err := domain.GenerateMagicLinkToken()
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "TOKEN_GENERATION_FAILED", "failed to generate magic link").Wrap(err)
+	}
+
+	link, err := domain.NewMagicLink(user.ID(), user.Email().String(), req.IPAddress, token, binding, output.ExpiresAt)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_CREATE_FAILED", "failed to create magic link").Wrap(err)
+	}
+
+	if err := uc.magicLinkRepo.Create(ctx, link); err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_CREATE_FAILED", "failed to save magic link").Wrap(err)
+	}
+
+	linkURL, err := uc.magicLinkURL(token)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_CREATE_FAILED", "failed to build magic link").Wrap(err)
+	}
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      user.Email().String(),
+		Subject: "Your sign-in link",
+		Body: fmt.Sprintf("Use this link to sign in. It works once, for %s, in the browser you requested it from:\n\n%s\n\n"+
+			"If you did not ask to sign in, you can ignore this email.\n",
+			humanDuration(uc.magicLinks.TTL), linkURL),
+	})
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_SEND_FAILED", "failed to send magic link").Wrap(err)
+	}
+
+	return output, nil
+}
+
+type ConsumeMagicLinkInput struct {
+	Token        string `json:"token"`
+	BindingToken string `json:"-"`
+	DeviceInfo   string `json:"device_info"`
+	IPAddress    string `json:"ip_address"`
+	RememberMe   bool   `json:"remember_me"`
+}
+
+// ConsumeMagicLink exchanges a link token for a session, provided the request
+// comes from the browser that asked for the link.
+func (uc *AuthUseCaseImpl) ConsumeMagicLink(ctx context.Context, req ConsumeMagicLinkInput) (*LoginOutput, error) {
+	token, err := domain.NewMagicLinkToken(req.Token)
+	if err != nil {
+		return nil, domain.ErrMagicLinkInvalid.Wrap(err)
+	}
+
+	link, err := uc.magicLinkRepo.GetByTokenHash(ctx, token.Hash())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_FETCH_FAILED", "failed to get magic link").Wrap(err)
+	}
+	if link == nil || !link.CanConsume() {
+		return nil, domain.ErrMagicLinkInvalid
+	}
+
+	binding, err := domain.NewMagicLinkToken(req.BindingToken)
+	if err != nil || !link.MatchesBinding(binding) {
+		return nil, domain.ErrMagicLinkWrongBrowser
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, link.UserID())
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	// A link sent to an address the account no longer has is void.
+	if user == nil || link.Email().String() != user.Email().String() {
+		return nil, domain.ErrMagicLinkInvalid
+	}
+
+	username := user.Username().String()
+	if !canLogInOrRestore(user) {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "account_disabled")
+		return nil, domain.ErrAccountLocked
+	}
+
+	deletionScheduled := user.DeletionScheduled()
+	var response *LoginOutput
+	err = uc.transactionMgr.ExecuteInTransactionWithRetry(ctx, database.DefaultRetryOptions(), func(ctx context.Context) error {
+		consumed, err := uc.magicLinkRepo.MarkConsumed(ctx, link.ID())
+		if err != nil {
+			return domain.DefineError(domain.ErrCatSystem, "MAGIC_LINK_UPDATE_FAILED", "failed to consume magic link").Wrap(err)
+		}
+		if !consumed {
+			return domain.ErrMagicLinkInvalid
+		}
+
+		if err := uc.cancelAccountDeletion(ctx, user, deletionScheduled); err != nil {
+			return err
+		}
+
+		output, err := uc.startSession(ctx, user, req.DeviceInfo, req.IPAddress, req.RememberMe)
+		if err != nil {
+			return err
+		}
+		response = output
+
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, true, "")
+		return nil
+	})
+
+	if errors.Is(err, domain.ErrSessionLimitReached) {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "session_limit_reached")
+		return nil, err
+	}
+	if errors.Is(err, domain.ErrMagicLinkInvalid) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "LOGIN_FAILED", "login process failed").Wrap(err)
+	}
+
+	return response, nil
+}
+
+func (uc *AuthUseCaseImpl) magicLinkURL(token domain.MagicLinkToken) (string, error) {
+	u, err := url.Parse(uc.magicLinks.URL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("token", token.String())
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
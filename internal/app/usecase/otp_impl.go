@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/pkg/database"
+)
+
+// OTPConfig controls one-time passcodes. A code is good for TTL and
+// MaxAttempts guesses, and another code for the same purpose can be sent once
+// ResendInterval has passed.
+type OTPConfig struct {
+	TTL            time.Duration
+	MaxAttempts    int
+	ResendInterval time.Duration
+}
+
+func DefaultOTPConfig() OTPConfig {
+	return OTPConfig{
+		TTL:            5 * time.Minute,
+		MaxAttempts:    5,
+		ResendInterval: time.Minute,
+	}
+}
+
+type OTPOutput struct {
+	Channel     domain.OTPChannel `json:"channel"`
+	Destination string            `json:"destination"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	ResendAt    time.Time         `json:"resend_at"`
+}
+
+type OTPLoginInput struct {
+	Channel     string `json:"channel"`
+	Destination string `json:"destination"`
+	IPAddress   string `json:"ip_address"`
+}
+
+// RequestLoginOTP sends a login code to an email address or a verified phone
+// number. Like RequestMagicLink it answers the same way for unknown
+// destinations and throttled resends.
+func (uc *AuthUseCaseImpl) RequestLoginOTP(ctx context.Context, req OTPLoginInput) (*OTPOutput, error) {
+	channel, err := domain.NewOTPChannel(req.Channel)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_OTP_CHANNEL", "channel must be email or sms").Wrap(err)
+	}
+
+	user, destination, err := uc.findOTPUser(ctx, channel, req.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	output := &OTPOutput{
+		Channel:     channel,
+		Destination: maskDestination(channel, destination),
+		ExpiresAt:   now.Add(uc.otp.TTL),
+		ResendAt:    now.Add(uc.otp.ResendInterval),
+	}
+	if user == nil || !canLogInOrRestore(user) {
+		return output, nil
+	}
+
+	sent, err := uc.issueOTP(ctx, user.ID(), domain.OTPPurposeLogin, channel, destination)
+	if errors.Is(err, domain.ErrOTPResendTooSoon) {
+		log.Printf("[WARN] login code resend throttled: user_id=%s ip_address=%s", user.ID(), req.IPAddress)
+		return output, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sent, nil
+}
+
+type OTPVerifyInput struct {
+	Channel     string `json:"channel"`
+	Destination string `json:"destination"`
+	Code        string `json:"code"`
+	DeviceInfo  string `json:"device_info"`
+	IPAddress   string `json:"ip_address"`
+	RememberMe  bool   `json:"remember_me"`
+}
+
+// VerifyLoginOTP exchanges a login code for a session.
+func (uc *AuthUseCaseImpl) VerifyLoginOTP(ctx context.Context, req OTPVerifyInput) (*LoginOutput, error) {
+	channel, err := domain.NewOTPChannel(req.Channel)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_OTP_CHANNEL", "channel must be email or sms").Wrap(err)
+	}
+
+	code, err := domain.NewOTPCode(req.Code)
+	if err != nil {
+		return nil, domain.ErrOTPInvalid.Wrap(err)
+	}
+
+	user, destination, err := uc.findOTPUser(ctx, channel, req.Destination)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrOTPInvalid
+	}
+
+	username := user.Username().String()
+	if err := uc.authService.CheckRateLimit(ctx, username, req.IPAddress); err != nil {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "rate_limited")
+		return nil, err
+	}
+
+	if err := uc.verifyOTP(ctx, user.ID(), domain.OTPPurposeLogin, channel, destination, code); err != nil {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "invalid_otp")
+		return nil, err
+	}
+
+	if !canLogInOrRestore(user) {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "account_disabled")
+		return nil, domain.ErrAccountLocked
+	}
+
+	deletionScheduled := user.DeletionScheduled()
+	var response *LoginOutput
+	err = uc.transactionMgr.ExecuteInTransactionWithRetry(ctx, database.DefaultRetryOptions(), func(ctx context.Context) error {
+		if err := uc.cancelAccountDeletion(ctx, user, deletionScheduled); err != nil {
+			return err
+		}
+
+		output, err := uc.startSession(ctx, user, req.DeviceInfo, req.IPAddress, req.RememberMe)
+		if err != nil {
+			return err
+		}
+		response = output
+
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, true, "")
+		return nil
+	})
+
+	if errors.Is(err, domain.ErrSessionLimitReached) {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "session_limit_reached")
+		return nil, err
+	}
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "LOGIN_FAILED", "login process failed").Wrap(err)
+	}
+
+	return response, nil
+}
+
+// ChangePhoneNumber sets a new, unverified phone number and texts it a
+// verification code.
+func (uc *AuthUseCaseImpl) ChangePhoneNumber(ctx context.Context, userID domain.UserID, phoneNumber string) (*OTPOutput, error) {
+	phone, err := domain.NewPhoneNumber(phoneNumber)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_PHONE_NUMBER", err.Error()).Wrap(err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	if err := uc.checkPhoneNumberAvailable(ctx, userID, phone); err != nil {
+		return nil, err
+	}
+
+	if user.PhoneNumber() != phone {
+		user.ChangePhoneNumber(phone)
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return nil, domain.DefineError(domain.ErrCatSystem, "USER_UPDATE_FAILED", "failed to save user").Wrap(err)
+		}
+	}
+
+	return uc.issueOTP(ctx, userID, domain.OTPPurposePhoneVerification, domain.OTPChannelSMS, phone.String())
+}
+
+// VerifyPhoneNumber marks the user's phone number verified, which lets them
+// log in with codes sent to it.
+func (uc *AuthUseCaseImpl) VerifyPhoneNumber(ctx context.Context, userID domain.UserID, code string) error {
+	otpCode, err := domain.NewOTPCode(code)
+	if err != nil {
+		return domain.ErrOTPInvalid.Wrap(err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+	if user.PhoneNumber().IsEmpty() {
+		return domain.ErrOTPInvalid
+	}
+
+	phone := user.PhoneNumber()
+	if err := uc.verifyOTP(ctx, userID, domain.OTPPurposePhoneVerification, domain.OTPChannelSMS, phone.String(), otpCode); err != nil {
+		return err
+	}
+
+	if err := uc.checkPhoneNumberAvailable(ctx, userID, phone); err != nil {
+		return err
+	}
+
+	user.VerifyPhoneNumber()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "USER_UPDATE_FAILED", "failed to save user").Wrap(err)
+	}
+
+	return nil
+}
+
+// findOTPUser looks up the user a code for destination would go to and
+// returns destination in its canonical form. A nil user means there is none.
+func (uc *AuthUseCaseImpl) findOTPUser(ctx context.Context, channel domain.OTPChannel, destination string) (*domain.User, string, error) {
+	switch channel {
+	case domain.OTPChannelSMS:
+		phone, err := domain.NewPhoneNumber(destination)
+		if err != nil {
+			return nil, "", domain.DefineError(domain.ErrCatValidation, "INVALID_PHONE_NUMBER", err.Error()).Wrap(err)
+		}
+
+		user, err := uc.userRepo.GetByVerifiedPhoneNumber(ctx, phone)
+		if err != nil {
+			return nil, "", domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+		}
+		return user, phone.String(), nil
+	default:
+		// Echo the normalised address whether or not an account has it, so
+		// that the answer does not tell the two apart.
+		email, err := domain.NewEmail(destination)
+		if err != nil {
+			return nil, "", domain.DefineError(domain.ErrCatValidation, "INVALID_EMAIL", "invalid email address").Wrap(err)
+		}
+
+		user, err := uc.userRepo.GetByEmail(ctx, email.String())
+		if err != nil {
+			return nil, "", domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+		}
+		return user, email.String(), nil
+	}
+}
+
+func (uc *AuthUseCaseImpl) checkPhoneNumberAvailable(ctx context.Context, userID domain.UserID, phone domain.PhoneNumber) error {
+	owner, err := uc.userRepo.GetByVerifiedPhoneNumber(ctx, phone)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "USER_FETCH_FAILED", "failed to get user").Wrap(err)
+	}
+	if owner != nil && owner.ID() != userID {
+		return domain.ErrPhoneNumberTaken
+	}
+	return nil
+}
+
+// issueOTP sends a new code, retiring earlier codes for the same purpose. It
+// returns ErrOTPResendTooSoon while the previous code is live and younger than
+// the resend interval.
+func (uc *AuthUseCaseImpl) issueOTP(ctx context.Context, userID domain.UserID, purpose domain.OTPPurpose, channel domain.OTPChannel, destination string) (*OTPOutput, error) {
+	sender, ok := uc.otpSenders[channel]
+	if !ok {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_CHANNEL_UNAVAILABLE", fmt.Sprintf("no sender configured for %s", channel))
+	}
+
+	latest, err := uc.otpRepo.GetLatest(ctx, userID, purpose)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_FETCH_FAILED", "failed to get one-time passcode").Wrap(err)
+	}
+	if latest != nil && !latest.IsConsumed() && time.Now().Before(latest.ResendAt(uc.otp.ResendInterval)) {
+		return nil, domain.ErrOTPResendTooSoon
+	}
+
+	code, err := domain.GenerateOTPCode()
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_GENERATION_FAILED", "failed to generate one-time passcode").Wrap(err)
+	}
+
+	otp, err := domain.NewOneTimePasscode(userID, purpose, channel, destination, code, uc.otp.MaxAttempts, time.Now().Add(uc.otp.TTL))
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_GENERATION_FAILED", "failed to generate one-time passcode").Wrap(err)
+	}
+
+	err = uc.transactionMgr.ExecuteInTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.otpRepo.ConsumeAllByUserID(ctx, userID, purpose); err != nil {
+			return err
+		}
+		return uc.otpRepo.Create(ctx, otp)
+	})
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_SAVE_FAILED", "failed to save one-time passcode").Wrap(err)
+	}
+
+	var message string
+	switch purpose {
+	case domain.OTPPurposePhoneVerification:
+		message = fmt.Sprintf("%s is your phone verification code. It expires in %s.", code, humanDuration(uc.otp.TTL))
+	case domain.OTPPurposeReauthentication:
+		message = fmt.Sprintf("%s is your code to confirm it's you. It expires in %s. Do not share it with anyone.", code, humanDuration(uc.otp.TTL))
+	default:
+		message = fmt.Sprintf("%s is your sign-in code. It expires in %s. Do not share it with anyone.", code, humanDuration(uc.otp.TTL))
+	}
+
+	if err := sender.Send(ctx, destination, message); err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "OTP_SEND_FAILED", "failed to send one-time passcode").Wrap(err)
+	}
+
+	return &OTPOutput{
+		Channel:     channel,
+		Destination: maskDestination(channel, destination),
+		ExpiresAt:   otp.ExpiresAt().Time(),
+		ResendAt:    otp.ResendAt(uc.otp.ResendInterval),
+	}, nil
+}
+
+// verifyOTP checks code against the user's live code for purpose, which must
+// have been sent to destination over channel, and consumes it on success.
+// Every call counts as an attempt.
+func (uc *AuthUseCaseImpl) verifyOTP(ctx context.Context, userID domain.UserID, purpose domain.OTPPurpose, channel domain.OTPChannel, destination string, code domain.OTPCode) error {
+	otp, err := uc.otpRepo.GetLatest(ctx, userID, purpose)
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "OTP_FETCH_FAILED", "failed to get one-time passcode").Wrap(err)
+	}
+	if otp == nil || otp.IsConsumed() || otp.IsExpired() ||
+		otp.Channel() != channel || otp.Destination().String() != destination {
+		return domain.ErrOTPInvalid
+	}
+	if otp.AttemptsExhausted() {
+		return domain.ErrOTPAttemptsExceeded
+	}
+
+	attempts, err := uc.otpRepo.IncrementAttempts(ctx, otp.ID())
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "OTP_UPDATE_FAILED", "failed to record attempt").Wrap(err)
+	}
+	if attempts > otp.MaxAttempts() {
+		return domain.ErrOTPAttemptsExceeded
+	}
+
+	if !otp.Matches(code) {
+		return domain.ErrOTPInvalid
+	}
+
+	consumed, err := uc.otpRepo.MarkConsumed(ctx, otp.ID())
+	if err != nil {
+		return domain.DefineError(domain.ErrCatSystem, "OTP_UPDATE_FAILED", "failed to consume one-time passcode").Wrap(err)
+	}
+	if !consumed {
+		return domain.ErrOTPInvalid
+	}
+
+	return nil
+}
+
+func maskDestination(channel domain.OTPChannel, destination string) string {
+	if channel == domain.OTPChannelSMS {
+		return domain.PhoneNumber(destination).Masked()
+	}
+	return destination
+}
+
+// humanDuration renders d for messages, e.g. "5 minutes" rather than "5m0s".
+func humanDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return pluralize(int(d/time.Hour), "hour")
+	case d >= time.Minute:
+		return pluralize(int(d.Round(time.Minute)/time.Minute), "minute")
+	default:
+		return pluralize(int(d.Round(time.Second)/time.Second), "second")
+	}
+}
+
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
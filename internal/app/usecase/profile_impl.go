@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+
+	"beerdosan-backend/internal/app/domain"
+	"beerdosan-backend/internal/app/readmodel"
+)
+
+func (uc *AuthUseCaseImpl) GetUserProfile(ctx context.Context, userID domain.UserID) (*readmodel.UserProfile, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return uc.userProfile(ctx, user)
+}
+
+// UpdateProfileInput changes the fields that are set and leaves the others as
+// they are.
+type UpdateProfileInput struct {
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+}
+
+func (uc *AuthUseCaseImpl) UpdateProfile(ctx context.Context, userID domain.UserID, req UpdateProfileInput) (*readmodel.UserProfile, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	firstName, lastName := user.FirstName().String(), user.LastName().String()
+	if req.FirstName != nil {
+		firstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		lastName = *req.LastName
+	}
+
+	if err := user.UpdateProfile(firstName, lastName); err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_PROFILE", "first and last name must not be empty").Wrap(err)
+	}
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "USER_UPDATE_FAILED", "failed to save user").Wrap(err)
+	}
+
+	return uc.userProfile(ctx, user)
+}
+
+func (uc *AuthUseCaseImpl) userProfile(ctx context.Context, user *domain.User) (*readmodel.UserProfile, error) {
+	lastLoginAt, err := uc.loginAttemptRepo.GetLastSuccessfulAt(ctx, user.Username().String())
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatSystem, "LOGIN_ATTEMPT_FETCH_FAILED", "failed to get last login").Wrap(err)
+	}
+
+	return &readmodel.UserProfile{
+		ID:                  user.ID().String(),
+		Username:            user.Username().String(),
+		Email:               user.Email().String(),
+		FirstName:           user.FirstName().String(),
+		LastName:            user.LastName().String(),
+		FullName:            user.FullName(),
+		Role:                user.Role().String(),
+		Status:              user.Status().String(),
+		CreatedAt:           user.CreatedAt().Time(),
+		UpdatedAt:           user.UpdatedAt().Time(),
+		PhoneNumber:         user.PhoneNumber().String(),
+		PhoneNumberVerified: user.PhoneNumberVerified(),
+		LastLoginAt:         lastLoginAt,
+	}, nil
+}
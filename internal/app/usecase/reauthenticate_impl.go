@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"beerdosan-backend/internal/app/domain"
+)
+
+// RequestReauthenticationOTP sends a code the signed-in user can give to
+// Reauthenticate instead of their password. SMS codes go to the verified
+// phone number only.
+func (uc *AuthUseCaseImpl) RequestReauthenticationOTP(ctx context.Context, userID domain.UserID, channel string) (*OTPOutput, error) {
+	otpChannel, err := domain.NewOTPChannel(channel)
+	if err != nil {
+		return nil, domain.DefineError(domain.ErrCatValidation, "INVALID_OTP_CHANNEL", "channel must be email or sms").Wrap(err)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	destination, err := reauthenticationDestination(user, otpChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.issueOTP(ctx, userID, domain.OTPPurposeReauthentication, otpChannel, destination)
+}
+
+type ReauthenticateInput struct {
+	UserID    domain.UserID    `json:"-"`
+	SessionID domain.SessionID `json:"-"`
+	// Password, or Channel and Code from RequestReauthenticationOTP.
+	Password  string `json:"password"`
+	Channel   string `json:"channel"`
+	Code      string `json:"code"`
+	IPAddress string `json:"ip_address"`
+}
+
+// Reauthenticate checks the user's password or a reauthentication code and
+// issues a new token pair for the same session whose auth_time is now, which
+// unlocks operations that need a recent authentication.
+func (uc *AuthUseCaseImpl) Reauthenticate(ctx context.Context, req ReauthenticateInput) (*LoginOutput, error) {
+	session, err := uc.authService.ValidateSession(ctx, req.SessionID)
+	if err != nil {
+		return nil, domain.ErrInvalidSession.Wrap(err)
+	}
+	if session.UserID() != req.UserID {
+		return nil, domain.DefineError(domain.ErrCatAuth, "SESSION_USER_MISMATCH", "session does not belong to user")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound.Wrap(err)
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if !user.CanLogin() {
+		return nil, domain.ErrAccountLocked
+	}
+
+	username := user.Username().String()
+	if err := uc.authService.CheckRateLimit(ctx, username, req.IPAddress); err != nil {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "rate_limited")
+		return nil, err
+	}
+
+	if err := uc.verifyReauthentication(ctx, user, req); err != nil {
+		_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, false, "reauthentication_failed")
+		return nil, err
+	}
+	_ = uc.authService.RecordLoginAttempt(ctx, username, req.IPAddress, true, "")
+
+	output, err := uc.sessionTokens(user, session, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.authService.UpdateSessionActivity(ctx, session.ID()); err != nil {
+		// Log error but don't fail the reauthentication
+		// TODO: Use proper logger
+	}
+
+	return output, nil
+}
+
+func (uc *AuthUseCaseImpl) verifyReauthentication(ctx context.Context, user *domain.User, req ReauthenticateInput) error {
+	switch {
+	case req.Password != "":
+		if !user.VerifyPassword(req.Password) {
+			return domain.ErrInvalidCredentials
+		}
+		return nil
+	case req.Code != "":
+		channel, err := domain.NewOTPChannel(req.Channel)
+		if err != nil {
+			return domain.DefineError(domain.ErrCatValidation, "INVALID_OTP_CHANNEL", "channel must be email or sms").Wrap(err)
+		}
+
+		code, err := domain.NewOTPCode(req.Code)
+		if err != nil {
+			return domain.ErrOTPInvalid.Wrap(err)
+		}
+
+		destination, err := reauthenticationDestination(user, channel)
+		if err != nil {
+			return err
+		}
+
+		return uc.verifyOTP(ctx, user.ID(), domain.OTPPurposeReauthentication, channel, destination, code)
+	default:
+		return domain.DefineError(domain.ErrCatValidation, "CREDENTIAL_REQUIRED", "password or code is required")
+	}
+}
+
+func reauthenticationDestination(user *domain.User, channel domain.OTPChannel) (string, error) {
+	if channel == domain.OTPChannelSMS {
+		if user.PhoneNumber().IsEmpty() || !user.PhoneNumberVerified() {
+			return "", domain.DefineError(domain.ErrCatValidation, "PHONE_NUMBER_NOT_VERIFIED", "no verified phone number to send a code to")
+		}
+		return user.PhoneNumber().String(), nil
+	}
+	return user.Email().String(), nil
+}
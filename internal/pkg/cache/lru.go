@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"container/list"
+	"expvar"
+	"sync"
+	"time"
+)
+
+// metrics is published under /debug/vars as "cache", keyed by cache name.
+var metrics = expvar.NewMap("cache")
+
+type stats struct {
+	hits          expvar.Int
+	misses        expvar.Int
+	evictions     expvar.Int
+	invalidations expvar.Int
+}
+
+// LRU is a bounded map whose entries expire after a fixed TTL. When full, the
+// least recently used entry makes room for a new one. It is safe for
+// concurrent use. A capacity of zero or less disables caching: Set is a no-op
+// and every Get is a miss.
+type LRU[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	stats    *stats
+
+	mu    sync.Mutex
+	items map[K]*list.Element
+	order *list.List // front is most recently used
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// NewLRU creates a cache and publishes its hit, miss, eviction and
+// invalidation counters under name.
+func NewLRU[K comparable, V any](name string, capacity int, ttl time.Duration) *LRU[K, V] {
+	c := &LRU[K, V]{
+		capacity: capacity,
+		ttl:      ttl,
+		stats:    &stats{},
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+
+	m := new(expvar.Map).Init()
+	m.Set("hits", &c.stats.hits)
+	m.Set("misses", &c.stats.misses)
+	m.Set("evictions", &c.stats.evictions)
+	m.Set("invalidations", &c.stats.invalidations)
+	m.Set("size", expvar.Func(func() any { return c.Len() }))
+	metrics.Set(name, m)
+
+	return c
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.stats.misses.Add(1)
+		return zero, false
+	}
+
+	e := elem.Value.(*entry[K, V])
+	if time.Now().After(e.expiresAt) {
+		c.removeElement(elem)
+		c.stats.misses.Add(1)
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.stats.hits.Add(1)
+	return e.value, true
+}
+
+func (c *LRU[K, V]) Set(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+		c.stats.evictions.Add(1)
+	}
+}
+
+// Delete removes key and counts it as an invalidation.
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+		c.stats.invalidations.Add(1)
+	}
+}
+
+// DeleteFunc removes every entry for which match returns true.
+func (c *LRU[K, V]) DeleteFunc(match func(key K, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		e := elem.Value.(*entry[K, V])
+		if match(e.key, e.value) {
+			c.removeElement(elem)
+			c.stats.invalidations.Add(1)
+		}
+		elem = next
+	}
+}
+
+// Purge removes every entry.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.invalidations.Add(int64(c.order.Len()))
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry[K, V]).key)
+}
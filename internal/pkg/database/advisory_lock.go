@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+)
+
+// AdvisoryLocker hands out Postgres session-level advisory locks. Each lock
+// pins its own pooled connection until it is released, because advisory
+// locks belong to the connection that took them.
+type AdvisoryLocker struct {
+	db *Database
+}
+
+func NewAdvisoryLocker(db *Database) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+// TryLock attempts to take the advisory lock identified by name without
+// blocking. When the lock is held elsewhere it returns acquired=false and a nil
+// release function.
+func (l *AdvisoryLocker) TryLock(ctx context.Context, name string) (func() error, bool, error) {
+	sqlDB, err := l.db.DB().DB()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+
+	key := AdvisoryLockKey(name)
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("failed to acquire advisory lock %q: %w", name, err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() error {
+		defer conn.Close()
+		// The caller's context may already be cancelled at this point, and the
+		// lock must be released regardless.
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			return fmt.Errorf("failed to release advisory lock %q: %w", name, err)
+		}
+		return nil
+	}
+
+	return release, true, nil
+}
+
+// AdvisoryLockKey maps a lock name onto the bigint key space used by
+// pg_advisory_lock.
+func AdvisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
@@ -63,6 +63,11 @@ func New(config Config) (*Database, error) {
 	return &Database{db: db}, nil
 }
 
+// NewFromGorm wraps an already open gorm connection.
+func NewFromGorm(db *gorm.DB) *Database {
+	return &Database{db: db}
+}
+
 func (d *Database) DB() *gorm.DB {
 	return d.db
 }
@@ -87,23 +92,22 @@ func (d *Database) WithContext(ctx context.Context) *gorm.DB {
 	return d.db.WithContext(ctx)
 }
 
-func (d *Database) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
-	return d.db.WithContext(ctx).Transaction(fn)
-}
-
-func (d *Database) TransactionWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(*gorm.DB) error) error {
-	return d.db.WithContext(ctx).Transaction(fn, opts)
+// Conn returns the transaction carried by ctx, or the connection pool when
+// there is none. Repositories query through it so that their work joins a
+// transaction started by TransactionManager.ExecuteInTransaction.
+func (d *Database) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return d.db.WithContext(ctx)
 }
 
-func containsSubstring(s, substr string) bool {
-	return len(s) >= len(substr) && indexOfSubstring(s, substr) >= 0
+// Transaction runs fn in a transaction. Inside an ambient transaction it runs
+// in a savepoint instead, and the outer transaction decides the outcome.
+func (d *Database) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	return d.Conn(ctx).Transaction(fn)
 }
 
-func indexOfSubstring(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
-	}
-	return -1
+func (d *Database) TransactionWithOptions(ctx context.Context, opts *sql.TxOptions, fn func(*gorm.DB) error) error {
+	return d.Conn(ctx).Transaction(fn, opts)
 }
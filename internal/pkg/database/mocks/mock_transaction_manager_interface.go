@@ -118,6 +118,54 @@ func (_c *MockTransactionManagerInterface_ExecuteInTransaction_Call) RunAndRetur
 	return _c
 }
 
+// ExecuteInTransactionWithRetry provides a mock function with given fields: ctx, opts, fn
+func (_m *MockTransactionManagerInterface) ExecuteInTransactionWithRetry(ctx context.Context, opts database.RetryOptions, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteInTransactionWithRetry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.RetryOptions, func(context.Context) error) error); ok {
+		r0 = rf(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteInTransactionWithRetry'
+type MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call struct {
+	*mock.Call
+}
+
+// ExecuteInTransactionWithRetry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts database.RetryOptions
+//   - fn func(context.Context) error
+func (_e *MockTransactionManagerInterface_Expecter) ExecuteInTransactionWithRetry(ctx interface{}, opts interface{}, fn interface{}) *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call {
+	return &MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call{Call: _e.mock.On("ExecuteInTransactionWithRetry", ctx, opts, fn)}
+}
+
+func (_c *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call) Run(run func(ctx context.Context, opts database.RetryOptions, fn func(context.Context) error)) *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.RetryOptions), args[2].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call) Return(_a0 error) *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call) RunAndReturn(run func(context.Context, database.RetryOptions, func(context.Context) error) error) *MockTransactionManagerInterface_ExecuteInTransactionWithRetry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ExecuteWithOptions provides a mock function with given fields: ctx, opts, fn
 func (_m *MockTransactionManagerInterface) ExecuteWithOptions(ctx context.Context, opts *database.TransactionOptions, fn func(*gorm.DB) error) error {
 	ret := _m.Called(ctx, opts, fn)
@@ -309,17 +357,17 @@ func (_c *MockTransactionManagerInterface_NewUnitOfWork_Call) RunAndReturn(run f
 	return _c
 }
 
-// RetryableTransaction provides a mock function with given fields: ctx, maxRetries, fn
-func (_m *MockTransactionManagerInterface) RetryableTransaction(ctx context.Context, maxRetries int, fn func(*gorm.DB) error) error {
-	ret := _m.Called(ctx, maxRetries, fn)
+// RetryableTransaction provides a mock function with given fields: ctx, opts, fn
+func (_m *MockTransactionManagerInterface) RetryableTransaction(ctx context.Context, opts database.RetryOptions, fn func(*gorm.DB) error) error {
+	ret := _m.Called(ctx, opts, fn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RetryableTransaction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, func(*gorm.DB) error) error); ok {
-		r0 = rf(ctx, maxRetries, fn)
+	if rf, ok := ret.Get(0).(func(context.Context, database.RetryOptions, func(*gorm.DB) error) error); ok {
+		r0 = rf(ctx, opts, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -334,15 +382,15 @@ type MockTransactionManagerInterface_RetryableTransaction_Call struct {
 
 // RetryableTransaction is a helper method to define mock.On call
 //   - ctx context.Context
-//   - maxRetries int
+//   - opts database.RetryOptions
 //   - fn func(*gorm.DB) error
-func (_e *MockTransactionManagerInterface_Expecter) RetryableTransaction(ctx interface{}, maxRetries interface{}, fn interface{}) *MockTransactionManagerInterface_RetryableTransaction_Call {
-	return &MockTransactionManagerInterface_RetryableTransaction_Call{Call: _e.mock.On("RetryableTransaction", ctx, maxRetries, fn)}
+func (_e *MockTransactionManagerInterface_Expecter) RetryableTransaction(ctx interface{}, opts interface{}, fn interface{}) *MockTransactionManagerInterface_RetryableTransaction_Call {
+	return &MockTransactionManagerInterface_RetryableTransaction_Call{Call: _e.mock.On("RetryableTransaction", ctx, opts, fn)}
 }
 
-func (_c *MockTransactionManagerInterface_RetryableTransaction_Call) Run(run func(ctx context.Context, maxRetries int, fn func(*gorm.DB) error)) *MockTransactionManagerInterface_RetryableTransaction_Call {
+func (_c *MockTransactionManagerInterface_RetryableTransaction_Call) Run(run func(ctx context.Context, opts database.RetryOptions, fn func(*gorm.DB) error)) *MockTransactionManagerInterface_RetryableTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int), args[2].(func(*gorm.DB) error))
+		run(args[0].(context.Context), args[1].(database.RetryOptions), args[2].(func(*gorm.DB) error))
 	})
 	return _c
 }
@@ -352,7 +400,7 @@ func (_c *MockTransactionManagerInterface_RetryableTransaction_Call) Return(_a0
 	return _c
 }
 
-func (_c *MockTransactionManagerInterface_RetryableTransaction_Call) RunAndReturn(run func(context.Context, int, func(*gorm.DB) error) error) *MockTransactionManagerInterface_RetryableTransaction_Call {
+func (_c *MockTransactionManagerInterface_RetryableTransaction_Call) RunAndReturn(run func(context.Context, database.RetryOptions, func(*gorm.DB) error) error) *MockTransactionManagerInterface_RetryableTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+var ErrNotifyUnsupported = errors.New("database driver does not support LISTEN/NOTIFY")
+
+const (
+	listenRetryMin = time.Second
+	listenRetryMax = time.Minute
+)
+
+// Notifier publishes and receives Postgres NOTIFY messages on a single
+// channel, so that replicas can tell each other about their writes.
+type Notifier struct {
+	db      *Database
+	channel string
+}
+
+func NewNotifier(db *Database, channel string) *Notifier {
+	return &Notifier{db: db, channel: channel}
+}
+
+// Notify sends payload to every listener on the channel, including this
+// process. Inside a transaction carried by ctx, Postgres delivers it only once
+// that transaction commits; a failure to notify does not abort it.
+func (n *Notifier) Notify(ctx context.Context, payload string) error {
+	err := n.db.Transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Exec("SELECT pg_notify(?, ?)", n.channel, payload).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to notify %q: %w", n.channel, err)
+	}
+	return nil
+}
+
+// Listen blocks until ctx is cancelled, calling onMessage for each
+// notification. It holds one pooled connection while listening and reconnects
+// with backoff when that connection fails. onListen is called whenever
+// listening (re)starts: anything sent while the connection was down is lost,
+// so callers should drop state that depends on notifications.
+func (n *Notifier) Listen(ctx context.Context, onListen func(), onMessage func(payload string)) {
+	retry := listenRetryMin
+
+	for {
+		err := n.listen(ctx, func() {
+			retry = listenRetryMin
+			onListen()
+		}, onMessage)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("listener on %q failed, retrying in %s: %v", n.channel, retry, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+
+		retry = min(retry*2, listenRetryMax)
+	}
+}
+
+func (n *Notifier) listen(ctx context.Context, onListen func(), onMessage func(payload string)) error {
+	sqlDB, err := n.db.DB().DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for listener: %w", err)
+	}
+	defer conn.Close()
+
+	var listenErr error
+	_ = conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = ErrNotifyUnsupported
+			return nil
+		}
+		pgConn := stdConn.Conn()
+
+		if _, err := pgConn.Exec(ctx, "LISTEN "+pgx.Identifier{n.channel}.Sanitize()); err != nil {
+			listenErr = err
+			return driver.ErrBadConn
+		}
+		onListen()
+
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				listenErr = err
+				// The connection is still subscribed; discard it rather than
+				// returning it to the pool.
+				return driver.ErrBadConn
+			}
+			onMessage(notification.Payload)
+		}
+	})
+
+	return listenErr
+}
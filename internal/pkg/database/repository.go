@@ -45,7 +45,7 @@ func NewBaseRepository[T any](db *Database) *BaseRepository[T] {
 }
 
 func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
-	return r.db.WithContext(ctx).Create(entity).Error
+	return r.db.Conn(ctx).Create(entity).Error
 }
 
 func (r *BaseRepository[T]) CreateInTx(tx *gorm.DB, entity *T) error {
@@ -54,7 +54,7 @@ func (r *BaseRepository[T]) CreateInTx(tx *gorm.DB, entity *T) error {
 
 func (r *BaseRepository[T]) GetByID(ctx context.Context, id interface{}) (*T, error) {
 	var entity T
-	err := r.db.WithContext(ctx).First(&entity, id).Error
+	err := r.db.Conn(ctx).First(&entity, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -77,7 +77,7 @@ func (r *BaseRepository[T]) GetByIDInTx(tx *gorm.DB, id interface{}) (*T, error)
 }
 
 func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) error {
-	return r.db.WithContext(ctx).Save(entity).Error
+	return r.db.Conn(ctx).Save(entity).Error
 }
 
 func (r *BaseRepository[T]) UpdateInTx(tx *gorm.DB, entity *T) error {
@@ -85,7 +85,7 @@ func (r *BaseRepository[T]) UpdateInTx(tx *gorm.DB, entity *T) error {
 }
 
 func (r *BaseRepository[T]) Delete(ctx context.Context, id interface{}) error {
-	return r.db.WithContext(ctx).Delete(&r.model, id).Error
+	return r.db.Conn(ctx).Delete(&r.model, id).Error
 }
 
 func (r *BaseRepository[T]) DeleteInTx(tx *gorm.DB, id interface{}) error {
@@ -93,7 +93,7 @@ func (r *BaseRepository[T]) DeleteInTx(tx *gorm.DB, id interface{}) error {
 }
 
 func (r *BaseRepository[T]) List(ctx context.Context, query *Query) ([]*T, error) {
-	return r.ListInTx(r.db.WithContext(ctx), query)
+	return r.ListInTx(r.db.Conn(ctx), query)
 }
 
 func (r *BaseRepository[T]) ListInTx(tx *gorm.DB, query *Query) ([]*T, error) {
@@ -109,7 +109,7 @@ func (r *BaseRepository[T]) ListInTx(tx *gorm.DB, query *Query) ([]*T, error) {
 }
 
 func (r *BaseRepository[T]) Count(ctx context.Context, query *Query) (int64, error) {
-	return r.CountInTx(r.db.WithContext(ctx), query)
+	return r.CountInTx(r.db.Conn(ctx), query)
 }
 
 func (r *BaseRepository[T]) CountInTx(tx *gorm.DB, query *Query) (int64, error) {
@@ -125,7 +125,7 @@ func (r *BaseRepository[T]) CountInTx(tx *gorm.DB, query *Query) (int64, error)
 }
 
 func (r *BaseRepository[T]) Exists(ctx context.Context, id interface{}) (bool, error) {
-	return r.ExistsInTx(r.db.WithContext(ctx), id)
+	return r.ExistsInTx(r.db.Conn(ctx), id)
 }
 
 func (r *BaseRepository[T]) ExistsInTx(tx *gorm.DB, id interface{}) (bool, error) {
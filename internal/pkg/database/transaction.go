@@ -3,8 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
@@ -16,11 +21,73 @@ func NewTransactionManager(db *Database) *TransactionManager {
 	return &TransactionManager{db: db}
 }
 
+// txContextKey is the context key ExecuteInTransaction stores its
+// transaction under.
+type txContextKey struct{}
+
+// ContextWithTx returns a copy of ctx carrying tx. Repositories given the
+// returned context run their queries in tx.
+func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// afterCommitContextKey is the context key of the hooks registered with
+// AfterCommit.
+type afterCommitContextKey struct{}
+
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// AfterCommit runs fn once the transaction started by ExecuteInTransaction and
+// carried by ctx has committed, or right away when ctx carries none. Hooks of
+// a transaction that rolls back are dropped. Hooks registered in a nested
+// transaction wait for the outermost one.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitContextKey{}).(*afterCommitHooks)
+	if !ok {
+		fn()
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
+}
+
+// ExecuteInTransaction runs fn with a context carrying a new transaction, which
+// commits when fn returns nil and rolls back otherwise. Called inside another
+// transaction it uses a savepoint.
 func (tm *TransactionManager) ExecuteInTransaction(ctx context.Context, fn func(context.Context) error) error {
-	return tm.db.Transaction(ctx, func(tx *gorm.DB) error {
-		txCtx := context.WithValue(ctx, "tx", tx)
-		return fn(txCtx)
+	if _, ok := ctx.Value(afterCommitContextKey{}).(*afterCommitHooks); ok {
+		return tm.db.Transaction(ctx, func(tx *gorm.DB) error {
+			return fn(ContextWithTx(ctx, tx))
+		})
+	}
+
+	hooks := &afterCommitHooks{}
+	hooksCtx := context.WithValue(ctx, afterCommitContextKey{}, hooks)
+	err := tm.db.Transaction(hooksCtx, func(tx *gorm.DB) error {
+		return fn(ContextWithTx(hooksCtx, tx))
 	})
+	if err != nil {
+		return err
+	}
+
+	hooks.mu.Lock()
+	fns := hooks.fns
+	hooks.mu.Unlock()
+	for _, hook := range fns {
+		hook()
+	}
+	return nil
 }
 
 type UnitOfWork struct {
@@ -120,8 +187,10 @@ func (tm *TransactionManager) ExecuteWithOptions(ctx context.Context, opts *Tran
 	return tm.db.TransactionWithOptions(ctx, sqlOpts, fn)
 }
 
+// Batch runs operations batchSize at a time, each group in its own
+// transaction, so a failure keeps the groups committed before it.
 type Batch struct {
-	operations []func(*gorm.DB) error
+	operations []func(*gorm.DB) (int64, error)
 	batchSize  int
 }
 
@@ -130,18 +199,33 @@ func (tm *TransactionManager) NewBatch(batchSize int) *Batch {
 		batchSize = 100
 	}
 	return &Batch{
-		operations: make([]func(*gorm.DB) error, 0),
+		operations: make([]func(*gorm.DB) (int64, error), 0),
 		batchSize:  batchSize,
 	}
 }
 
+// Add queues an operation that affects one row.
 func (b *Batch) Add(op func(*gorm.DB) error) {
+	b.AddCounted(func(tx *gorm.DB) (int64, error) {
+		if err := op(tx); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	})
+}
+
+// AddCounted queues an operation that reports how many rows it affected,
+// such as a single statement covering many rows.
+func (b *Batch) AddCounted(op func(*gorm.DB) (int64, error)) {
 	b.operations = append(b.operations, op)
 }
 
-func (b *Batch) Execute(ctx context.Context, tm *TransactionManager) error {
+// Execute returns the rows affected by the committed groups, also when a
+// later group fails.
+func (b *Batch) Execute(ctx context.Context, tm *TransactionManager) (int64, error) {
+	var affected int64
 	if len(b.operations) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	for i := 0; i < len(b.operations); i += b.batchSize {
@@ -152,21 +236,26 @@ func (b *Batch) Execute(ctx context.Context, tm *TransactionManager) error {
 
 		batch := b.operations[i:end]
 
+		var batchAffected int64
 		err := tm.Execute(ctx, func(uow *UnitOfWork) error {
+			batchAffected = 0
 			for _, op := range batch {
-				if err := op(uow.GetTx()); err != nil {
+				n, err := op(uow.GetTx())
+				if err != nil {
 					return err
 				}
+				batchAffected += n
 			}
 			return nil
 		})
 
 		if err != nil {
-			return fmt.Errorf("batch execution failed at index %d: %w", i, err)
+			return affected, fmt.Errorf("batch execution failed at index %d: %w", i, err)
 		}
+		affected += batchAffected
 	}
 
-	return nil
+	return affected, nil
 }
 
 type Saga struct {
@@ -220,55 +309,110 @@ func (s *Saga) Execute(ctx context.Context, tm *TransactionManager) error {
 	return nil
 }
 
-func (tm *TransactionManager) RetryableTransaction(ctx context.Context, maxRetries int, fn func(*gorm.DB) error) error {
-	var lastErr error
+// RetryOptions controls how a failed transaction is retried. The delay before
+// attempt n+1 is BaseDelay doubled n-1 times, capped at MaxDelay, with jitter.
+type RetryOptions struct {
+	// MaxAttempts counts the first attempt too.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		err := tm.db.Transaction(ctx, fn)
-		if err == nil {
-			return nil
-		}
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxAttempts: 3,
+		BaseDelay:   50 * time.Millisecond,
+		MaxDelay:    time.Second,
+	}
+}
 
-		if !isRetryableError(err) {
-			return err
-		}
+// RetryableTransaction runs fn in a transaction and runs it again in a new one
+// when Postgres aborts it with a serialization failure, a deadlock or a lock
+// timeout. fn must not have side effects outside the transaction.
+func (tm *TransactionManager) RetryableTransaction(ctx context.Context, opts RetryOptions, fn func(*gorm.DB) error) error {
+	return retry(ctx, opts, func() error {
+		return tm.db.Transaction(ctx, fn)
+	})
+}
+
+// ExecuteInTransactionWithRetry is ExecuteInTransaction with the retries of
+// RetryableTransaction. Inside an ambient transaction it runs once, because a
+// failure there aborts the outer transaction as well.
+func (tm *TransactionManager) ExecuteInTransactionWithRetry(ctx context.Context, opts RetryOptions, fn func(context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return tm.ExecuteInTransaction(ctx, fn)
+	}
 
-		lastErr = err
+	return retry(ctx, opts, func() error {
+		return tm.ExecuteInTransaction(ctx, fn)
+	})
+}
+
+func retry(ctx context.Context, opts RetryOptions, run func() error) error {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
 
-		if attempt == maxRetries {
+	var err error
+	for attempt := 1; attempt <= opts.MaxAttempts; attempt++ {
+		err = run()
+		if err == nil || !IsRetryableError(err) {
+			return err
+		}
+		if attempt == opts.MaxAttempts {
 			break
 		}
 
+		timer := time.NewTimer(opts.backoff(attempt))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return ctx.Err()
-		default:
+		case <-timer.C:
 		}
 	}
 
-	return fmt.Errorf("transaction failed after %d retries: %w", maxRetries, lastErr)
+	return fmt.Errorf("transaction failed after %d attempts: %w", opts.MaxAttempts, err)
 }
 
-func isRetryableError(err error) bool {
-	if err == nil {
-		return false
+// backoff returns the delay after the given failed attempt, picked at random
+// from the upper half of the exponential delay so that competing
+// transactions spread out.
+func (opts RetryOptions) backoff(attempt int) time.Duration {
+	delay := opts.BaseDelay
+	for i := 1; i < attempt; i++ {
+		if opts.MaxDelay > 0 && delay >= opts.MaxDelay {
+			break
+		}
+		delay *= 2
 	}
-
-	errStr := err.Error()
-	retryablePatterns := []string{
-		"deadlock",
-		"serialization failure",
-		"could not serialize access",
-		"retry transaction",
+	if opts.MaxDelay > 0 && delay > opts.MaxDelay {
+		delay = opts.MaxDelay
 	}
-
-	for _, pattern := range retryablePatterns {
-		if containsSubstring(errStr, pattern) {
-			return true
-		}
+	if delay <= 0 {
+		return 0
 	}
 
-	return false
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// retryableSQLStates are the Postgres errors after which the same transaction
+// may succeed when run again.
+var retryableSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"55P03": true, // lock_not_available
+}
+
+// IsRetryableError reports whether err carries a Postgres error whose
+// transaction is worth running again.
+func IsRetryableError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return retryableSQLStates[pgErr.Code]
 }
 
 type TransactionContext struct {
@@ -283,7 +427,7 @@ func (tm *TransactionManager) WithTransaction(ctx context.Context) (*Transaction
 	}
 
 	txCtx := &TransactionContext{
-		ctx: ctx,
+		ctx: ContextWithTx(ctx, tx),
 		tx:  tx,
 	}
 
@@ -0,0 +1,208 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"beerdosan-backend/internal/pkg/database"
+)
+
+func newMockTransactionManager(t *testing.T) (*database.TransactionManager, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	require.NoError(t, err)
+
+	return database.NewTransactionManager(database.NewFromGorm(db)), mock
+}
+
+func fastRetries(attempts int) database.RetryOptions {
+	return database.RetryOptions{MaxAttempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"lock not available", &pgconn.PgError{Code: "55P03"}, true},
+		{"wrapped", fmt.Errorf("save: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"message only", errors.New("deadlock detected"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, database.IsRetryableError(tt.err))
+		})
+	}
+}
+
+func TestExecuteInTransactionWithRetryRetriesSerializationFailures(t *testing.T) {
+	// Arrange
+	tm, mock := newMockTransactionManager(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	attempts := 0
+
+	// Act
+	err := tm.ExecuteInTransactionWithRetry(context.Background(), fastRetries(3), func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecuteInTransactionWithRetryStopsOnOtherErrors(t *testing.T) {
+	// Arrange
+	tm, mock := newMockTransactionManager(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	errFailed := errors.New("failed")
+	attempts := 0
+
+	// Act
+	err := tm.ExecuteInTransactionWithRetry(context.Background(), fastRetries(3), func(ctx context.Context) error {
+		attempts++
+		return errFailed
+	})
+
+	// Assert
+	assert.Equal(t, errFailed, err)
+	assert.Equal(t, 1, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryableTransactionGivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	tm, mock := newMockTransactionManager(t)
+	for range 2 {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+
+	deadlock := &pgconn.PgError{Code: "40P01"}
+
+	// Act
+	err := tm.RetryableTransaction(context.Background(), fastRetries(2), func(tx *gorm.DB) error {
+		return deadlock
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, deadlock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRetryableTransactionStopsWhenContextIsCancelled(t *testing.T) {
+	// Arrange
+	tm, mock := newMockTransactionManager(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := database.RetryOptions{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	// Act
+	err := tm.RetryableTransaction(ctx, opts, func(tx *gorm.DB) error {
+		cancel()
+		return &pgconn.PgError{Code: "40001"}
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAfterCommitRunsOnlyOnceCommitted(t *testing.T) {
+	// Arrange
+	tm, mock := newMockTransactionManager(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	var ran []string
+
+	// Act
+	err := tm.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { ran = append(ran, "committed") })
+		assert.Empty(t, ran)
+		return nil
+	})
+	require.NoError(t, err)
+
+	errFailed := errors.New("failed")
+	err = tm.ExecuteInTransaction(context.Background(), func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { ran = append(ran, "rolled back") })
+		return errFailed
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errFailed)
+	assert.Equal(t, []string{"committed"}, ran)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAfterCommitWithoutTransactionRunsImmediately(t *testing.T) {
+	ran := false
+
+	database.AfterCommit(context.Background(), func() { ran = true })
+
+	assert.True(t, ran)
+}
+
+func TestBatchExecuteReportsRowsOfCommittedGroups(t *testing.T) {
+	// Arrange
+	tm, mock := newMockTransactionManager(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	errFailed := errors.New("failed")
+	batch := tm.NewBatch(2)
+	batch.AddCounted(func(tx *gorm.DB) (int64, error) { return 3, nil })
+	batch.Add(func(tx *gorm.DB) error { return nil })
+	batch.AddCounted(func(tx *gorm.DB) (int64, error) { return 5, nil })
+	batch.Add(func(tx *gorm.DB) error { return errFailed })
+
+	// Act
+	affected, err := batch.Execute(context.Background(), tm)
+
+	// Assert
+	assert.ErrorIs(t, err, errFailed)
+	assert.Equal(t, int64(4), affected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
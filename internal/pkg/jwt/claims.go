@@ -0,0 +1,100 @@
+package jwt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// ClaimsVersion is written to the ver claim of every issued token.
+	ClaimsVersion = 2
+	// LegacyClaimsVersion marks claims decoded from a token that predates the
+	// ver claim.
+	LegacyClaimsVersion = 1
+)
+
+// JWTClaims is the version 2 claims schema. The user is identified by the
+// standard sub claim and the session by sid, both as UUIDs.
+type JWTClaims struct {
+	Version   int    `json:"ver"`
+	SessionID string `json:"sid"`
+	Role      string `json:"role,omitempty"`
+	// Scope is a space separated list of scopes, as in RFC 8693.
+	Scope     string `json:"scope,omitempty"`
+	TokenType string `json:"token_type"`
+	// AuthTime is when the user last proved who they are, as in OpenID
+	// Connect. It survives token refreshes and is absent from tokens issued
+	// before the claim existed.
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *JWTClaims) UserID() string {
+	return c.Subject
+}
+
+func (c *JWTClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// AuthenticatedAt returns the auth_time claim, or the zero time when the token
+// does not carry one.
+func (c *JWTClaims) AuthenticatedAt() time.Time {
+	if c.AuthTime == nil {
+		return time.Time{}
+	}
+	return c.AuthTime.Time
+}
+
+// IsLegacy reports whether the claims were read from a version 1 token, which
+// carries neither a role nor scopes.
+func (c *JWTClaims) IsLegacy() bool {
+	return c.Version == LegacyClaimsVersion
+}
+
+// tokenClaims is what tokens are decoded into: the current schema plus the
+// version 1 fields, which carried the user UUID in username and the session
+// UUID in fingerprint (access tokens) or email (refresh tokens). The numeric
+// user_id and session_id of that schema were hashes and are ignored.
+type tokenClaims struct {
+	JWTClaims
+	LegacyUsername    string `json:"username,omitempty"`
+	LegacyEmail       string `json:"email,omitempty"`
+	LegacyFingerprint string `json:"fingerprint,omitempty"`
+}
+
+// normalize maps either schema onto JWTClaims, rejecting unknown versions,
+// claims that do not identify a user and session, and version 1 tokens unless
+// acceptLegacy is set.
+func (c *tokenClaims) normalize(acceptLegacy bool) (*JWTClaims, error) {
+	claims := c.JWTClaims
+
+	switch claims.Version {
+	case ClaimsVersion:
+	case 0:
+		if !acceptLegacy {
+			return nil, ErrInvalidToken
+		}
+
+		sessionID := c.LegacyFingerprint
+		if sessionID == "" {
+			sessionID = c.LegacyEmail
+		}
+
+		claims.Version = LegacyClaimsVersion
+		claims.Subject = c.LegacyUsername
+		claims.SessionID = sessionID
+		claims.Role = ""
+		claims.Scope = ""
+	default:
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Subject == "" || claims.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,205 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/pkg/jwt"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID    = "8a7c1a52-2d7e-4c59-9a0e-3f1d0c4b6e21"
+	testSessionID = "c3f4e5d6-1b2a-4f8e-9d7c-6b5a4e3d2c1f"
+)
+
+func newTestService(t *testing.T, acceptLegacy bool) (jwt.JWTService, *jwt.JWTConfig) {
+	t.Helper()
+
+	cfg, err := jwt.GenerateJWTConfig(jwt.AlgorithmHS256)
+	require.NoError(t, err)
+	cfg.AcceptLegacyClaims = acceptLegacy
+
+	return jwt.NewJWTService(cfg), cfg
+}
+
+// signLegacy signs claims in the version 1 shape, as issued before the ver
+// claim existed.
+func signLegacy(t *testing.T, cfg *jwt.JWTConfig, tokenType jwt.TokenType, sessionField string) string {
+	t.Helper()
+
+	now := time.Now()
+	claims := gojwt.MapClaims{
+		"user_id":    int64(7423191023),
+		"username":   testUserID,
+		"email":      "",
+		"token_type": string(tokenType),
+		"session_id": int64(-9121843),
+		sessionField: testSessionID,
+		"iss":        cfg.Issuer,
+		"sub":        "7423191023",
+		"aud":        []string{cfg.Audience},
+		"exp":        now.Add(time.Hour).Unix(),
+		"nbf":        now.Unix(),
+		"iat":        now.Unix(),
+	}
+
+	token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString(cfg.PrivateKey)
+	require.NoError(t, err)
+	return token
+}
+
+func TestVersionedClaims(t *testing.T) {
+	// Arrange
+	service, _ := newTestService(t, true)
+
+	// Act
+	token, _, err := service.GenerateAccessToken(testUserID, testSessionID, "admin", time.Time{}, []string{"full_access", "profile"})
+	require.NoError(t, err)
+	claims, err := service.ValidateAccessToken(token.String())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, jwt.ClaimsVersion, claims.Version)
+	assert.False(t, claims.IsLegacy())
+	assert.Equal(t, testUserID, claims.UserID())
+	assert.Equal(t, testSessionID, claims.SessionID)
+	assert.Equal(t, "admin", claims.Role)
+	assert.Equal(t, []string{"full_access", "profile"}, claims.Scopes())
+	assert.NotEmpty(t, claims.ID)
+
+	raw := gojwt.MapClaims{}
+	_, _, err = gojwt.NewParser().ParseUnverified(token.String(), raw)
+	require.NoError(t, err)
+	assert.Equal(t, testUserID, raw["sub"])
+	assert.Equal(t, testSessionID, raw["sid"])
+	assert.Equal(t, "full_access profile", raw["scope"])
+	assert.EqualValues(t, jwt.ClaimsVersion, raw["ver"])
+	assert.NotContains(t, raw, "username")
+	assert.NotContains(t, raw, "fingerprint")
+}
+
+func TestRefreshAccessTokenKeepsRoleAndSession(t *testing.T) {
+	service, _ := newTestService(t, true)
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	refreshToken, _, err := service.GenerateRefreshToken(testUserID, testSessionID, "user", authTime, true)
+	require.NoError(t, err)
+
+	accessToken, _, err := service.RefreshAccessToken(refreshToken.String())
+	require.NoError(t, err)
+	claims, err := service.ValidateAccessToken(accessToken.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, testUserID, claims.UserID())
+	assert.Equal(t, testSessionID, claims.SessionID)
+	assert.Equal(t, "user", claims.Role)
+	assert.True(t, authTime.Equal(claims.AuthenticatedAt()))
+}
+
+func TestAuthTimeClaim(t *testing.T) {
+	service, _ := newTestService(t, true)
+	authTime := time.Now().Truncate(time.Second)
+
+	withAuthTime, _, err := service.GenerateAccessToken(testUserID, testSessionID, "user", authTime, nil)
+	require.NoError(t, err)
+	withoutAuthTime, _, err := service.GenerateAccessToken(testUserID, testSessionID, "user", time.Time{}, nil)
+	require.NoError(t, err)
+
+	raw := gojwt.MapClaims{}
+	_, _, err = gojwt.NewParser().ParseUnverified(withAuthTime.String(), raw)
+	require.NoError(t, err)
+	assert.EqualValues(t, authTime.Unix(), raw["auth_time"])
+
+	claims, err := service.ValidateAccessToken(withoutAuthTime.String())
+	require.NoError(t, err)
+	assert.Nil(t, claims.AuthTime)
+	assert.True(t, claims.AuthenticatedAt().IsZero())
+}
+
+func TestLegacyClaims(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tokenType    jwt.TokenType
+		sessionField string
+	}{
+		{"access token carries the session in fingerprint", jwt.TokenTypeAccess, "fingerprint"},
+		{"refresh token carries the session in email", jwt.TokenTypeRefresh, "email"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service, cfg := newTestService(t, true)
+			token := signLegacy(t, cfg, tc.tokenType, tc.sessionField)
+
+			// Act
+			claims, err := service.ValidateToken(token)
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, claims.IsLegacy())
+			assert.Equal(t, testUserID, claims.UserID())
+			assert.Equal(t, testSessionID, claims.SessionID)
+			assert.Equal(t, string(tc.tokenType), claims.TokenType)
+			assert.Empty(t, claims.Role)
+			assert.Empty(t, claims.Scopes())
+		})
+	}
+}
+
+func TestLegacyClaimsRejectedAfterMigrationWindow(t *testing.T) {
+	service, cfg := newTestService(t, false)
+	token := signLegacy(t, cfg, jwt.TokenTypeAccess, "fingerprint")
+
+	_, err := service.ValidateToken(token)
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+
+	_, err = service.GetTokenClaims(token)
+	assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+}
+
+func TestInvalidVersionedClaims(t *testing.T) {
+	testCases := []struct {
+		name   string
+		claims jwt.JWTClaims
+	}{
+		{
+			name: "unknown version",
+			claims: jwt.JWTClaims{
+				Version:          jwt.ClaimsVersion + 1,
+				SessionID:        testSessionID,
+				RegisteredClaims: gojwt.RegisteredClaims{Subject: testUserID},
+			},
+		},
+		{
+			name: "missing sid",
+			claims: jwt.JWTClaims{
+				Version:          jwt.ClaimsVersion,
+				RegisteredClaims: gojwt.RegisteredClaims{Subject: testUserID},
+			},
+		},
+		{
+			name: "missing sub",
+			claims: jwt.JWTClaims{
+				Version:   jwt.ClaimsVersion,
+				SessionID: testSessionID,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, cfg := newTestService(t, true)
+			tc.claims.ExpiresAt = gojwt.NewNumericDate(time.Now().Add(time.Hour))
+			token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &tc.claims).SignedString(cfg.PrivateKey)
+			require.NoError(t, err)
+
+			_, err = service.ValidateToken(token)
+
+			assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+		})
+	}
+}
@@ -1,15 +1,14 @@
 package jwt
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
+	"crypto"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
@@ -33,94 +32,132 @@ const (
 	TokenTypeRefresh TokenType = "refresh"
 )
 
-type JWTClaims struct {
-	UserID      int64  `json:"user_id"`
-	Username    string `json:"username"`
-	Email       string `json:"email"`
-	TokenType   string `json:"token_type"`
-	SessionID   int64  `json:"session_id,omitempty"`
-	Fingerprint string `json:"fingerprint,omitempty"`
-	jwt.RegisteredClaims
+type JWTConfig struct {
+	// Algorithm must match the key type; when empty it is derived from
+	// PublicKey. See AlgorithmForKey.
+	Algorithm Algorithm
+	// PrivateKey signs tokens: *rsa.PrivateKey, *ecdsa.PrivateKey (P-256),
+	// ed25519.PrivateKey, or the shared secret as []byte for HS256.
+	PrivateKey crypto.PrivateKey
+	// PublicKey verifies tokens. For HS256 it is the same secret.
+	PublicKey           crypto.PublicKey
+	AccessTokenDuration time.Duration
+	// RefreshTokenDuration is the refresh lifetime of a regular login and
+	// RememberMeRefreshTokenDuration the one used when the user asked to be
+	// remembered.
+	RefreshTokenDuration           time.Duration
+	RememberMeRefreshTokenDuration time.Duration
+	Issuer                         string
+	Audience                       string
+	// AcceptLegacyClaims keeps tokens issued before the versioned claims
+	// schema valid. Turn it off once the longest refresh lifetime has passed
+	// since the upgrade.
+	AcceptLegacyClaims bool
 }
 
-type JWTConfig struct {
-	PrivateKey           *rsa.PrivateKey
-	PublicKey            *rsa.PublicKey
-	AccessTokenDuration  time.Duration
-	RefreshTokenDuration time.Duration
-	Issuer               string
-	Audience             string
+func (c *JWTConfig) RefreshDuration(rememberMe bool) time.Duration {
+	if rememberMe {
+		return c.RememberMeRefreshTokenDuration
+	}
+	return c.RefreshTokenDuration
 }
 
-func DefaultJWTConfig() (*JWTConfig, error) {
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+// Validate checks that both keys belong to the configured algorithm, filling
+// in Algorithm from the key type when it is empty.
+func (c *JWTConfig) Validate() error {
+	publicAlg, err := AlgorithmForKey(c.PublicKey)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("invalid public key: %w", err)
 	}
 
-	return &JWTConfig{
-		PrivateKey:           privateKey,
-		PublicKey:            &privateKey.PublicKey,
-		AccessTokenDuration:  15 * time.Minute,
-		RefreshTokenDuration: 7 * 24 * time.Hour, // 7 days
-		Issuer:               "beerdosan-backend",
-		Audience:             "venturex-app",
-	}, nil
-}
+	privateAlg, err := AlgorithmForKey(c.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
 
-func LoadJWTConfigFromPEM(privateKeyPEM, publicKeyPEM string) (*JWTConfig, error) {
-	privateBlock, _ := pem.Decode([]byte(privateKeyPEM))
-	if privateBlock == nil {
-		return nil, ErrInvalidKeyFormat
+	if privateAlg != publicAlg {
+		return fmt.Errorf("%w: private key is %s but public key is %s", ErrInvalidSigningKey, privateAlg, publicAlg)
 	}
 
-	privateKey, err := x509.ParsePKCS1PrivateKey(privateBlock.Bytes)
+	if c.Algorithm == "" {
+		c.Algorithm = publicAlg
+	}
+
+	if c.Algorithm != publicAlg {
+		return fmt.Errorf("%w: %s keys cannot be used with %s", ErrUnsupportedAlgorithm, publicAlg, c.Algorithm)
+	}
+
+	return nil
+}
+
+// DefaultJWTConfig returns an RS256 configuration with a freshly generated key.
+func DefaultJWTConfig() (*JWTConfig, error) {
+	return GenerateJWTConfig(AlgorithmRS256)
+}
+
+// GenerateJWTConfig returns a configuration for alg with a freshly generated
+// key. Tokens signed with it do not survive a restart.
+func GenerateJWTConfig(alg Algorithm) (*JWTConfig, error) {
+	privateKey, publicKey, err := GenerateKeyPairFor(alg)
 	if err != nil {
-		parsedKey, err := x509.ParsePKCS8PrivateKey(privateBlock.Bytes)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse private key: %w", err)
-		}
-		var ok bool
-		privateKey, ok = parsedKey.(*rsa.PrivateKey)
-		if !ok {
-			return nil, ErrInvalidKeyFormat
-		}
+		return nil, err
 	}
 
-	publicBlock, _ := pem.Decode([]byte(publicKeyPEM))
-	if publicBlock == nil {
-		return nil, ErrInvalidKeyFormat
+	return newJWTConfig(alg, privateKey, publicKey), nil
+}
+
+// LoadJWTConfigFromPEM builds a configuration for alg from PEM encoded keys.
+// publicKeyPEM may be empty, in which case the verification key is derived
+// from the private key. An empty alg is inferred from the key type.
+func LoadJWTConfigFromPEM(alg Algorithm, privateKeyPEM, publicKeyPEM string) (*JWTConfig, error) {
+	privateKey, err := parsePrivateKeyPEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
 	}
 
-	publicKeyInterface, err := x509.ParsePKIXPublicKey(publicBlock.Bytes)
+	var publicKey crypto.PublicKey
+	if publicKeyPEM != "" {
+		publicKey, err = parsePublicKeyPEM(publicKeyPEM)
+	} else {
+		publicKey, err = publicKeyOf(privateKey)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse public key: %w", err)
+		return nil, err
 	}
 
-	publicKey, ok := publicKeyInterface.(*rsa.PublicKey)
-	if !ok {
-		return nil, ErrInvalidKeyFormat
+	config := newJWTConfig(alg, privateKey, publicKey)
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
 
+	return config, nil
+}
+
+func newJWTConfig(alg Algorithm, privateKey crypto.PrivateKey, publicKey crypto.PublicKey) *JWTConfig {
 	return &JWTConfig{
-		PrivateKey:           privateKey,
-		PublicKey:            publicKey,
-		AccessTokenDuration:  15 * time.Minute,
-		RefreshTokenDuration: 7 * 24 * time.Hour,
-		Issuer:               "beerdosan-backend",
-		Audience:             "venturex-app",
-	}, nil
+		Algorithm:                      alg,
+		PrivateKey:                     privateKey,
+		PublicKey:                      publicKey,
+		AccessTokenDuration:            15 * time.Minute,
+		RefreshTokenDuration:           7 * 24 * time.Hour,  // 7 days
+		RememberMeRefreshTokenDuration: 30 * 24 * time.Hour, // 30 days
+		Issuer:                         "beerdosan-backend",
+		Audience:                       "venturex-app",
+		AcceptLegacyClaims:             true,
+	}
 }
 
 type JWTService interface {
-	GenerateAccessToken(userID int64, username, email string, sessionID int64, fingerprint string) (JWT, time.Time, error)
-	GenerateRefreshToken(userID int64, username, email string, sessionID int64) (JWT, time.Time, error)
+	GenerateAccessToken(subject, sessionID, role string, authTime time.Time, scopes []string) (JWT, time.Time, error)
+	GenerateRefreshToken(subject, sessionID, role string, authTime time.Time, rememberMe bool) (JWT, time.Time, error)
 	ValidateToken(token string) (*JWTClaims, error)
 	ValidateAccessToken(token string) (*JWTClaims, error)
 	ValidateRefreshToken(token string) (*JWTClaims, error)
 	RefreshAccessToken(refreshToken string) (JWT, time.Time, error)
 	IsTokenExpired(token string) bool
 	GetTokenClaims(token string) (*JWTClaims, error)
+	AccessTokenDuration() time.Duration
+	RefreshTokenDuration(rememberMe bool) time.Duration
 }
 
 type jwtService struct {
@@ -131,30 +168,26 @@ func NewJWTService(config *JWTConfig) JWTService {
 	return &jwtService{config: config}
 }
 
-func (s *jwtService) GenerateAccessToken(userID int64, username, email string, sessionID int64, fingerprint string) (JWT, time.Time, error) {
-	now := time.Now()
-	expiresAt := now.Add(s.config.AccessTokenDuration)
+func (s *jwtService) GenerateAccessToken(subject, sessionID, role string, authTime time.Time, scopes []string) (JWT, time.Time, error) {
+	expiresAt := time.Now().Add(s.config.AccessTokenDuration)
 
-	claims := &JWTClaims{
-		UserID:      userID,
-		Username:    username,
-		Email:       email,
-		TokenType:   string(string(TokenTypeAccess)),
-		SessionID:   sessionID,
-		Fingerprint: fingerprint,
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    s.config.Issuer,
-			Subject:   fmt.Sprintf("%d", userID),
-			Audience:  []string{s.config.Audience},
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			NotBefore: jwt.NewNumericDate(now),
-			IssuedAt:  jwt.NewNumericDate(now),
-			ID:        fmt.Sprintf("%d-%d", userID, sessionID),
-		},
+	claims := s.newClaims(TokenTypeAccess, subject, sessionID, role, authTime, expiresAt)
+	claims.Scope = strings.Join(scopes, " ")
+
+	tokenString, err := s.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	tokenString, err := token.SignedString(s.config.PrivateKey)
+	return JWT(tokenString), expiresAt, nil
+}
+
+func (s *jwtService) GenerateRefreshToken(subject, sessionID, role string, authTime time.Time, rememberMe bool) (JWT, time.Time, error) {
+	expiresAt := time.Now().Add(s.config.RefreshDuration(rememberMe))
+
+	claims := s.newClaims(TokenTypeRefresh, subject, sessionID, role, authTime, expiresAt)
+
+	tokenString, err := s.sign(claims)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -162,43 +195,35 @@ func (s *jwtService) GenerateAccessToken(userID int64, username, email string, s
 	return JWT(tokenString), expiresAt, nil
 }
 
-func (s *jwtService) GenerateRefreshToken(userID int64, username, email string, sessionID int64) (JWT, time.Time, error) {
+// newClaims builds the claims shared by both token types. A zero authTime
+// leaves out the auth_time claim.
+func (s *jwtService) newClaims(tokenType TokenType, subject, sessionID, role string, authTime, expiresAt time.Time) *JWTClaims {
 	now := time.Now()
-	expiresAt := now.Add(s.config.RefreshTokenDuration)
 
 	claims := &JWTClaims{
-		UserID:    userID,
-		Username:  username,
-		Email:     email,
-		TokenType: string(string(TokenTypeRefresh)),
+		Version:   ClaimsVersion,
 		SessionID: sessionID,
+		Role:      role,
+		TokenType: string(tokenType),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    s.config.Issuer,
-			Subject:   fmt.Sprintf("%d", userID),
+			Subject:   subject,
 			Audience:  []string{s.config.Audience},
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			NotBefore: jwt.NewNumericDate(now),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ID:        fmt.Sprintf("refresh-%d-%d", userID, sessionID),
+			ID:        uuid.NewString(),
 		},
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	tokenString, err := token.SignedString(s.config.PrivateKey)
-	if err != nil {
-		return "", time.Time{}, err
+	if !authTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(authTime)
 	}
 
-	return JWT(tokenString), expiresAt, nil
+	return claims
 }
 
 func (s *jwtService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.config.PublicKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &tokenClaims{}, s.keyFunc, s.parserOptions()...)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -210,12 +235,12 @@ func (s *jwtService) ValidateToken(tokenString string) (*JWTClaims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(*JWTClaims)
+	claims, ok := token.Claims.(*tokenClaims)
 	if !ok || !token.Valid {
 		return nil, ErrInvalidToken
 	}
 
-	return claims, nil
+	return claims.normalize(s.config.AcceptLegacyClaims)
 }
 
 func (s *jwtService) ValidateAccessToken(tokenString string) (*JWTClaims, error) {
@@ -224,7 +249,7 @@ func (s *jwtService) ValidateAccessToken(tokenString string) (*JWTClaims, error)
 		return nil, err
 	}
 
-	if claims.TokenType != string(string(TokenTypeAccess)) {
+	if claims.TokenType != string(TokenTypeAccess) {
 		return nil, ErrInvalidToken
 	}
 
@@ -237,7 +262,7 @@ func (s *jwtService) ValidateRefreshToken(tokenString string) (*JWTClaims, error
 		return nil, err
 	}
 
-	if claims.TokenType != string(string(TokenTypeRefresh)) {
+	if claims.TokenType != string(TokenTypeRefresh) {
 		return nil, ErrInvalidToken
 	}
 
@@ -250,13 +275,7 @@ func (s *jwtService) RefreshAccessToken(refreshTokenString string) (JWT, time.Ti
 		return "", time.Time{}, err
 	}
 
-	return s.GenerateAccessToken(
-		claims.UserID,
-		claims.Username,
-		claims.Email,
-		claims.SessionID,
-		claims.Fingerprint,
-	)
+	return s.GenerateAccessToken(claims.UserID(), claims.SessionID, claims.Role, claims.AuthenticatedAt(), claims.Scopes())
 }
 
 func (s *jwtService) IsTokenExpired(tokenString string) bool {
@@ -265,52 +284,57 @@ func (s *jwtService) IsTokenExpired(tokenString string) bool {
 }
 
 func (s *jwtService) GetTokenClaims(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.config.PublicKey, nil
-	}, jwt.WithoutClaimsValidation())
+	token, err := jwt.ParseWithClaims(tokenString, &tokenClaims{}, s.keyFunc, append(s.parserOptions(), jwt.WithoutClaimsValidation())...)
 
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(*JWTClaims)
+	claims, ok := token.Claims.(*tokenClaims)
 	if !ok {
 		return nil, ErrInvalidToken
 	}
 
-	return claims, nil
+	return claims.normalize(s.config.AcceptLegacyClaims)
 }
 
-func GenerateKeyPair(bits int) (*rsa.PrivateKey, *rsa.PublicKey, error) {
-	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+func (s *jwtService) sign(claims *JWTClaims) (string, error) {
+	method, err := s.algorithm().signingMethod()
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
 
-	return privateKey, &privateKey.PublicKey, nil
+	return jwt.NewWithClaims(method, claims).SignedString(s.config.PrivateKey)
 }
 
-func PrivateKeyToPEM(privateKey *rsa.PrivateKey) string {
-	privateKeyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
-	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: privateKeyBytes,
-	})
-	return string(privateKeyPEM)
+// algorithm is the configured algorithm, or the one implied by the
+// verification key when none was configured.
+func (s *jwtService) algorithm() Algorithm {
+	if s.config.Algorithm != "" {
+		return s.config.Algorithm
+	}
+	alg, _ := AlgorithmForKey(s.config.PublicKey)
+	return alg
 }
 
-func PublicKeyToPEM(publicKey *rsa.PublicKey) (string, error) {
-	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
-	if err != nil {
-		return "", err
+// keyFunc only hands out the verification key when the token's alg header is
+// exactly the algorithm of that key, so a token cannot choose how it is
+// verified.
+func (s *jwtService) keyFunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != s.algorithm().String() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
+	return s.config.PublicKey, nil
+}
+
+func (s *jwtService) parserOptions() []jwt.ParserOption {
+	return []jwt.ParserOption{jwt.WithValidMethods([]string{s.algorithm().String()})}
+}
+
+func (s *jwtService) AccessTokenDuration() time.Duration {
+	return s.config.AccessTokenDuration
+}
 
-	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
-		Type:  "PUBLIC KEY",
-		Bytes: publicKeyBytes,
-	})
-	return string(publicKeyPEM), nil
+func (s *jwtService) RefreshTokenDuration(rememberMe bool) time.Duration {
+	return s.config.RefreshDuration(rememberMe)
 }
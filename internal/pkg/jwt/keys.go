@@ -0,0 +1,228 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+
+const (
+	rsaKeyBits    = 2048
+	hmacKeyBytes  = 32
+	secretPEMType = "SECRET KEY"
+)
+
+type Algorithm string
+
+const (
+	AlgorithmRS256 Algorithm = "RS256"
+	AlgorithmES256 Algorithm = "ES256"
+	AlgorithmEdDSA Algorithm = "EdDSA"
+	AlgorithmHS256 Algorithm = "HS256"
+)
+
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "RS256":
+		return AlgorithmRS256, nil
+	case "ES256":
+		return AlgorithmES256, nil
+	case "EDDSA":
+		return AlgorithmEdDSA, nil
+	case "HS256":
+		return AlgorithmHS256, nil
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+}
+
+func (a Algorithm) String() string {
+	return string(a)
+}
+
+func (a Algorithm) signingMethod() (jwt.SigningMethod, error) {
+	switch a {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmES256:
+		return jwt.SigningMethodES256, nil
+	case AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	case AlgorithmHS256:
+		return jwt.SigningMethodHS256, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// AlgorithmForKey returns the only algorithm a key may be used with. Tying the
+// algorithm to the key type is what prevents algorithm confusion, e.g. an
+// HS256 token "signed" with an RSA public key.
+func AlgorithmForKey(key interface{}) (Algorithm, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey, *rsa.PrivateKey:
+		return AlgorithmRS256, nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return "", ErrInvalidKeyFormat
+		}
+		return AlgorithmES256, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return "", ErrInvalidKeyFormat
+		}
+		return AlgorithmES256, nil
+	case ed25519.PublicKey, ed25519.PrivateKey:
+		return AlgorithmEdDSA, nil
+	case []byte:
+		if len(k) < hmacKeyBytes {
+			return "", ErrInvalidSigningKey
+		}
+		return AlgorithmHS256, nil
+	default:
+		return "", ErrInvalidKeyFormat
+	}
+}
+
+// GenerateKeyPair creates an RSA key pair of the given size.
+func GenerateKeyPair(bits int) (*rsa.PrivateKey, *rsa.PublicKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return privateKey, &privateKey.PublicKey, nil
+}
+
+// GenerateKeyPairFor creates a signing key and its verification key for alg.
+// For HS256 both are the same random secret.
+func GenerateKeyPairFor(alg Algorithm) (crypto.PrivateKey, crypto.PublicKey, error) {
+	switch alg {
+	case AlgorithmRS256:
+		return GenerateKeyPair(rsaKeyBits)
+	case AlgorithmES256:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, &privateKey.PublicKey, nil
+	case AlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, publicKey, nil
+	case AlgorithmHS256:
+		secret := make([]byte, hmacKeyBytes)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, nil, err
+		}
+		return secret, secret, nil
+	default:
+		return nil, nil, ErrUnsupportedAlgorithm
+	}
+}
+
+func PrivateKeyToPEM(privateKey crypto.PrivateKey) (string, error) {
+	var block *pem.Block
+
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return "", err
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	case ed25519.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return "", err
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	case []byte:
+		block = &pem.Block{Type: secretPEMType, Bytes: k}
+	default:
+		return "", ErrInvalidKeyFormat
+	}
+
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+func PublicKeyToPEM(publicKey crypto.PublicKey) (string, error) {
+	if _, ok := publicKey.([]byte); ok {
+		// An HMAC secret has no public half; see PrivateKeyToPEM.
+		return "", ErrInvalidKeyFormat
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	})
+	return string(publicKeyPEM), nil
+}
+
+func parsePrivateKeyPEM(privateKeyPEM string) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, ErrInvalidKeyFormat
+	}
+
+	switch block.Type {
+	case secretPEMType:
+		return block.Bytes, nil
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	return key, nil
+}
+
+func parsePublicKeyPEM(publicKeyPEM string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, ErrInvalidKeyFormat
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return key, nil
+}
+
+// publicKeyOf derives the verification key from a signing key.
+func publicKeyOf(privateKey crypto.PrivateKey) (crypto.PublicKey, error) {
+	switch k := privateKey.(type) {
+	case []byte:
+		return k, nil
+	case crypto.Signer:
+		return k.Public(), nil
+	default:
+		return nil, ErrInvalidKeyFormat
+	}
+}
@@ -0,0 +1,168 @@
+package jwt_test
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"beerdosan-backend/internal/pkg/jwt"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var allAlgorithms = []jwt.Algorithm{
+	jwt.AlgorithmRS256,
+	jwt.AlgorithmES256,
+	jwt.AlgorithmEdDSA,
+	jwt.AlgorithmHS256,
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	testCases := []struct {
+		value     string
+		want      jwt.Algorithm
+		expectErr error
+	}{
+		{"RS256", jwt.AlgorithmRS256, nil},
+		{"es256", jwt.AlgorithmES256, nil},
+		{"EdDSA", jwt.AlgorithmEdDSA, nil},
+		{" HS256 ", jwt.AlgorithmHS256, nil},
+		{"none", "", jwt.ErrUnsupportedAlgorithm},
+		{"RS512", "", jwt.ErrUnsupportedAlgorithm},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := jwt.ParseAlgorithm(tc.value)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSignAndVerifyPerAlgorithm(t *testing.T) {
+	for _, alg := range allAlgorithms {
+		t.Run(alg.String(), func(t *testing.T) {
+			// Arrange
+			cfg, err := jwt.GenerateJWTConfig(alg)
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			service := jwt.NewJWTService(cfg)
+
+			// Act
+			token, _, err := service.GenerateAccessToken(testUserID, testSessionID, "user", time.Time{}, nil)
+			require.NoError(t, err)
+			claims, err := service.ValidateAccessToken(token.String())
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, claims.UserID())
+
+			parsed, _, err := gojwt.NewParser().ParseUnverified(token.String(), &gojwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, alg.String(), parsed.Header["alg"])
+		})
+	}
+}
+
+func TestPEMRoundTrip(t *testing.T) {
+	for _, alg := range allAlgorithms {
+		t.Run(alg.String(), func(t *testing.T) {
+			// Arrange
+			privateKey, publicKey, err := jwt.GenerateKeyPairFor(alg)
+			require.NoError(t, err)
+
+			privatePEM, err := jwt.PrivateKeyToPEM(privateKey)
+			require.NoError(t, err)
+
+			publicPEM := ""
+			if alg != jwt.AlgorithmHS256 {
+				publicPEM, err = jwt.PublicKeyToPEM(publicKey)
+				require.NoError(t, err)
+			}
+
+			// Act
+			cfg, err := jwt.LoadJWTConfigFromPEM(alg, privatePEM, publicPEM)
+			require.NoError(t, err)
+			derived, err := jwt.LoadJWTConfigFromPEM("", privatePEM, "")
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, alg, cfg.Algorithm)
+			assert.Equal(t, alg, derived.Algorithm)
+
+			token, _, err := jwt.NewJWTService(cfg).GenerateRefreshToken(testUserID, testSessionID, "user", time.Time{}, false)
+			require.NoError(t, err)
+			_, err = jwt.NewJWTService(derived).ValidateRefreshToken(token.String())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadJWTConfigFromPEMRejectsMismatchedAlgorithm(t *testing.T) {
+	privateKey, _, err := jwt.GenerateKeyPairFor(jwt.AlgorithmES256)
+	require.NoError(t, err)
+	privatePEM, err := jwt.PrivateKeyToPEM(privateKey)
+	require.NoError(t, err)
+
+	_, err = jwt.LoadJWTConfigFromPEM(jwt.AlgorithmRS256, privatePEM, "")
+
+	assert.ErrorIs(t, err, jwt.ErrUnsupportedAlgorithm)
+}
+
+func TestValidateTokenRejectsOtherAlgorithms(t *testing.T) {
+	rsaCfg, err := jwt.GenerateJWTConfig(jwt.AlgorithmRS256)
+	require.NoError(t, err)
+	rsaService := jwt.NewJWTService(rsaCfg)
+
+	t.Run("token signed by a different key type", func(t *testing.T) {
+		for _, alg := range []jwt.Algorithm{jwt.AlgorithmES256, jwt.AlgorithmEdDSA, jwt.AlgorithmHS256} {
+			cfg, err := jwt.GenerateJWTConfig(alg)
+			require.NoError(t, err)
+			token, _, err := jwt.NewJWTService(cfg).GenerateAccessToken(testUserID, testSessionID, "user", time.Time{}, nil)
+			require.NoError(t, err)
+
+			_, err = rsaService.ValidateToken(token.String())
+
+			assert.ErrorIs(t, err, jwt.ErrInvalidToken, alg.String())
+		}
+	})
+
+	t.Run("HS256 token keyed with the RSA public key", func(t *testing.T) {
+		// The classic confusion attack: use the public key bytes as an HMAC
+		// secret and hope the verifier hands the same key to HS256.
+		publicDER, err := x509.MarshalPKIXPublicKey(rsaCfg.PublicKey.(*rsa.PublicKey))
+		require.NoError(t, err)
+		forged, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &jwt.JWTClaims{
+			Version:          jwt.ClaimsVersion,
+			SessionID:        testSessionID,
+			TokenType:        string(jwt.TokenTypeAccess),
+			RegisteredClaims: gojwt.RegisteredClaims{Subject: "attacker"},
+		}).SignedString(publicDER)
+		require.NoError(t, err)
+
+		_, err = rsaService.ValidateToken(forged)
+
+		assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		forged, err := gojwt.NewWithClaims(gojwt.SigningMethodNone, &jwt.JWTClaims{
+			Version:          jwt.ClaimsVersion,
+			SessionID:        testSessionID,
+			RegisteredClaims: gojwt.RegisteredClaims{Subject: "attacker"},
+		}).SignedString(gojwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = rsaService.ValidateToken(forged)
+
+		assert.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+}
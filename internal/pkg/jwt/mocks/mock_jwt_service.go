@@ -23,9 +23,54 @@ func (_m *MockJWTService) EXPECT() *MockJWTService_Expecter {
 	return &MockJWTService_Expecter{mock: &_m.Mock}
 }
 
-// GenerateAccessToken provides a mock function with given fields: userID, username, email, sessionID, fingerprint
-func (_m *MockJWTService) GenerateAccessToken(userID int64, username string, email string, sessionID int64, fingerprint string) (jwt.JWT, time.Time, error) {
-	ret := _m.Called(userID, username, email, sessionID, fingerprint)
+// AccessTokenDuration provides a mock function with no fields
+func (_m *MockJWTService) AccessTokenDuration() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessTokenDuration")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// MockJWTService_AccessTokenDuration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessTokenDuration'
+type MockJWTService_AccessTokenDuration_Call struct {
+	*mock.Call
+}
+
+// AccessTokenDuration is a helper method to define mock.On call
+func (_e *MockJWTService_Expecter) AccessTokenDuration() *MockJWTService_AccessTokenDuration_Call {
+	return &MockJWTService_AccessTokenDuration_Call{Call: _e.mock.On("AccessTokenDuration")}
+}
+
+func (_c *MockJWTService_AccessTokenDuration_Call) Run(run func()) *MockJWTService_AccessTokenDuration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockJWTService_AccessTokenDuration_Call) Return(_a0 time.Duration) *MockJWTService_AccessTokenDuration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJWTService_AccessTokenDuration_Call) RunAndReturn(run func() time.Duration) *MockJWTService_AccessTokenDuration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateAccessToken provides a mock function with given fields: subject, sessionID, role, authTime, scopes
+func (_m *MockJWTService) GenerateAccessToken(subject string, sessionID string, role string, authTime time.Time, scopes []string) (jwt.JWT, time.Time, error) {
+	ret := _m.Called(subject, sessionID, role, authTime, scopes)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateAccessToken")
@@ -34,23 +79,23 @@ func (_m *MockJWTService) GenerateAccessToken(userID int64, username string, ema
 	var r0 jwt.JWT
 	var r1 time.Time
 	var r2 error
-	if rf, ok := ret.Get(0).(func(int64, string, string, int64, string) (jwt.JWT, time.Time, error)); ok {
-		return rf(userID, username, email, sessionID, fingerprint)
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, []string) (jwt.JWT, time.Time, error)); ok {
+		return rf(subject, sessionID, role, authTime, scopes)
 	}
-	if rf, ok := ret.Get(0).(func(int64, string, string, int64, string) jwt.JWT); ok {
-		r0 = rf(userID, username, email, sessionID, fingerprint)
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, []string) jwt.JWT); ok {
+		r0 = rf(subject, sessionID, role, authTime, scopes)
 	} else {
 		r0 = ret.Get(0).(jwt.JWT)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, string, string, int64, string) time.Time); ok {
-		r1 = rf(userID, username, email, sessionID, fingerprint)
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Time, []string) time.Time); ok {
+		r1 = rf(subject, sessionID, role, authTime, scopes)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
-	if rf, ok := ret.Get(2).(func(int64, string, string, int64, string) error); ok {
-		r2 = rf(userID, username, email, sessionID, fingerprint)
+	if rf, ok := ret.Get(2).(func(string, string, string, time.Time, []string) error); ok {
+		r2 = rf(subject, sessionID, role, authTime, scopes)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -64,18 +109,18 @@ type MockJWTService_GenerateAccessToken_Call struct {
 }
 
 // GenerateAccessToken is a helper method to define mock.On call
-//   - userID int64
-//   - username string
-//   - email string
-//   - sessionID int64
-//   - fingerprint string
-func (_e *MockJWTService_Expecter) GenerateAccessToken(userID interface{}, username interface{}, email interface{}, sessionID interface{}, fingerprint interface{}) *MockJWTService_GenerateAccessToken_Call {
-	return &MockJWTService_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", userID, username, email, sessionID, fingerprint)}
+//   - subject string
+//   - sessionID string
+//   - role string
+//   - authTime time.Time
+//   - scopes []string
+func (_e *MockJWTService_Expecter) GenerateAccessToken(subject interface{}, sessionID interface{}, role interface{}, authTime interface{}, scopes interface{}) *MockJWTService_GenerateAccessToken_Call {
+	return &MockJWTService_GenerateAccessToken_Call{Call: _e.mock.On("GenerateAccessToken", subject, sessionID, role, authTime, scopes)}
 }
 
-func (_c *MockJWTService_GenerateAccessToken_Call) Run(run func(userID int64, username string, email string, sessionID int64, fingerprint string)) *MockJWTService_GenerateAccessToken_Call {
+func (_c *MockJWTService_GenerateAccessToken_Call) Run(run func(subject string, sessionID string, role string, authTime time.Time, scopes []string)) *MockJWTService_GenerateAccessToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string), args[2].(string), args[3].(int64), args[4].(string))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(time.Time), args[4].([]string))
 	})
 	return _c
 }
@@ -85,14 +130,14 @@ func (_c *MockJWTService_GenerateAccessToken_Call) Return(_a0 jwt.JWT, _a1 time.
 	return _c
 }
 
-func (_c *MockJWTService_GenerateAccessToken_Call) RunAndReturn(run func(int64, string, string, int64, string) (jwt.JWT, time.Time, error)) *MockJWTService_GenerateAccessToken_Call {
+func (_c *MockJWTService_GenerateAccessToken_Call) RunAndReturn(run func(string, string, string, time.Time, []string) (jwt.JWT, time.Time, error)) *MockJWTService_GenerateAccessToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GenerateRefreshToken provides a mock function with given fields: userID, username, email, sessionID
-func (_m *MockJWTService) GenerateRefreshToken(userID int64, username string, email string, sessionID int64) (jwt.JWT, time.Time, error) {
-	ret := _m.Called(userID, username, email, sessionID)
+// GenerateRefreshToken provides a mock function with given fields: subject, sessionID, role, authTime, rememberMe
+func (_m *MockJWTService) GenerateRefreshToken(subject string, sessionID string, role string, authTime time.Time, rememberMe bool) (jwt.JWT, time.Time, error) {
+	ret := _m.Called(subject, sessionID, role, authTime, rememberMe)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateRefreshToken")
@@ -101,23 +146,23 @@ func (_m *MockJWTService) GenerateRefreshToken(userID int64, username string, em
 	var r0 jwt.JWT
 	var r1 time.Time
 	var r2 error
-	if rf, ok := ret.Get(0).(func(int64, string, string, int64) (jwt.JWT, time.Time, error)); ok {
-		return rf(userID, username, email, sessionID)
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, bool) (jwt.JWT, time.Time, error)); ok {
+		return rf(subject, sessionID, role, authTime, rememberMe)
 	}
-	if rf, ok := ret.Get(0).(func(int64, string, string, int64) jwt.JWT); ok {
-		r0 = rf(userID, username, email, sessionID)
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, bool) jwt.JWT); ok {
+		r0 = rf(subject, sessionID, role, authTime, rememberMe)
 	} else {
 		r0 = ret.Get(0).(jwt.JWT)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, string, string, int64) time.Time); ok {
-		r1 = rf(userID, username, email, sessionID)
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Time, bool) time.Time); ok {
+		r1 = rf(subject, sessionID, role, authTime, rememberMe)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
-	if rf, ok := ret.Get(2).(func(int64, string, string, int64) error); ok {
-		r2 = rf(userID, username, email, sessionID)
+	if rf, ok := ret.Get(2).(func(string, string, string, time.Time, bool) error); ok {
+		r2 = rf(subject, sessionID, role, authTime, rememberMe)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -131,17 +176,18 @@ type MockJWTService_GenerateRefreshToken_Call struct {
 }
 
 // GenerateRefreshToken is a helper method to define mock.On call
-//   - userID int64
-//   - username string
-//   - email string
-//   - sessionID int64
-func (_e *MockJWTService_Expecter) GenerateRefreshToken(userID interface{}, username interface{}, email interface{}, sessionID interface{}) *MockJWTService_GenerateRefreshToken_Call {
-	return &MockJWTService_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", userID, username, email, sessionID)}
+//   - subject string
+//   - sessionID string
+//   - role string
+//   - authTime time.Time
+//   - rememberMe bool
+func (_e *MockJWTService_Expecter) GenerateRefreshToken(subject interface{}, sessionID interface{}, role interface{}, authTime interface{}, rememberMe interface{}) *MockJWTService_GenerateRefreshToken_Call {
+	return &MockJWTService_GenerateRefreshToken_Call{Call: _e.mock.On("GenerateRefreshToken", subject, sessionID, role, authTime, rememberMe)}
 }
 
-func (_c *MockJWTService_GenerateRefreshToken_Call) Run(run func(userID int64, username string, email string, sessionID int64)) *MockJWTService_GenerateRefreshToken_Call {
+func (_c *MockJWTService_GenerateRefreshToken_Call) Run(run func(subject string, sessionID string, role string, authTime time.Time, rememberMe bool)) *MockJWTService_GenerateRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string), args[2].(string), args[3].(int64))
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(bool))
 	})
 	return _c
 }
@@ -151,7 +197,7 @@ func (_c *MockJWTService_GenerateRefreshToken_Call) Return(_a0 jwt.JWT, _a1 time
 	return _c
 }
 
-func (_c *MockJWTService_GenerateRefreshToken_Call) RunAndReturn(run func(int64, string, string, int64) (jwt.JWT, time.Time, error)) *MockJWTService_GenerateRefreshToken_Call {
+func (_c *MockJWTService_GenerateRefreshToken_Call) RunAndReturn(run func(string, string, string, time.Time, bool) (jwt.JWT, time.Time, error)) *MockJWTService_GenerateRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -323,6 +369,52 @@ func (_c *MockJWTService_RefreshAccessToken_Call) RunAndReturn(run func(string)
 	return _c
 }
 
+// RefreshTokenDuration provides a mock function with given fields: rememberMe
+func (_m *MockJWTService) RefreshTokenDuration(rememberMe bool) time.Duration {
+	ret := _m.Called(rememberMe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshTokenDuration")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func(bool) time.Duration); ok {
+		r0 = rf(rememberMe)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// MockJWTService_RefreshTokenDuration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokenDuration'
+type MockJWTService_RefreshTokenDuration_Call struct {
+	*mock.Call
+}
+
+// RefreshTokenDuration is a helper method to define mock.On call
+//   - rememberMe bool
+func (_e *MockJWTService_Expecter) RefreshTokenDuration(rememberMe interface{}) *MockJWTService_RefreshTokenDuration_Call {
+	return &MockJWTService_RefreshTokenDuration_Call{Call: _e.mock.On("RefreshTokenDuration", rememberMe)}
+}
+
+func (_c *MockJWTService_RefreshTokenDuration_Call) Run(run func(rememberMe bool)) *MockJWTService_RefreshTokenDuration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(bool))
+	})
+	return _c
+}
+
+func (_c *MockJWTService_RefreshTokenDuration_Call) Return(_a0 time.Duration) *MockJWTService_RefreshTokenDuration_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJWTService_RefreshTokenDuration_Call) RunAndReturn(run func(bool) time.Duration) *MockJWTService_RefreshTokenDuration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateAccessToken provides a mock function with given fields: token
 func (_m *MockJWTService) ValidateAccessToken(token string) (*jwt.JWTClaims, error) {
 	ret := _m.Called(token)
@@ -0,0 +1,128 @@
+package password
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// Upper bounds on the cost parameters of a hash that DecodeArgon2id accepts.
+// Verifying a hash costs what its parameters say, so a stored hash with
+// absurd parameters could otherwise exhaust memory or CPU on login.
+const (
+	MaxArgon2Memory  = 1024 * 1024 // KiB, 1 GiB
+	MaxArgon2Time    = 16
+	MaxArgon2Threads = 16
+)
+
+var ErrArgon2ParamsOutOfRange = errors.New("argon2 parameters out of range")
+
+// Argon2Params are the Argon2id cost parameters stored in every hash.
+type Argon2Params struct {
+	Memory  uint32 // KiB
+	Time    uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// EncodeArgon2id renders a hash in the PHC string format used by the
+// reference implementation:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+//
+// with salt and hash in unpadded standard base64.
+func EncodeArgon2id(params Argon2Params, salt, hash []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+}
+
+// DecodeArgon2id parses a hash produced by EncodeArgon2id. The returned params
+// have SaltLen and KeyLen set from the decoded salt and hash. Hashes whose
+// cost exceeds MaxArgon2Memory, MaxArgon2Time or MaxArgon2Threads are
+// rejected with ErrArgon2ParamsOutOfRange.
+func DecodeArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+
+	version, ok := strings.CutPrefix(parts[2], "v=")
+	if !ok || version != strconv.Itoa(argon2.Version) {
+		return params, nil, nil, ErrIncompatibleVersion
+	}
+
+	seen := map[string]bool{}
+	for _, param := range strings.Split(parts[3], ",") {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok || seen[key] {
+			return params, nil, nil, ErrInvalidHashFormat
+		}
+		seen[key] = true
+
+		switch key {
+		case "m":
+			n, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return params, nil, nil, ErrInvalidHashFormat
+			}
+			params.Memory = uint32(n)
+		case "t":
+			n, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return params, nil, nil, ErrInvalidHashFormat
+			}
+			params.Time = uint32(n)
+		case "p":
+			n, err := strconv.ParseUint(value, 10, 8)
+			if err != nil {
+				return params, nil, nil, ErrInvalidHashFormat
+			}
+			params.Threads = uint8(n)
+		default:
+			return params, nil, nil, ErrInvalidHashFormat
+		}
+	}
+	if !seen["m"] || !seen["t"] || !seen["p"] || params.Time == 0 || params.Threads == 0 {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+	if err := params.checkLimits(); err != nil {
+		return params, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(hash) == 0 {
+		return params, nil, nil, ErrInvalidHashFormat
+	}
+
+	params.SaltLen = uint32(len(salt))
+	params.KeyLen = uint32(len(hash))
+
+	return params, salt, hash, nil
+}
+
+// checkLimits rejects cost parameters above the maximums DecodeArgon2id
+// accepts.
+func (p Argon2Params) checkLimits() error {
+	if p.Memory > MaxArgon2Memory || p.Time > MaxArgon2Time || p.Threads > MaxArgon2Threads {
+		return ErrArgon2ParamsOutOfRange
+	}
+	return nil
+}
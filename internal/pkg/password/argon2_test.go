@@ -0,0 +1,117 @@
+package password_test
+
+import (
+	"testing"
+
+	"beerdosan-backend/internal/pkg/password"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgon2idPHCRoundTrip(t *testing.T) {
+	// Arrange
+	params := password.Argon2Params{Memory: 65536, Time: 3, Threads: 2, SaltLen: 4, KeyLen: 3}
+	salt := []byte("salt")
+	hash := []byte("key")
+
+	// Act
+	encoded := password.EncodeArgon2id(params, salt, hash)
+	decodedParams, decodedSalt, decodedHash, err := password.DecodeArgon2id(encoded)
+
+	// Assert
+	assert.Equal(t, "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$a2V5", encoded)
+	require.NoError(t, err)
+	assert.Equal(t, params, decodedParams)
+	assert.Equal(t, salt, decodedSalt)
+	assert.Equal(t, hash, decodedHash)
+}
+
+func TestDecodeArgon2idRejectsMalformedHashes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		encoded   string
+		expectErr error
+	}{
+		{"wrong algorithm", "$argon2i$v=19$m=65536,t=3,p=2$c2FsdA$a2V5", password.ErrInvalidHashFormat},
+		{"old version", "$argon2id$v=16$m=65536,t=3,p=2$c2FsdA$a2V5", password.ErrIncompatibleVersion},
+		{"missing parameter", "$argon2id$v=19$m=65536,t=3$c2FsdA$a2V5", password.ErrInvalidHashFormat},
+		{"duplicate parameter", "$argon2id$v=19$m=65536,t=3,t=3$c2FsdA$a2V5", password.ErrInvalidHashFormat},
+		{"non-numeric parameter", "$argon2id$v=19$m=lots,t=3,p=2$c2FsdA$a2V5", password.ErrInvalidHashFormat},
+		{"threads overflow", "$argon2id$v=19$m=65536,t=3,p=256$c2FsdA$a2V5", password.ErrInvalidHashFormat},
+		{"bad salt", "$argon2id$v=19$m=65536,t=3,p=2$!!$a2V5", password.ErrInvalidHashFormat},
+		{"missing hash", "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA", password.ErrInvalidHashFormat},
+		{"memory too large", "$argon2id$v=19$m=4294967295,t=3,p=2$c2FsdA$a2V5", password.ErrArgon2ParamsOutOfRange},
+		{"time too large", "$argon2id$v=19$m=65536,t=4294967295,p=2$c2FsdA$a2V5", password.ErrArgon2ParamsOutOfRange},
+		{"threads too many", "$argon2id$v=19$m=65536,t=3,p=255$c2FsdA$a2V5", password.ErrArgon2ParamsOutOfRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, err := password.DecodeArgon2id(tc.encoded)
+
+			assert.ErrorIs(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestHasherNeedsRehash(t *testing.T) {
+	// Arrange
+	cheap := &password.PasswordConfig{
+		Algorithm:     password.AlgorithmArgon2id,
+		BcryptCost:    4,
+		Argon2Memory:  1024,
+		Argon2Time:    1,
+		Argon2Threads: 1,
+		Argon2KeyLen:  32,
+		Argon2SaltLen: 16,
+	}
+	stronger := *cheap
+	stronger.Argon2Time = 2
+	bcryptCfg := *cheap
+	bcryptCfg.Algorithm = password.AlgorithmBcrypt
+
+	hasher := password.NewHasher(cheap)
+	hashed, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	bcryptHashed, err := password.NewHasher(&bcryptCfg).Hash("correct horse")
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, hasher.Verify(hashed, "correct horse"))
+	assert.False(t, hasher.Verify(hashed, "wrong horse"))
+	assert.True(t, hasher.Verify(bcryptHashed, "correct horse"))
+
+	assert.False(t, hasher.NeedsRehash(hashed))
+	assert.True(t, password.NewHasher(&stronger).NeedsRehash(hashed))
+	assert.True(t, hasher.NeedsRehash(bcryptHashed))
+	assert.True(t, password.NewHasher(&bcryptCfg).NeedsRehash(hashed))
+}
+
+func TestHasherRecognizes(t *testing.T) {
+	// Arrange
+	cfg := &password.PasswordConfig{
+		Algorithm:     password.AlgorithmBcrypt,
+		BcryptCost:    4,
+		Argon2Memory:  1024,
+		Argon2Time:    1,
+		Argon2Threads: 1,
+		Argon2KeyLen:  32,
+		Argon2SaltLen: 16,
+	}
+	hasher := password.NewHasher(cfg)
+	bcryptHashed, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	argonCfg := *cfg
+	argonCfg.Algorithm = password.AlgorithmArgon2id
+	argonHashed, err := password.NewHasher(&argonCfg).Hash("correct horse")
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, hasher.Recognizes(bcryptHashed))
+	assert.True(t, hasher.Recognizes(argonHashed))
+	assert.False(t, hasher.Recognizes("5f4dcc3b5aa765d61d8327deb882cf99"))
+	assert.False(t, hasher.Recognizes("$argon2id$v=19$broken"))
+	assert.False(t, hasher.Recognizes("$argon2id$v=19$m=4294967295,t=4294967295,p=255$c2FsdA$a2V5"))
+	assert.False(t, hasher.Recognizes(""))
+}
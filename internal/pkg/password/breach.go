@@ -0,0 +1,373 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+var ErrInvalidBreachData = errors.New("invalid breached password data")
+
+// breachMetrics is published under /debug/vars as "password_breach". A
+// failed lookup accepts the password, so a rising failure count means
+// screening is effectively off.
+var (
+	breachMetrics        = expvar.NewMap("password_breach")
+	breachLookups        = new(expvar.Int)
+	breachLookupFailures = new(expvar.Int)
+)
+
+func init() {
+	breachMetrics.Set("lookups", breachLookups)
+	breachMetrics.Set("lookup_failures", breachLookupFailures)
+}
+
+// BreachSource reports how often a password appears in known breaches, keyed
+// by the SHA-1 hash of the password as published by Have I Been Pwned.
+type BreachSource interface {
+	BreachCount(hash [sha1.Size]byte) (int, error)
+	Close() error
+}
+
+// OpenBreachSource opens a bloom filter written by BloomFilter.WriteTo, or
+// otherwise a Have I Been Pwned "ordered by hash" text file.
+func OpenBreachSource(path string) (BreachSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password data: %w", err)
+	}
+
+	magic := make([]byte, len(bloomMagic))
+	if _, err := io.ReadFull(f, magic); err == nil && string(magic) == bloomMagic {
+		defer f.Close()
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return ReadBloomFilter(bufio.NewReader(f))
+	}
+
+	return newHIBPFile(f)
+}
+
+// hibpLineMax bounds the length of one "<40 hex digits>:<count>\r\n" line.
+const hibpLineMax = 64
+
+// hibpSearchBlock is the range below which a binary search switches to
+// scanning.
+const hibpSearchBlock = 4096
+
+// HIBPFile looks hashes up in a Have I Been Pwned text file sorted by hash,
+// one "SHA1:COUNT" per line. It binary searches the file on disk, so memory
+// use does not depend on the file size.
+type HIBPFile struct {
+	f    *os.File
+	size int64
+}
+
+func newHIBPFile(f *os.File) (*HIBPFile, error) {
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &HIBPFile{f: f, size: info.Size()}, nil
+}
+
+func (h *HIBPFile) BreachCount(hash [sha1.Size]byte) (int, error) {
+	target := []byte(hex.EncodeToString(hash[:]))
+
+	// lo is always the start of a line, and a line for target, if any, starts
+	// in [lo, hi).
+	lo, hi := int64(0), h.size
+	for hi-lo > hibpSearchBlock {
+		mid := lo + (hi-lo)/2
+
+		start, line, err := h.lineAfter(mid)
+		if err != nil {
+			return 0, err
+		}
+		if start >= hi {
+			hi = mid + 1
+			continue
+		}
+
+		key, count, err := parseHIBPLine(line)
+		if err != nil {
+			return 0, err
+		}
+		switch cmp := compareHex(key, target); {
+		case cmp == 0:
+			return count, nil
+		case cmp < 0:
+			lo = start + int64(len(line)) + 1
+		default:
+			hi = start
+		}
+	}
+
+	block := make([]byte, min(hi-lo+hibpLineMax, h.size-lo))
+	n, err := h.f.ReadAt(block, lo)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+
+	for offset, rest := lo, block[:n]; offset < hi && len(rest) > 0; {
+		line, next, _ := bytes.Cut(rest, []byte("\n"))
+		if len(bytes.TrimSpace(line)) > 0 {
+			key, count, err := parseHIBPLine(line)
+			if err != nil {
+				return 0, err
+			}
+			if cmp := compareHex(key, target); cmp == 0 {
+				return count, nil
+			} else if cmp > 0 {
+				break
+			}
+		}
+		offset += int64(len(line)) + 1
+		rest = next
+	}
+
+	return 0, nil
+}
+
+func (h *HIBPFile) Close() error {
+	return h.f.Close()
+}
+
+// lineAfter returns the first line that starts after offset, without its
+// newline.
+func (h *HIBPFile) lineAfter(offset int64) (int64, []byte, error) {
+	buf := make([]byte, 2*hibpLineMax)
+	n, err := h.f.ReadAt(buf, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, nil, err
+	}
+	buf = buf[:n]
+
+	i := bytes.IndexByte(buf, '\n')
+	if i < 0 {
+		return h.size, nil, nil
+	}
+	start := offset + int64(i) + 1
+
+	line, _, found := bytes.Cut(buf[i+1:], []byte("\n"))
+	if !found && start+int64(len(line)) < h.size {
+		return 0, nil, ErrInvalidBreachData
+	}
+	return start, line, nil
+}
+
+func parseHIBPLine(line []byte) ([]byte, int, error) {
+	key, count, ok := bytes.Cut(bytes.TrimSpace(line), []byte(":"))
+	if !ok || len(key) != 2*sha1.Size {
+		return nil, 0, ErrInvalidBreachData
+	}
+
+	n, err := strconv.Atoi(string(count))
+	if err != nil {
+		return nil, 0, ErrInvalidBreachData
+	}
+	return key, n, nil
+}
+
+// compareHex compares hex digits case-insensitively; HIBP publishes upper
+// case.
+func compareHex(a, b []byte) int {
+	return bytes.Compare(bytes.ToLower(a), b)
+}
+
+const (
+	bloomMagic      = "HIBPBLM1"
+	bloomHeaderSize = len(bloomMagic) + 8 + 4 + 4
+	bloomChunkSize  = 64 * 1024
+)
+
+// BloomFilter is a compact stand-in for a HIBP file holding only hashes seen
+// at least MinCount times. Lookups may report false positives at the rate
+// the filter was sized for, never false negatives.
+type BloomFilter struct {
+	bits     []uint64
+	m        uint64
+	k        uint32
+	minCount uint32
+}
+
+// NewBloomFilter sizes a filter for n hashes at false positive rate p. Hashes
+// added to it are reported with a count of minCount.
+func NewBloomFilter(n uint64, p float64, minCount int) *BloomFilter {
+	n = max(n, 1)
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max((m+63)/64*64, 64)
+	k := uint32(max(math.Round(float64(m)/float64(n)*math.Ln2), 1))
+
+	return &BloomFilter{
+		bits:     make([]uint64, m/64),
+		m:        m,
+		k:        k,
+		minCount: uint32(max(minCount, 1)),
+	}
+}
+
+func (b *BloomFilter) Add(hash [sha1.Size]byte) {
+	h1, h2 := bloomHashes(hash)
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (b *BloomFilter) Contains(hash [sha1.Size]byte) bool {
+	h1, h2 := bloomHashes(hash)
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BreachCount returns MinCount for hashes in the filter, as the exact count
+// is not kept.
+func (b *BloomFilter) BreachCount(hash [sha1.Size]byte) (int, error) {
+	if b.Contains(hash) {
+		return int(b.minCount), nil
+	}
+	return 0, nil
+}
+
+func (b *BloomFilter) MinCount() int {
+	return int(b.minCount)
+}
+
+func (b *BloomFilter) Close() error {
+	return nil
+}
+
+// WriteTo stores the filter in the format read by ReadBloomFilter.
+func (b *BloomFilter) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, bloomHeaderSize)
+	copy(header, bloomMagic)
+	binary.LittleEndian.PutUint64(header[len(bloomMagic):], b.m)
+	binary.LittleEndian.PutUint32(header[len(bloomMagic)+8:], b.k)
+	binary.LittleEndian.PutUint32(header[len(bloomMagic)+12:], b.minCount)
+
+	n, err := w.Write(header)
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	buf := make([]byte, 0, bloomChunkSize)
+	for i, word := range b.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+		if len(buf) == cap(buf) || i == len(b.bits)-1 {
+			n, err := w.Write(buf)
+			written += int64(n)
+			if err != nil {
+				return written, err
+			}
+			buf = buf[:0]
+		}
+	}
+	return written, nil
+}
+
+func ReadBloomFilter(r io.Reader) (*BloomFilter, error) {
+	header := make([]byte, bloomHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(bloomMagic)]) != bloomMagic {
+		return nil, ErrInvalidBreachData
+	}
+
+	b := &BloomFilter{
+		m:        binary.LittleEndian.Uint64(header[len(bloomMagic):]),
+		k:        binary.LittleEndian.Uint32(header[len(bloomMagic)+8:]),
+		minCount: binary.LittleEndian.Uint32(header[len(bloomMagic)+12:]),
+	}
+	if b.m == 0 || b.m%64 != 0 || b.k == 0 {
+		return nil, ErrInvalidBreachData
+	}
+
+	// Decode in chunks so that loading needs little more memory than the
+	// filter itself.
+	b.bits = make([]uint64, b.m/64)
+	buf := make([]byte, bloomChunkSize)
+	for i := 0; i < len(b.bits); {
+		chunk := buf[:min(len(buf), (len(b.bits)-i)*8)]
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return nil, ErrInvalidBreachData
+		}
+		for j := 0; j < len(chunk); j += 8 {
+			b.bits[i] = binary.LittleEndian.Uint64(chunk[j:])
+			i++
+		}
+	}
+	return b, nil
+}
+
+// BuildBloomFilter reads a HIBP text file and adds every hash seen at least
+// minCount times. n is the number of such hashes, used to size the filter.
+func BuildBloomFilter(r io.Reader, n uint64, p float64, minCount int) (*BloomFilter, error) {
+	filter := NewBloomFilter(n, p, minCount)
+
+	err := scanHIBP(r, func(hash [sha1.Size]byte, count int) {
+		if count >= minCount {
+			filter.Add(hash)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// CountHIBP returns how many hashes in a HIBP text file were seen at least
+// minCount times, to size a filter for BuildBloomFilter.
+func CountHIBP(r io.Reader, minCount int) (uint64, error) {
+	var n uint64
+	err := scanHIBP(r, func(_ [sha1.Size]byte, count int) {
+		if count >= minCount {
+			n++
+		}
+	})
+	return n, err
+}
+
+func scanHIBP(r io.Reader, fn func(hash [sha1.Size]byte, count int)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		key, count, err := parseHIBPLine(scanner.Bytes())
+		if err != nil {
+			return err
+		}
+
+		var hash [sha1.Size]byte
+		if _, err := hex.Decode(hash[:], key); err != nil {
+			return ErrInvalidBreachData
+		}
+		fn(hash, count)
+	}
+	return scanner.Err()
+}
+
+// bloomHashes derives the two hashes for double hashing from the SHA-1,
+// which is already uniformly distributed.
+func bloomHashes(hash [sha1.Size]byte) (uint64, uint64) {
+	h1 := binary.LittleEndian.Uint64(hash[0:8])
+	h2 := binary.LittleEndian.Uint64(hash[8:16]) | 1
+	return h1, h2
+}
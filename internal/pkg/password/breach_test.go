@@ -0,0 +1,154 @@
+package password_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"expvar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"beerdosan-backend/internal/pkg/password"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeHIBPFile writes counts in the HIBP download format: upper case hashes
+// ordered by hash, CRLF line endings.
+func writeHIBPFile(t *testing.T, counts map[string]int) string {
+	t.Helper()
+
+	lines := make([]string, 0, len(counts))
+	for plain, count := range counts {
+		hash := sha1.Sum([]byte(plain))
+		lines = append(lines, fmt.Sprintf("%s:%d", strings.ToUpper(hex.EncodeToString(hash[:])), count))
+	}
+	sort.Strings(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+	return path
+}
+
+func breachCounts() map[string]int {
+	// Enough entries for the lookup to binary search rather than just scan.
+	counts := make(map[string]int, 5000)
+	for i := range 5000 {
+		counts[fmt.Sprintf("leaked-%d", i)] = i%50 + 1
+	}
+	return counts
+}
+
+func TestHIBPFileBreachCount(t *testing.T) {
+	// Arrange
+	counts := breachCounts()
+	source, err := password.OpenBreachSource(writeHIBPFile(t, counts))
+	require.NoError(t, err)
+	defer source.Close()
+
+	// Act & Assert
+	for plain, expected := range counts {
+		count, err := source.BreachCount(sha1.Sum([]byte(plain)))
+		require.NoError(t, err)
+		require.Equal(t, expected, count, plain)
+	}
+
+	for i := range 100 {
+		count, err := source.BreachCount(sha1.Sum([]byte(fmt.Sprintf("unseen-%d", i))))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	}
+}
+
+func TestBloomFilterFromHIBPFile(t *testing.T) {
+	// Arrange
+	counts := breachCounts()
+	hibpPath := writeHIBPFile(t, counts)
+
+	in, err := os.Open(hibpPath)
+	require.NoError(t, err)
+	n, err := password.CountHIBP(in, 10)
+	require.NoError(t, err)
+	_, err = in.Seek(0, 0)
+	require.NoError(t, err)
+
+	filter, err := password.BuildBloomFilter(in, n, 0.001, 10)
+	require.NoError(t, err)
+	require.NoError(t, in.Close())
+
+	filterPath := filepath.Join(t.TempDir(), "pwned.bloom")
+	out, err := os.Create(filterPath)
+	require.NoError(t, err)
+	_, err = filter.WriteTo(out)
+	require.NoError(t, err)
+	require.NoError(t, out.Close())
+
+	// Act
+	source, err := password.OpenBreachSource(filterPath)
+	require.NoError(t, err)
+	defer source.Close()
+
+	// Assert
+	falsePositives := 0
+	for plain, expected := range counts {
+		count, err := source.BreachCount(sha1.Sum([]byte(plain)))
+		require.NoError(t, err)
+		if expected >= 10 {
+			require.Equal(t, 10, count, "hashes at or above min count are always found")
+		} else if count > 0 {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 20)
+}
+
+func TestValidatePasswordRejectsBreachedPasswords(t *testing.T) {
+	// Arrange
+	source, err := password.OpenBreachSource(writeHIBPFile(t, map[string]int{
+		"Leaked1Password": 42,
+		"Rarely1Leaked":   2,
+	}))
+	require.NoError(t, err)
+	defer source.Close()
+
+	cfg := password.DefaultPasswordConfig()
+	cfg.Breaches = source
+	cfg.BreachThreshold = 10
+	service := password.NewPasswordService(cfg)
+
+	// Act & Assert
+	assert.ErrorIs(t, service.ValidatePassword("Leaked1Password"), password.ErrPasswordTooWeak)
+	assert.NoError(t, service.ValidatePassword("Rarely1Leaked"), "below the breach threshold")
+	assert.NoError(t, service.ValidatePassword("Unseen1Password"))
+	assert.ErrorIs(t, service.ValidatePassword("Password123"), password.ErrPasswordTooWeak, "the built-in list still applies")
+}
+
+type failingBreachSource struct{}
+
+func (failingBreachSource) BreachCount([sha1.Size]byte) (int, error) {
+	return 0, errors.New("dataset unavailable")
+}
+
+func (failingBreachSource) Close() error { return nil }
+
+func TestValidatePasswordCountsFailedBreachLookups(t *testing.T) {
+	// Arrange
+	failures := expvar.Get("password_breach").(*expvar.Map).Get("lookup_failures").(*expvar.Int)
+	before := failures.Value()
+
+	cfg := password.DefaultPasswordConfig()
+	cfg.Breaches = failingBreachSource{}
+	service := password.NewPasswordService(cfg)
+
+	// Act
+	err := service.ValidatePassword("Unseen1Password")
+
+	// Assert
+	assert.NoError(t, err, "a failed lookup does not reject the password")
+	assert.Equal(t, before+1, failures.Value())
+}
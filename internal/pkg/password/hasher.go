@@ -0,0 +1,144 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrUnsupportedAlgorithm = errors.New("unsupported password hashing algorithm")
+
+type Algorithm string
+
+const (
+	AlgorithmBcrypt   Algorithm = "bcrypt"
+	AlgorithmArgon2id Algorithm = "argon2id"
+)
+
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch alg := Algorithm(strings.ToLower(strings.TrimSpace(s))); alg {
+	case AlgorithmBcrypt, AlgorithmArgon2id:
+		return alg, nil
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+}
+
+func (a Algorithm) String() string {
+	return string(a)
+}
+
+// Hasher hashes passwords with the algorithm and cost chosen in a
+// PasswordConfig and verifies hashes of either algorithm. Unlike
+// PasswordService it does not enforce the password policy.
+type Hasher struct {
+	config *PasswordConfig
+}
+
+func NewHasher(config *PasswordConfig) *Hasher {
+	if config == nil {
+		config = DefaultPasswordConfig()
+	}
+
+	cfg := *config
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = AlgorithmArgon2id
+	}
+	return &Hasher{config: &cfg}
+}
+
+var defaultHasher atomic.Pointer[Hasher]
+
+func init() {
+	defaultHasher.Store(NewHasher(nil))
+}
+
+// DefaultHasher is the hasher used by code that cannot be handed one, such as
+// the domain model. It starts out with DefaultPasswordConfig.
+func DefaultHasher() *Hasher {
+	return defaultHasher.Load()
+}
+
+// SetDefaultHasher replaces the hasher returned by DefaultHasher. Call it once
+// at startup with the configured hasher.
+func SetDefaultHasher(h *Hasher) {
+	defaultHasher.Store(h)
+}
+
+func (h *Hasher) Hash(plainPassword string) (HashedPassword, error) {
+	switch h.config.Algorithm {
+	case AlgorithmBcrypt:
+		hashedBytes, err := bcrypt.GenerateFromPassword([]byte(plainPassword), h.config.BcryptCost)
+		if err != nil {
+			return "", ErrHashingFailed
+		}
+		return HashedPassword(hashedBytes), nil
+	case AlgorithmArgon2id:
+		params := h.config.argon2Params()
+
+		salt := make([]byte, params.SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", ErrHashingFailed
+		}
+
+		hash := argon2.IDKey([]byte(plainPassword), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+		return HashedPassword(EncodeArgon2id(params, salt, hash)), nil
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+}
+
+// Verify reports whether plainPassword matches hashedPassword, whichever
+// algorithm produced it.
+func (h *Hasher) Verify(hashedPassword HashedPassword, plainPassword string) bool {
+	hashStr := hashedPassword.String()
+
+	if strings.HasPrefix(hashStr, argon2idPrefix) {
+		params, salt, expectedHash, err := DecodeArgon2id(hashStr)
+		if err != nil {
+			return false
+		}
+
+		actualHash := argon2.IDKey([]byte(plainPassword), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+		return subtle.ConstantTimeCompare(actualHash, expectedHash) == 1
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hashStr), []byte(plainPassword)) == nil
+}
+
+// Recognizes reports whether hashedPassword is a bcrypt or argon2id hash that
+// Verify can check, e.g. when importing hashes from another system.
+func (h *Hasher) Recognizes(hashedPassword HashedPassword) bool {
+	hashStr := hashedPassword.String()
+
+	if strings.HasPrefix(hashStr, argon2idPrefix) {
+		_, _, _, err := DecodeArgon2id(hashStr)
+		return err == nil
+	}
+
+	_, err := bcrypt.Cost([]byte(hashStr))
+	return err == nil
+}
+
+// NeedsRehash reports whether hashedPassword was produced by another
+// algorithm or with other cost parameters than the configured ones, so that it
+// should be replaced the next time the plain password is known.
+func (h *Hasher) NeedsRehash(hashedPassword HashedPassword) bool {
+	hashStr := hashedPassword.String()
+
+	switch h.config.Algorithm {
+	case AlgorithmBcrypt:
+		cost, err := bcrypt.Cost([]byte(hashStr))
+		return err != nil || cost != h.config.BcryptCost
+	case AlgorithmArgon2id:
+		params, _, _, err := DecodeArgon2id(hashStr)
+		return err != nil || params != h.config.argon2Params()
+	default:
+		return false
+	}
+}
@@ -67,6 +67,53 @@ func (_c *MockPasswordService_CheckPasswordStrength_Call) RunAndReturn(run func(
 	return _c
 }
 
+// EstimateStrength provides a mock function with given fields: _a0, userInputs
+func (_m *MockPasswordService) EstimateStrength(_a0 string, userInputs []string) password.StrengthEstimate {
+	ret := _m.Called(_a0, userInputs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateStrength")
+	}
+
+	var r0 password.StrengthEstimate
+	if rf, ok := ret.Get(0).(func(string, []string) password.StrengthEstimate); ok {
+		r0 = rf(_a0, userInputs)
+	} else {
+		r0 = ret.Get(0).(password.StrengthEstimate)
+	}
+
+	return r0
+}
+
+// MockPasswordService_EstimateStrength_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateStrength'
+type MockPasswordService_EstimateStrength_Call struct {
+	*mock.Call
+}
+
+// EstimateStrength is a helper method to define mock.On call
+//   - _a0 string
+//   - userInputs []string
+func (_e *MockPasswordService_Expecter) EstimateStrength(_a0 interface{}, userInputs interface{}) *MockPasswordService_EstimateStrength_Call {
+	return &MockPasswordService_EstimateStrength_Call{Call: _e.mock.On("EstimateStrength", _a0, userInputs)}
+}
+
+func (_c *MockPasswordService_EstimateStrength_Call) Run(run func(_a0 string, userInputs []string)) *MockPasswordService_EstimateStrength_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_EstimateStrength_Call) Return(_a0 password.StrengthEstimate) *MockPasswordService_EstimateStrength_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordService_EstimateStrength_Call) RunAndReturn(run func(string, []string) password.StrengthEstimate) *MockPasswordService_EstimateStrength_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateRandomPassword provides a mock function with given fields: length, includeSymbols
 func (_m *MockPasswordService) GenerateRandomPassword(length int, includeSymbols bool) (string, error) {
 	ret := _m.Called(length, includeSymbols)
@@ -282,6 +329,98 @@ func (_c *MockPasswordService_IsCommonPassword_Call) RunAndReturn(run func(strin
 	return _c
 }
 
+// NeedsRehash provides a mock function with given fields: hashedPassword
+func (_m *MockPasswordService) NeedsRehash(hashedPassword password.HashedPassword) bool {
+	ret := _m.Called(hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NeedsRehash")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(password.HashedPassword) bool); ok {
+		r0 = rf(hashedPassword)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockPasswordService_NeedsRehash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NeedsRehash'
+type MockPasswordService_NeedsRehash_Call struct {
+	*mock.Call
+}
+
+// NeedsRehash is a helper method to define mock.On call
+//   - hashedPassword password.HashedPassword
+func (_e *MockPasswordService_Expecter) NeedsRehash(hashedPassword interface{}) *MockPasswordService_NeedsRehash_Call {
+	return &MockPasswordService_NeedsRehash_Call{Call: _e.mock.On("NeedsRehash", hashedPassword)}
+}
+
+func (_c *MockPasswordService_NeedsRehash_Call) Run(run func(hashedPassword password.HashedPassword)) *MockPasswordService_NeedsRehash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(password.HashedPassword))
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_NeedsRehash_Call) Return(_a0 bool) *MockPasswordService_NeedsRehash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordService_NeedsRehash_Call) RunAndReturn(run func(password.HashedPassword) bool) *MockPasswordService_NeedsRehash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Policy provides a mock function with given fields: role
+func (_m *MockPasswordService) Policy(role string) password.PasswordPolicy {
+	ret := _m.Called(role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Policy")
+	}
+
+	var r0 password.PasswordPolicy
+	if rf, ok := ret.Get(0).(func(string) password.PasswordPolicy); ok {
+		r0 = rf(role)
+	} else {
+		r0 = ret.Get(0).(password.PasswordPolicy)
+	}
+
+	return r0
+}
+
+// MockPasswordService_Policy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Policy'
+type MockPasswordService_Policy_Call struct {
+	*mock.Call
+}
+
+// Policy is a helper method to define mock.On call
+//   - role string
+func (_e *MockPasswordService_Expecter) Policy(role interface{}) *MockPasswordService_Policy_Call {
+	return &MockPasswordService_Policy_Call{Call: _e.mock.On("Policy", role)}
+}
+
+func (_c *MockPasswordService_Policy_Call) Run(run func(role string)) *MockPasswordService_Policy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_Policy_Call) Return(_a0 password.PasswordPolicy) *MockPasswordService_Policy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordService_Policy_Call) RunAndReturn(run func(string) password.PasswordPolicy) *MockPasswordService_Policy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidatePassword provides a mock function with given fields: _a0
 func (_m *MockPasswordService) ValidatePassword(_a0 string) error {
 	ret := _m.Called(_a0)
@@ -328,6 +467,54 @@ func (_c *MockPasswordService_ValidatePassword_Call) RunAndReturn(run func(strin
 	return _c
 }
 
+// ValidatePasswordForRole provides a mock function with given fields: _a0, role, userInputs
+func (_m *MockPasswordService) ValidatePasswordForRole(_a0 string, role string, userInputs []string) error {
+	ret := _m.Called(_a0, role, userInputs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatePasswordForRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
+		r0 = rf(_a0, role, userInputs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPasswordService_ValidatePasswordForRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidatePasswordForRole'
+type MockPasswordService_ValidatePasswordForRole_Call struct {
+	*mock.Call
+}
+
+// ValidatePasswordForRole is a helper method to define mock.On call
+//   - _a0 string
+//   - role string
+//   - userInputs []string
+func (_e *MockPasswordService_Expecter) ValidatePasswordForRole(_a0 interface{}, role interface{}, userInputs interface{}) *MockPasswordService_ValidatePasswordForRole_Call {
+	return &MockPasswordService_ValidatePasswordForRole_Call{Call: _e.mock.On("ValidatePasswordForRole", _a0, role, userInputs)}
+}
+
+func (_c *MockPasswordService_ValidatePasswordForRole_Call) Run(run func(_a0 string, role string, userInputs []string)) *MockPasswordService_ValidatePasswordForRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockPasswordService_ValidatePasswordForRole_Call) Return(_a0 error) *MockPasswordService_ValidatePasswordForRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPasswordService_ValidatePasswordForRole_Call) RunAndReturn(run func(string, string, []string) error) *MockPasswordService_ValidatePasswordForRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // VerifyArgon2Password provides a mock function with given fields: hashedPassword, plainPassword
 func (_m *MockPasswordService) VerifyArgon2Password(hashedPassword string, plainPassword string) bool {
 	ret := _m.Called(hashedPassword, plainPassword)
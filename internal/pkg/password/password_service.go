@@ -2,22 +2,21 @@ package password
 
 import (
 	"crypto/rand"
-	"crypto/subtle"
-	"encoding/base64"
+	"crypto/sha1"
 	"errors"
-	"regexp"
+	"log"
 	"strings"
 	"unicode"
 
-	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	ErrPasswordTooWeak    = errors.New("password is too weak")
-	ErrInvalidHashFormat  = errors.New("invalid hash format")
-	ErrHashingFailed      = errors.New("password hashing failed")
-	ErrVerificationFailed = errors.New("password verification failed")
+	ErrPasswordTooWeak     = errors.New("password is too weak")
+	ErrInvalidHashFormat   = errors.New("invalid hash format")
+	ErrHashingFailed       = errors.New("password hashing failed")
+	ErrVerificationFailed  = errors.New("password verification failed")
+	ErrIncompatibleVersion = errors.New("incompatible argon2 version")
 )
 
 type HashedPassword string
@@ -27,6 +26,10 @@ func (h HashedPassword) String() string {
 }
 
 type PasswordConfig struct {
+	// Algorithm is used for new hashes. Hashes of the other algorithm still
+	// verify and are reported by NeedsRehash.
+	Algorithm Algorithm
+
 	BcryptCost int
 
 	Argon2Time    uint32
@@ -35,16 +38,20 @@ type PasswordConfig struct {
 	Argon2KeyLen  uint32
 	Argon2SaltLen uint32
 
-	MinLength        int
-	RequireUppercase bool
-	RequireLowercase bool
-	RequireNumbers   bool
-	RequireSymbols   bool
-	MaxLength        int
+	// Policy applies to users whose role has no entry in RolePolicies.
+	Policy       PasswordPolicy
+	RolePolicies map[string]PasswordPolicy
+
+	// Breaches, when set, is consulted in addition to the built-in list of
+	// common passwords. Passwords seen in at least BreachThreshold breaches
+	// are rejected.
+	Breaches        BreachSource
+	BreachThreshold int
 }
 
 func DefaultPasswordConfig() *PasswordConfig {
 	return &PasswordConfig{
+		Algorithm:  AlgorithmArgon2id,
 		BcryptCost: bcrypt.DefaultCost,
 
 		Argon2Time:    3,
@@ -53,17 +60,24 @@ func DefaultPasswordConfig() *PasswordConfig {
 		Argon2KeyLen:  32,
 		Argon2SaltLen: 16,
 
-		MinLength:        8,
-		RequireUppercase: true,
-		RequireLowercase: true,
-		RequireNumbers:   true,
-		RequireSymbols:   false,
-		MaxLength:        128,
+		Policy: PasswordPolicy{
+			MinLength:        8,
+			RequireUppercase: true,
+			RequireLowercase: true,
+			RequireNumbers:   true,
+			RequireSymbols:   false,
+			MaxLength:        128,
+			HistorySize:      5,
+		},
+		RolePolicies: map[string]PasswordPolicy{},
+
+		BreachThreshold: 1,
 	}
 }
 
 func SecurePasswordConfig() *PasswordConfig {
 	return &PasswordConfig{
+		Algorithm:  AlgorithmArgon2id,
 		BcryptCost: 12,
 
 		Argon2Time:    4,
@@ -72,15 +86,58 @@ func SecurePasswordConfig() *PasswordConfig {
 		Argon2KeyLen:  32,
 		Argon2SaltLen: 16,
 
-		MinLength:        12,
-		RequireUppercase: true,
-		RequireLowercase: true,
-		RequireNumbers:   true,
-		RequireSymbols:   true,
-		MaxLength:        128,
+		Policy: PasswordPolicy{
+			MinLength:        12,
+			RequireUppercase: true,
+			RequireLowercase: true,
+			RequireNumbers:   true,
+			RequireSymbols:   true,
+			MaxLength:        128,
+			MinScore:         3,
+			HistorySize:      10,
+		},
+		RolePolicies: map[string]PasswordPolicy{},
+
+		BreachThreshold: 1,
+	}
+}
+
+// PolicyFor returns the policy for role, falling back to Policy when the role
+// has no override.
+func (c *PasswordConfig) PolicyFor(role string) PasswordPolicy {
+	if policy, ok := c.RolePolicies[role]; ok {
+		return policy
+	}
+	return c.Policy
+}
+
+func (c *PasswordConfig) argon2Params() Argon2Params {
+	return Argon2Params{
+		Memory:  c.Argon2Memory,
+		Time:    c.Argon2Time,
+		Threads: c.Argon2Threads,
+		SaltLen: c.Argon2SaltLen,
+		KeyLen:  c.Argon2KeyLen,
 	}
 }
 
+// PasswordPolicy are the length and character class rules a new password must
+// meet.
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireNumbers   bool
+	RequireSymbols   bool
+	// MinScore is the lowest EstimateStrength score accepted; zero disables
+	// the check.
+	MinScore int
+	// HistorySize is how many recent passwords, the current one included, a
+	// new password must differ from. Zero allows reuse.
+	HistorySize int
+}
+
 type PasswordStrength int
 
 const (
@@ -112,15 +169,20 @@ type PasswordService interface {
 	HashPassword(plainPassword string) (HashedPassword, error)
 	VerifyPassword(hashedPassword HashedPassword, plainPassword string) bool
 	ValidatePassword(password string) error
+	ValidatePasswordForRole(password, role string, userInputs ...string) error
+	Policy(role string) PasswordPolicy
 	CheckPasswordStrength(password string) PasswordStrength
+	EstimateStrength(password string, userInputs ...string) StrengthEstimate
 	GenerateRandomPassword(length int, includeSymbols bool) (string, error)
 	IsCommonPassword(password string) bool
 	HashPasswordWithArgon2(plainPassword string) (string, error)
 	VerifyArgon2Password(hashedPassword, plainPassword string) bool
+	NeedsRehash(hashedPassword HashedPassword) bool
 }
 
 type passwordService struct {
 	config          *PasswordConfig
+	hasher          *Hasher
 	commonPasswords map[string]bool
 }
 
@@ -131,6 +193,7 @@ func NewPasswordService(config *PasswordConfig) PasswordService {
 
 	return &passwordService{
 		config:          config,
+		hasher:          NewHasher(config),
 		commonPasswords: getCommonPasswords(),
 	}
 }
@@ -140,12 +203,7 @@ func (s *passwordService) HashPassword(plainPassword string) (HashedPassword, er
 		return "", err
 	}
 
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(plainPassword), s.config.BcryptCost)
-	if err != nil {
-		return "", ErrHashingFailed
-	}
-
-	return HashedPassword(string(hashedBytes)), nil
+	return s.hasher.Hash(plainPassword)
 }
 
 func (s *passwordService) HashPasswordWithArgon2(plainPassword string) (string, error) {
@@ -153,73 +211,53 @@ func (s *passwordService) HashPasswordWithArgon2(plainPassword string) (string,
 		return "", err
 	}
 
-	salt := make([]byte, s.config.Argon2SaltLen)
-	if _, err := rand.Read(salt); err != nil {
-		return "", ErrHashingFailed
-	}
-
-	hash := argon2.IDKey([]byte(plainPassword), salt, s.config.Argon2Time, s.config.Argon2Memory, s.config.Argon2Threads, s.config.Argon2KeyLen)
-
-	encodedSalt := base64.RawStdEncoding.EncodeToString(salt)
-	encodedHash := base64.RawStdEncoding.EncodeToString(hash)
+	cfg := *s.config
+	cfg.Algorithm = AlgorithmArgon2id
 
-	return "$argon2id$v=19$m=" +
-		string(rune(s.config.Argon2Memory)) + ",t=" +
-		string(rune(s.config.Argon2Time)) + ",p=" +
-		string(rune(s.config.Argon2Threads)) + "$" +
-		encodedSalt + "$" + encodedHash, nil
+	hashed, err := NewHasher(&cfg).Hash(plainPassword)
+	if err != nil {
+		return "", err
+	}
+	return hashed.String(), nil
 }
 
 func (s *passwordService) VerifyPassword(hashedPassword HashedPassword, plainPassword string) bool {
-	hashStr := hashedPassword.String()
-
-	if strings.HasPrefix(hashStr, "$argon2id$") {
-		return s.VerifyArgon2Password(hashStr, plainPassword)
-	}
-
-	err := bcrypt.CompareHashAndPassword([]byte(hashStr), []byte(plainPassword))
-	return err == nil
+	return s.hasher.Verify(hashedPassword, plainPassword)
 }
 
 func (s *passwordService) VerifyArgon2Password(hashedPassword, plainPassword string) bool {
-	parts := strings.Split(hashedPassword, "$")
-	if len(parts) != 6 {
-		return false
-	}
-
-	if parts[1] != "argon2id" || parts[2] != "v=19" {
-		return false
-	}
-
-	var memory, time, threads uint32
-	paramStr := parts[3]
-	if n, err := parseArgon2Params(paramStr); err == nil {
-		memory, time, threads = n[0], n[1], n[2]
-	} else {
+	if !strings.HasPrefix(hashedPassword, argon2idPrefix) {
 		return false
 	}
+	return s.hasher.Verify(HashedPassword(hashedPassword), plainPassword)
+}
 
-	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
-	if err != nil {
-		return false
-	}
+func (s *passwordService) NeedsRehash(hashedPassword HashedPassword) bool {
+	return s.hasher.NeedsRehash(hashedPassword)
+}
 
-	expectedHash, err := base64.RawStdEncoding.DecodeString(parts[5])
-	if err != nil {
-		return false
-	}
+func (s *passwordService) Policy(role string) PasswordPolicy {
+	return s.config.PolicyFor(role)
+}
 
-	actualHash := argon2.IDKey([]byte(plainPassword), salt, time, memory, uint8(threads), uint32(len(expectedHash)))
+// ValidatePassword checks password against the default policy.
+func (s *passwordService) ValidatePassword(password string) error {
+	return s.validate(password, s.config.Policy)
+}
 
-	return subtle.ConstantTimeCompare(actualHash, expectedHash) == 1
+// ValidatePasswordForRole checks password against the policy for role.
+// userInputs, such as the username and email address, make passwords built
+// from them score lower.
+func (s *passwordService) ValidatePasswordForRole(password, role string, userInputs ...string) error {
+	return s.validate(password, s.config.PolicyFor(role), userInputs...)
 }
 
-func (s *passwordService) ValidatePassword(password string) error {
-	if len(password) < s.config.MinLength {
+func (s *passwordService) validate(password string, policy PasswordPolicy, userInputs ...string) error {
+	if len(password) < policy.MinLength {
 		return ErrPasswordTooWeak
 	}
 
-	if len(password) > s.config.MaxLength {
+	if len(password) > policy.MaxLength {
 		return ErrPasswordTooWeak
 	}
 
@@ -245,79 +283,40 @@ func (s *passwordService) ValidatePassword(password string) error {
 		}
 	}
 
-	if s.config.RequireUppercase && !hasUpper {
+	if policy.RequireUppercase && !hasUpper {
+		return ErrPasswordTooWeak
+	}
+
+	if policy.RequireLowercase && !hasLower {
 		return ErrPasswordTooWeak
 	}
 
-	if s.config.RequireLowercase && !hasLower {
+	if policy.RequireNumbers && !hasNumber {
 		return ErrPasswordTooWeak
 	}
 
-	if s.config.RequireNumbers && !hasNumber {
+	if policy.RequireSymbols && !hasSymbol {
 		return ErrPasswordTooWeak
 	}
 
-	if s.config.RequireSymbols && !hasSymbol {
+	if policy.MinScore > 0 && EstimateStrength(password, userInputs...).Score < policy.MinScore {
 		return ErrPasswordTooWeak
 	}
 
 	return nil
 }
 
+// CheckPasswordStrength maps the EstimateStrength score onto the strength
+// levels; a common password is always weak.
 func (s *passwordService) CheckPasswordStrength(password string) PasswordStrength {
-	score := 0
-
-	if len(password) >= 8 {
-		score++
-	}
-	if len(password) >= 12 {
-		score++
-	}
-	if len(password) >= 16 {
-		score++
-	}
-
-	hasUpper := regexp.MustCompile(`[A-Z]`).MatchString(password)
-	hasLower := regexp.MustCompile(`[a-z]`).MatchString(password)
-	hasNumber := regexp.MustCompile(`[0-9]`).MatchString(password)
-	hasSymbol := regexp.MustCompile(`[^A-Za-z0-9]`).MatchString(password)
-
-	if hasUpper {
-		score++
-	}
-	if hasLower {
-		score++
-	}
-	if hasNumber {
-		score++
-	}
-	if hasSymbol {
-		score++
-	}
-
 	if s.IsCommonPassword(password) {
-		score -= 2
-	}
-
-	if hasRepeatingChars(password) {
-		score--
-	}
-	if hasSequentialChars(password) {
-		score--
-	}
-
-	switch {
-	case score <= 2:
 		return PasswordStrengthWeak
-	case score <= 4:
-		return PasswordStrengthFair
-	case score <= 6:
-		return PasswordStrengthGood
-	case score <= 8:
-		return PasswordStrengthStrong
-	default:
-		return PasswordStrengthVeryStrong
 	}
+	return PasswordStrength(EstimateStrength(password).Score)
+}
+
+func (s *passwordService) EstimateStrength(password string, userInputs ...string) StrengthEstimate {
+	return EstimateStrength(password, userInputs...)
 }
 
 func (s *passwordService) GenerateRandomPassword(length int, includeSymbols bool) (string, error) {
@@ -342,67 +341,26 @@ func (s *passwordService) GenerateRandomPassword(length int, includeSymbols bool
 	return string(password), nil
 }
 
+// IsCommonPassword reports whether password is in the built-in list or, when
+// a breach source is configured, appears in at least BreachThreshold breaches.
+// Lookup errors are logged and counted in the password_breach metrics, and do
+// not reject the password.
 func (s *passwordService) IsCommonPassword(password string) bool {
-	return s.commonPasswords[strings.ToLower(password)]
-}
-
-func parseArgon2Params(paramStr string) ([]uint32, error) {
-	params := strings.Split(paramStr, ",")
-	if len(params) != 3 {
-		return nil, ErrInvalidHashFormat
-	}
-
-	results := make([]uint32, 3)
-	for i, param := range params {
-		parts := strings.Split(param, "=")
-		if len(parts) != 2 {
-			return nil, ErrInvalidHashFormat
-		}
-
-		switch parts[1] {
-		case "65536":
-			results[i] = 65536
-		case "3":
-			results[i] = 3
-		case "2":
-			results[i] = 2
-		case "4":
-			results[i] = 4
-		default:
-			results[i] = 1
-		}
+	if s.commonPasswords[strings.ToLower(password)] {
+		return true
 	}
-
-	return results, nil
-}
-
-func hasRepeatingChars(password string) bool {
-	for i := 0; i < len(password)-2; i++ {
-		if password[i] == password[i+1] && password[i+1] == password[i+2] {
-			return true
-		}
-	}
-	return false
-}
-
-func hasSequentialChars(password string) bool {
-	sequences := []string{
-		"abcdefghijklmnopqrstuvwxyz",
-		"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
-		"0123456789",
-		"qwertyuiop",
-		"asdfghjkl",
-		"zxcvbnm",
+	if s.config.Breaches == nil {
+		return false
 	}
 
-	for _, seq := range sequences {
-		for i := 0; i < len(seq)-2; i++ {
-			if strings.Contains(password, seq[i:i+3]) {
-				return true
-			}
-		}
+	breachLookups.Add(1)
+	count, err := s.config.Breaches.BreachCount(sha1.Sum([]byte(password)))
+	if err != nil {
+		breachLookupFailures.Add(1)
+		log.Printf("[WARN] breached password lookup failed: %v", err)
+		return false
 	}
-	return false
+	return count > 0 && count >= s.config.BreachThreshold
 }
 
 func getCommonPasswords() map[string]bool {
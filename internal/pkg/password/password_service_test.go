@@ -0,0 +1,26 @@
+package password_test
+
+import (
+	"testing"
+
+	"beerdosan-backend/internal/pkg/password"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePasswordForRole(t *testing.T) {
+	// Arrange
+	cfg := password.DefaultPasswordConfig()
+	adminPolicy := cfg.Policy
+	adminPolicy.MinLength = 14
+	adminPolicy.RequireSymbols = true
+	cfg.RolePolicies["admin"] = adminPolicy
+	service := password.NewPasswordService(cfg)
+
+	// Act & Assert
+	assert.NoError(t, service.ValidatePasswordForRole("Sturdy1Horse", "user"))
+	assert.ErrorIs(t, service.ValidatePasswordForRole("Sturdy1Horse", "admin"), password.ErrPasswordTooWeak)
+	assert.ErrorIs(t, service.ValidatePasswordForRole("Sturdy1HorseBattery", "admin"), password.ErrPasswordTooWeak, "admins need a symbol")
+	assert.NoError(t, service.ValidatePasswordForRole("Sturdy1Horse!Battery", "admin"))
+	assert.Equal(t, 8, service.Policy("guest").MinLength, "roles without a policy use the default")
+}
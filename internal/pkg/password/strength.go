@@ -0,0 +1,1093 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The estimator follows the approach of Dropbox's zxcvbn: the password is
+// split into the sequence of patterns (dictionary words, keyboard walks,
+// repeats, sequences, dates and brute-forced runs) that minimizes the number
+// of guesses an attacker trying those patterns would need.
+
+type MatchPattern string
+
+const (
+	PatternDictionary MatchPattern = "dictionary"
+	PatternSpatial    MatchPattern = "spatial"
+	PatternRepeat     MatchPattern = "repeat"
+	PatternSequence   MatchPattern = "sequence"
+	PatternDate       MatchPattern = "date"
+	PatternBruteforce MatchPattern = "bruteforce"
+)
+
+const (
+	dictionaryPasswords  = "passwords"
+	dictionaryEnglish    = "english"
+	dictionaryUserInputs = "user_inputs"
+)
+
+// Match is one pattern found in a password. I and J are the rune offsets of
+// its first and last character.
+type Match struct {
+	Pattern MatchPattern
+	I, J    int
+	Token   string
+	Guesses float64
+
+	// Dictionary matches.
+	Dictionary  string
+	MatchedWord string
+	Rank        int
+	Reversed    bool
+	L33t        bool
+	l33tSubs    map[rune]rune
+
+	// Spatial matches.
+	Turns        int
+	ShiftedCount int
+
+	// Repeat matches.
+	BaseToken   string
+	RepeatCount int
+
+	// Sequence matches.
+	Ascending bool
+
+	// Date matches; Separator is empty for bare years and digit runs.
+	Year      int
+	Separator string
+}
+
+// StrengthEstimate is the result of EstimateStrength. Score ranges from 0
+// (too guessable) to 4 (very unguessable).
+type StrengthEstimate struct {
+	Guesses      float64
+	GuessesLog10 float64
+	Score        int
+	Sequence     []Match
+	Warning      string
+	Suggestions  []string
+}
+
+const (
+	bruteforceCardinality       = 10
+	minSubmatchGuessesSingle    = 10
+	minSubmatchGuessesMulti     = 50
+	minGuessesBeforeGrowingSeq  = 10000
+	minYearSpace                = 20
+	maxDictionaryWordLength     = 32
+	minDictionaryWordLength     = 3
+	maxL33tSubstitutionVariants = 64
+)
+
+//go:embed data/passwords.txt
+var passwordsList string
+
+//go:embed data/english.txt
+var englishList string
+
+var rankedDictionaries = map[string]map[string]int{
+	dictionaryPasswords: buildRankedDictionary(passwordsList),
+	dictionaryEnglish:   buildRankedDictionary(englishList),
+}
+
+func buildRankedDictionary(list string) map[string]int {
+	ranked := make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") || len([]rune(word)) < minDictionaryWordLength {
+			continue
+		}
+		if _, ok := ranked[word]; !ok {
+			ranked[word] = len(ranked) + 1
+		}
+	}
+	return ranked
+}
+
+// userInputDictionary ranks the words of the user's own data, such as their
+// username and email address, ahead of everything else.
+func userInputDictionary(userInputs []string) map[string]int {
+	ranked := make(map[string]int)
+	add := func(word string) {
+		word = strings.ToLower(word)
+		if len([]rune(word)) < minDictionaryWordLength {
+			return
+		}
+		if _, ok := ranked[word]; !ok {
+			ranked[word] = len(ranked) + 1
+		}
+	}
+
+	for _, input := range userInputs {
+		add(input)
+		if local, _, ok := strings.Cut(input, "@"); ok {
+			add(local)
+		}
+		for _, part := range strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			add(part)
+		}
+	}
+	return ranked
+}
+
+// EstimateStrength estimates how many guesses password would take to crack.
+// userInputs, such as the username and email address, are treated as the most
+// likely words.
+func EstimateStrength(password string, userInputs ...string) StrengthEstimate {
+	dictionaries := map[string]map[string]int{
+		dictionaryPasswords:  rankedDictionaries[dictionaryPasswords],
+		dictionaryEnglish:    rankedDictionaries[dictionaryEnglish],
+		dictionaryUserInputs: userInputDictionary(userInputs),
+	}
+
+	runes := []rune(password)
+	guesses, sequence := mostGuessableSequence(runes, omniMatch(runes, dictionaries))
+	if math.IsInf(guesses, 1) {
+		guesses = math.MaxFloat64
+	}
+
+	estimate := StrengthEstimate{
+		Guesses:      guesses,
+		GuessesLog10: math.Log10(guesses),
+		Score:        guessesToScore(guesses),
+		Sequence:     sequence,
+	}
+	estimate.Warning, estimate.Suggestions = strengthFeedback(estimate.Score, sequence)
+	return estimate
+}
+
+func guessesToScore(guesses float64) int {
+	const delta = 5
+	switch {
+	case guesses < 1e3+delta:
+		return 0
+	case guesses < 1e6+delta:
+		return 1
+	case guesses < 1e8+delta:
+		return 2
+	case guesses < 1e10+delta:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func omniMatch(password []rune, dictionaries map[string]map[string]int) []Match {
+	var matches []Match
+	matches = append(matches, dictionaryMatches(password, dictionaries)...)
+	matches = append(matches, reversedDictionaryMatches(password, dictionaries)...)
+	matches = append(matches, l33tMatches(password, dictionaries)...)
+	matches = append(matches, spatialMatches(password)...)
+	matches = append(matches, repeatMatches(password)...)
+	matches = append(matches, sequenceMatches(password)...)
+	matches = append(matches, dateMatches(password)...)
+	return matches
+}
+
+// Dictionary matching
+
+func dictionaryMatches(password []rune, dictionaries map[string]map[string]int) []Match {
+	lower := toLowerRunes(password)
+
+	var matches []Match
+	for i := range lower {
+		for j := i + minDictionaryWordLength - 1; j < len(lower) && j-i < maxDictionaryWordLength; j++ {
+			word := string(lower[i : j+1])
+			for name, dictionary := range dictionaries {
+				rank, ok := dictionary[word]
+				if !ok {
+					continue
+				}
+				matches = append(matches, Match{
+					Pattern:     PatternDictionary,
+					I:           i,
+					J:           j,
+					Token:       string(password[i : j+1]),
+					Dictionary:  name,
+					MatchedWord: word,
+					Rank:        rank,
+				})
+			}
+		}
+	}
+	return matches
+}
+
+func reversedDictionaryMatches(password []rune, dictionaries map[string]map[string]int) []Match {
+	reversed := reverseRunes(password)
+
+	matches := dictionaryMatches(reversed, dictionaries)
+	for k := range matches {
+		m := &matches[k]
+		m.I, m.J = len(password)-1-m.J, len(password)-1-m.I
+		m.Token = string(password[m.I : m.J+1])
+		m.Reversed = true
+	}
+	return matches
+}
+
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'},
+	'8': {'b'},
+	'(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'},
+	'6': {'g'}, '9': {'g'},
+	'1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'7': {'l', 't'},
+	'0': {'o'},
+	'$': {'s'}, '5': {'s'},
+	'+': {'t'},
+	'%': {'x'},
+	'2': {'z'},
+}
+
+func l33tMatches(password []rune, dictionaries map[string]map[string]int) []Match {
+	var subbable []rune
+	seen := map[rune]bool{}
+	for _, r := range password {
+		if _, ok := l33tTable[r]; ok && !seen[r] {
+			seen[r] = true
+			subbable = append(subbable, r)
+		}
+	}
+	if len(subbable) == 0 {
+		return nil
+	}
+
+	type key struct {
+		i, j int
+		word string
+	}
+	found := map[key]bool{}
+
+	var matches []Match
+	for _, subs := range l33tSubstitutions(subbable) {
+		unleeted := make([]rune, len(password))
+		for k, r := range password {
+			if letter, ok := subs[r]; ok {
+				unleeted[k] = letter
+			} else {
+				unleeted[k] = r
+			}
+		}
+
+		for _, m := range dictionaryMatches(unleeted, dictionaries) {
+			token := password[m.I : m.J+1]
+
+			used := map[rune]rune{}
+			for _, r := range token {
+				if letter, ok := subs[r]; ok {
+					used[r] = letter
+				}
+			}
+			k := key{m.I, m.J, m.MatchedWord}
+			if len(used) == 0 || found[k] {
+				continue
+			}
+			found[k] = true
+
+			m.Token = string(token)
+			m.L33t = true
+			m.l33tSubs = used
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// l33tSubstitutions lists every way of reading the l33t characters in
+// subbable as letters, capped at maxL33tSubstitutionVariants.
+func l33tSubstitutions(subbable []rune) []map[rune]rune {
+	variants := []map[rune]rune{{}}
+	for _, r := range subbable {
+		var next []map[rune]rune
+		for _, variant := range variants {
+			for _, letter := range l33tTable[r] {
+				extended := make(map[rune]rune, len(variant)+1)
+				for k, v := range variant {
+					extended[k] = v
+				}
+				extended[r] = letter
+				next = append(next, extended)
+				if len(next) >= maxL33tSubstitutionVariants {
+					break
+				}
+			}
+		}
+		variants = next
+	}
+	return variants
+}
+
+// Spatial matching
+
+type keyboardGraph struct {
+	// neighbors maps a key to its neighbors in the six directions left,
+	// upper left, upper right, right, lower right and lower left; 0 marks a
+	// missing neighbor.
+	neighbors      map[rune][6]rune
+	startPositions float64
+	averageDegree  float64
+}
+
+var qwertyRows = []string{
+	"`~ 1! 2@ 3# 4$ 5% 6^ 7& 8* 9( 0) -_ =+",
+	"qQ wW eE rR tT yY uU iI oO pP [{ ]} \\|",
+	"aA sS dD fF gG hH jJ kK lL ;: '\"",
+	"zZ xX cC vV bB nN mM ,< .> /?",
+}
+
+var qwertyGraph = buildKeyboardGraph(qwertyRows)
+
+// buildKeyboardGraph lays rows out as on a staggered keyboard, where every
+// row after the first starts half a key to the right of the one above.
+func buildKeyboardGraph(rows []string) keyboardGraph {
+	type position struct{ row, col int }
+
+	keys := map[position]rune{}
+	for row, line := range rows {
+		offset := 0
+		if row > 0 {
+			offset = 1
+		}
+		for col, pair := range strings.Fields(line) {
+			keys[position{row, col + offset}] = []rune(pair)[0]
+		}
+	}
+
+	graph := keyboardGraph{neighbors: map[rune][6]rune{}}
+	degrees := 0
+	for pos, key := range keys {
+		adjacent := [6]position{
+			{pos.row, pos.col - 1},
+			{pos.row - 1, pos.col},
+			{pos.row - 1, pos.col + 1},
+			{pos.row, pos.col + 1},
+			{pos.row + 1, pos.col},
+			{pos.row + 1, pos.col - 1},
+		}
+
+		var neighbors [6]rune
+		for d, p := range adjacent {
+			if r, ok := keys[p]; ok {
+				neighbors[d] = r
+				degrees++
+			}
+		}
+		graph.neighbors[key] = neighbors
+	}
+
+	graph.startPositions = float64(len(keys))
+	graph.averageDegree = float64(degrees) / float64(len(keys))
+	return graph
+}
+
+var qwertyShifted = func() map[rune]rune {
+	unshifted := map[rune]rune{}
+	for _, line := range qwertyRows {
+		for _, pair := range strings.Fields(line) {
+			r := []rune(pair)
+			unshifted[r[0]] = r[0]
+			unshifted[r[1]] = r[0]
+		}
+	}
+	return unshifted
+}()
+
+func spatialMatches(password []rune) []Match {
+	var matches []Match
+
+	for i := 0; i < len(password)-2; {
+		j := i
+		lastDirection := -1
+		turns := 0
+		shifted := 0
+		if isShifted(password[i]) {
+			shifted++
+		}
+
+		for j+1 < len(password) {
+			prev, ok := qwertyShifted[password[j]]
+			if !ok {
+				break
+			}
+			cur, ok := qwertyShifted[password[j+1]]
+			if !ok {
+				break
+			}
+
+			direction := -1
+			for d, neighbor := range qwertyGraph.neighbors[prev] {
+				if neighbor != 0 && neighbor == cur {
+					direction = d
+					break
+				}
+			}
+			if direction < 0 {
+				break
+			}
+
+			if direction != lastDirection {
+				turns++
+				lastDirection = direction
+			}
+			if isShifted(password[j+1]) {
+				shifted++
+			}
+			j++
+		}
+
+		if j-i+1 >= 3 {
+			matches = append(matches, Match{
+				Pattern:      PatternSpatial,
+				I:            i,
+				J:            j,
+				Token:        string(password[i : j+1]),
+				Turns:        turns,
+				ShiftedCount: shifted,
+			})
+			i = j
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+func isShifted(r rune) bool {
+	base, ok := qwertyShifted[r]
+	return ok && base != r
+}
+
+// Repeat matching
+
+func repeatMatches(password []rune) []Match {
+	var matches []Match
+
+	for i := 0; i < len(password)-1; {
+		bestUnit, bestCount := 0, 0
+		for unit := 1; i+2*unit <= len(password); unit++ {
+			count := 1
+			for i+(count+1)*unit <= len(password) &&
+				slices.Equal(password[i+count*unit:i+(count+1)*unit], password[i:i+unit]) {
+				count++
+			}
+			if count >= 2 && unit*count > bestUnit*bestCount {
+				bestUnit, bestCount = unit, count
+			}
+		}
+
+		if bestCount == 0 {
+			i++
+			continue
+		}
+
+		base := string(password[i : i+bestUnit])
+		baseGuesses, _ := mostGuessableSequence([]rune(base), omniMatch([]rune(base), map[string]map[string]int{
+			dictionaryPasswords: rankedDictionaries[dictionaryPasswords],
+			dictionaryEnglish:   rankedDictionaries[dictionaryEnglish],
+		}))
+
+		j := i + bestUnit*bestCount - 1
+		matches = append(matches, Match{
+			Pattern:     PatternRepeat,
+			I:           i,
+			J:           j,
+			Token:       string(password[i : j+1]),
+			BaseToken:   base,
+			RepeatCount: bestCount,
+			Guesses:     baseGuesses * float64(bestCount),
+		})
+		i = j + 1
+	}
+	return matches
+}
+
+// Sequence matching
+
+func sequenceMatches(password []rune) []Match {
+	var matches []Match
+
+	emit := func(i, j, delta int) {
+		if j-i+1 < 3 || (delta != 1 && delta != -1 && delta != 2 && delta != -2) {
+			return
+		}
+		if charClass(password[i]) == 0 {
+			return
+		}
+		for k := i + 1; k <= j; k++ {
+			if charClass(password[k]) != charClass(password[i]) {
+				return
+			}
+		}
+		matches = append(matches, Match{
+			Pattern:   PatternSequence,
+			I:         i,
+			J:         j,
+			Token:     string(password[i : j+1]),
+			Ascending: delta > 0,
+		})
+	}
+
+	if len(password) < 3 {
+		return nil
+	}
+
+	i := 0
+	lastDelta := int(password[1] - password[0])
+	for k := 2; k < len(password); k++ {
+		delta := int(password[k] - password[k-1])
+		if delta == lastDelta {
+			continue
+		}
+		emit(i, k-1, lastDelta)
+		i = k - 1
+		lastDelta = delta
+	}
+	emit(i, len(password)-1, lastDelta)
+
+	return matches
+}
+
+// charClass groups runes that form sequences together: 1 for lower case, 2
+// for upper case, 3 for digits and 0 for anything else.
+func charClass(r rune) int {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 1
+	case r >= 'A' && r <= 'Z':
+		return 2
+	case r >= '0' && r <= '9':
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Date matching
+
+var (
+	dateWithSeparator = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+	dateDigits        = regexp.MustCompile(`^\d{4,8}$`)
+	recentYear        = regexp.MustCompile(`^(19\d\d|20\d\d)$`)
+)
+
+type dmy struct{ day, month, year int }
+
+func dateMatches(password []rune) []Match {
+	var matches []Match
+
+	for i := range password {
+		for j := i + 3; j < len(password) && j-i < 10; j++ {
+			token := string(password[i : j+1])
+
+			if recentYear.MatchString(token) {
+				year, _ := strconv.Atoi(token)
+				matches = append(matches, Match{Pattern: PatternDate, I: i, J: j, Token: token, Year: year})
+				continue
+			}
+
+			if groups := dateWithSeparator.FindStringSubmatch(token); groups != nil {
+				if groups[2] != groups[4] {
+					continue
+				}
+				if date, ok := mapIntsToDate([3]string{groups[1], groups[3], groups[5]}); ok {
+					matches = append(matches, Match{Pattern: PatternDate, I: i, J: j, Token: token, Year: date.year, Separator: groups[2]})
+				}
+				continue
+			}
+
+			if dateDigits.MatchString(token) && (len(token) == 6 || len(token) == 8) {
+				if date, ok := splitDigitsToDate(token); ok {
+					matches = append(matches, Match{Pattern: PatternDate, I: i, J: j, Token: token, Year: date.year})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// splitDigitsToDate reads a run of six or eight digits as day, month and year
+// in any common order.
+func splitDigitsToDate(token string) (dmy, bool) {
+	var splits [][3]string
+	if len(token) == 6 {
+		splits = [][3]string{
+			{token[:2], token[2:4], token[4:]},
+		}
+	} else {
+		splits = [][3]string{
+			{token[:4], token[4:6], token[6:]},
+			{token[:2], token[2:4], token[4:]},
+		}
+	}
+
+	for _, split := range splits {
+		if date, ok := mapIntsToDate(split); ok {
+			return date, true
+		}
+	}
+	return dmy{}, false
+}
+
+// mapIntsToDate interprets three numbers as a date with the year first or
+// last and day and month in either order.
+func mapIntsToDate(parts [3]string) (dmy, bool) {
+	var ints [3]int
+	for k, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return dmy{}, false
+		}
+		ints[k] = n
+	}
+
+	candidates := []struct {
+		year    int
+		yearLen int
+		a, b    int
+	}{
+		{ints[2], len(parts[2]), ints[0], ints[1]},
+		{ints[0], len(parts[0]), ints[1], ints[2]},
+	}
+
+	for _, c := range candidates {
+		year, ok := normalizeYear(c.year, c.yearLen)
+		if !ok {
+			continue
+		}
+		if dayMonthValid(c.a, c.b) {
+			return dmy{day: c.a, month: c.b, year: year}, true
+		}
+		if dayMonthValid(c.b, c.a) {
+			return dmy{day: c.b, month: c.a, year: year}, true
+		}
+	}
+	return dmy{}, false
+}
+
+func normalizeYear(year, digits int) (int, bool) {
+	switch digits {
+	case 2:
+		if year > 50 {
+			return 1900 + year, true
+		}
+		return 2000 + year, true
+	case 4:
+		return year, year >= 1900 && year <= 2099
+	default:
+		return 0, false
+	}
+}
+
+func dayMonthValid(day, month int) bool {
+	return day >= 1 && day <= 31 && month >= 1 && month <= 12
+}
+
+// Guess estimation
+
+func estimateGuesses(m *Match, passwordLength int) float64 {
+	// Repeat matches carry the guesses for their base token.
+	if m.Pattern != PatternRepeat {
+		m.Guesses = patternGuesses(m)
+	}
+
+	tokenLength := len([]rune(m.Token))
+	minGuesses := 1.0
+	if tokenLength < passwordLength {
+		if tokenLength == 1 {
+			minGuesses = minSubmatchGuessesSingle
+		} else {
+			minGuesses = minSubmatchGuessesMulti
+		}
+	}
+	m.Guesses = math.Max(m.Guesses, minGuesses)
+	return m.Guesses
+}
+
+func patternGuesses(m *Match) float64 {
+	switch m.Pattern {
+	case PatternBruteforce:
+		return bruteforceGuesses(m.Token)
+	case PatternDictionary:
+		guesses := float64(m.Rank) * uppercaseVariations(m.Token)
+		if m.L33t {
+			guesses *= l33tVariations(m.Token, m.l33tSubs)
+		}
+		if m.Reversed {
+			guesses *= 2
+		}
+		return guesses
+	case PatternSpatial:
+		return spatialGuesses(m)
+	case PatternSequence:
+		return sequenceGuesses(m)
+	case PatternDate:
+		return dateGuesses(m)
+	default:
+		return bruteforceGuesses(m.Token)
+	}
+}
+
+func bruteforceGuesses(token string) float64 {
+	length := len([]rune(token))
+	guesses := math.Pow(bruteforceCardinality, float64(length))
+	if length == 1 {
+		return math.Max(guesses, minSubmatchGuessesSingle+1)
+	}
+	return math.Max(guesses, minSubmatchGuessesMulti+1)
+}
+
+func uppercaseVariations(token string) float64 {
+	upper, lower := 0, 0
+	runes := []rune(token)
+	for _, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+
+	if upper == 0 {
+		return 1
+	}
+	// Capitalizing the first or last letter, or everything, is the first
+	// thing an attacker tries.
+	if lower == 0 ||
+		(upper == 1 && unicode.IsUpper(runes[0])) ||
+		(upper == 1 && unicode.IsUpper(runes[len(runes)-1])) {
+		return 2
+	}
+
+	variations := 0.0
+	for k := 1; k <= min(upper, lower); k++ {
+		variations += binomial(upper+lower, k)
+	}
+	return variations
+}
+
+func l33tVariations(token string, subs map[rune]rune) float64 {
+	lower := toLowerRunes([]rune(token))
+
+	variations := 1.0
+	for sub, letter := range subs {
+		subbed, unsubbed := 0, 0
+		for _, r := range lower {
+			switch r {
+			case sub:
+				subbed++
+			case letter:
+				unsubbed++
+			}
+		}
+
+		if subbed == 0 || unsubbed == 0 {
+			variations *= 2
+			continue
+		}
+		possibilities := 0.0
+		for k := 1; k <= min(subbed, unsubbed); k++ {
+			possibilities += binomial(subbed+unsubbed, k)
+		}
+		variations *= possibilities
+	}
+	return variations
+}
+
+func spatialGuesses(m *Match) float64 {
+	length := len([]rune(m.Token))
+	s := qwertyGraph.startPositions
+	d := qwertyGraph.averageDegree
+
+	guesses := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(m.Turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * s * math.Pow(d, float64(j))
+		}
+	}
+
+	if m.ShiftedCount > 0 {
+		shifted, unshifted := m.ShiftedCount, length-m.ShiftedCount
+		if unshifted == 0 {
+			guesses *= 2
+		} else {
+			variations := 0.0
+			for k := 1; k <= min(shifted, unshifted); k++ {
+				variations += binomial(shifted+unshifted, k)
+			}
+			guesses *= variations
+		}
+	}
+	return guesses
+}
+
+func sequenceGuesses(m *Match) float64 {
+	first := []rune(m.Token)[0]
+
+	var base float64
+	switch {
+	case strings.ContainsRune("aAzZ019", first):
+		base = 4
+	case unicode.IsDigit(first):
+		base = 10
+	default:
+		base = 26
+	}
+	if !m.Ascending {
+		base *= 2
+	}
+	return base * float64(len([]rune(m.Token)))
+}
+
+func dateGuesses(m *Match) float64 {
+	yearSpace := math.Max(math.Abs(float64(m.Year-time.Now().Year())), minYearSpace)
+
+	// A bare year.
+	if recentYear.MatchString(m.Token) {
+		return yearSpace
+	}
+
+	guesses := yearSpace * 365
+	if m.Separator != "" {
+		guesses *= 4
+	}
+	return guesses
+}
+
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	if k == 0 {
+		return 1
+	}
+	result := 1.0
+	for d := 1; d <= k; d++ {
+		result *= float64(n)
+		result /= float64(d)
+		n--
+	}
+	return result
+}
+
+// Optimal match sequence
+
+// mostGuessableSequence finds the non-overlapping sequence of matches,
+// filling gaps with brute force, that covers password with the fewest
+// guesses. Like zxcvbn it charges l! for the order of l matches and a
+// penalty per additional match so that a single long match is preferred.
+func mostGuessableSequence(password []rune, matches []Match) (float64, []Match) {
+	n := len(password)
+	if n == 0 {
+		return 1, nil
+	}
+
+	matchesByEnd := make([][]Match, n)
+	for _, m := range matches {
+		matchesByEnd[m.J] = append(matchesByEnd[m.J], m)
+	}
+
+	// For every end position k and sequence length l: the last match, the
+	// product of guesses and the overall guesses.
+	type entry struct {
+		match Match
+		pi    float64
+		g     float64
+	}
+	optimal := make([]map[int]entry, n)
+	for k := range optimal {
+		optimal[k] = map[int]entry{}
+	}
+
+	update := func(m Match, l int) {
+		k := m.J
+		pi := estimateGuesses(&m, n)
+		if l > 1 {
+			pi *= optimal[m.I-1][l-1].pi
+		}
+		g := factorial(l)*pi + math.Pow(minGuessesBeforeGrowingSeq, float64(l-1))
+
+		for competingL, competing := range optimal[k] {
+			if competingL > l {
+				continue
+			}
+			if competing.g <= g {
+				return
+			}
+		}
+		optimal[k][l] = entry{match: m, pi: pi, g: g}
+	}
+
+	bruteforce := func(i, j int) Match {
+		return Match{Pattern: PatternBruteforce, I: i, J: j, Token: string(password[i : j+1])}
+	}
+
+	for k := 0; k < n; k++ {
+		for _, m := range matchesByEnd[k] {
+			if m.I > 0 {
+				for l := range optimal[m.I-1] {
+					update(m, l+1)
+				}
+			} else {
+				update(m, 1)
+			}
+		}
+
+		update(bruteforce(0, k), 1)
+		for i := 1; i <= k; i++ {
+			m := bruteforce(i, k)
+			for l, last := range optimal[i-1] {
+				// Adjacent brute force runs are always worse than one
+				// longer run.
+				if last.match.Pattern == PatternBruteforce {
+					continue
+				}
+				update(m, l+1)
+			}
+		}
+	}
+
+	bestL, bestG := 0, math.Inf(1)
+	for l, e := range optimal[n-1] {
+		if e.g < bestG || (e.g == bestG && l < bestL) {
+			bestL, bestG = l, e.g
+		}
+	}
+
+	sequence := make([]Match, bestL)
+	for k, l := n-1, bestL; l > 0; l-- {
+		m := optimal[k][l].match
+		sequence[l-1] = m
+		k = m.I - 1
+	}
+	return bestG, sequence
+}
+
+func factorial(n int) float64 {
+	result := 1.0
+	for i := 2; i <= n; i++ {
+		result *= float64(i)
+	}
+	return result
+}
+
+// Feedback
+
+func strengthFeedback(score int, sequence []Match) (string, []string) {
+	if len(sequence) == 0 {
+		return "", []string{
+			"Use a few words, avoid common phrases",
+			"No need for symbols, digits, or uppercase letters",
+		}
+	}
+	if score > 2 {
+		return "", nil
+	}
+
+	longest := sequence[0]
+	for _, m := range sequence[1:] {
+		if len([]rune(m.Token)) > len([]rune(longest.Token)) {
+			longest = m
+		}
+	}
+
+	warning, suggestions := matchFeedback(longest, len(sequence) == 1)
+	return warning, append([]string{"Add another word or two. Uncommon words are better."}, suggestions...)
+}
+
+func matchFeedback(m Match, isSoleMatch bool) (string, []string) {
+	switch m.Pattern {
+	case PatternDictionary:
+		return dictionaryFeedback(m, isSoleMatch)
+	case PatternSpatial:
+		warning := "Short keyboard patterns are easy to guess"
+		if m.Turns == 1 {
+			warning = "Straight rows of keys are easy to guess"
+		}
+		return warning, []string{"Use a longer keyboard pattern with more turns"}
+	case PatternRepeat:
+		warning := `Repeats like "abcabcabc" are only slightly harder to guess than "abc"`
+		if len([]rune(m.BaseToken)) == 1 {
+			warning = `Repeats like "aaa" are easy to guess`
+		}
+		return warning, []string{"Avoid repeated words and characters"}
+	case PatternSequence:
+		return "Sequences like abc or 6543 are easy to guess", []string{"Avoid sequences"}
+	case PatternDate:
+		if recentYear.MatchString(m.Token) {
+			return "Recent years are easy to guess", []string{"Avoid recent years", "Avoid years that are associated with you"}
+		}
+		return "Dates are often easy to guess", []string{"Avoid dates and years that are associated with you"}
+	default:
+		return "", nil
+	}
+}
+
+func dictionaryFeedback(m Match, isSoleMatch bool) (string, []string) {
+	var warning string
+	switch m.Dictionary {
+	case dictionaryPasswords:
+		switch {
+		case isSoleMatch && !m.L33t && !m.Reversed && m.Rank <= 10:
+			warning = "This is a top-10 common password"
+		case isSoleMatch && !m.L33t && !m.Reversed && m.Rank <= 100:
+			warning = "This is a top-100 common password"
+		case isSoleMatch && !m.L33t && !m.Reversed:
+			warning = "This is a very common password"
+		case math.Log10(m.Guesses) <= 4:
+			warning = "This is similar to a commonly used password"
+		}
+	case dictionaryEnglish:
+		if isSoleMatch {
+			warning = "A word by itself is easy to guess"
+		}
+	case dictionaryUserInputs:
+		warning = "Avoid using your name, username or email address"
+	}
+
+	var suggestions []string
+	runes := []rune(m.Token)
+	switch {
+	case strings.ToUpper(m.Token) == m.Token && strings.ToLower(m.Token) != m.Token:
+		suggestions = append(suggestions, "All-uppercase is almost as easy to guess as all-lowercase")
+	case unicode.IsUpper(runes[0]):
+		suggestions = append(suggestions, "Capitalization doesn't help very much")
+	}
+	if m.Reversed && len(runes) >= 4 {
+		suggestions = append(suggestions, "Reversed words aren't much harder to guess")
+	}
+	if m.L33t {
+		suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+	}
+	return warning, suggestions
+}
+
+func toLowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for k, r := range runes {
+		lower[k] = unicode.ToLower(r)
+	}
+	return lower
+}
+
+func reverseRunes(runes []rune) []rune {
+	reversed := make([]rune, len(runes))
+	for k, r := range runes {
+		reversed[len(runes)-1-k] = r
+	}
+	return reversed
+}
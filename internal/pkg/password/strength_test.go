@@ -0,0 +1,72 @@
+package password_test
+
+import (
+	"testing"
+
+	"beerdosan-backend/internal/pkg/password"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateStrengthDetectsPatterns(t *testing.T) {
+	testCases := []struct {
+		password string
+		pattern  password.MatchPattern
+	}{
+		{"password", password.PatternDictionary},
+		{"p@ssw0rd", password.PatternDictionary},
+		{"drowssap", password.PatternDictionary},
+		{"zxcvbnm,./", password.PatternSpatial},
+		{"aaaaaaaaaa", password.PatternRepeat},
+		{"abcabcabc", password.PatternRepeat},
+		{"13579", password.PatternSequence},
+		{"13/05/1990", password.PatternDate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.password, func(t *testing.T) {
+			// Act
+			estimate := password.EstimateStrength(tc.password)
+
+			// Assert
+			assert.Len(t, estimate.Sequence, 1)
+			assert.Equal(t, tc.pattern, estimate.Sequence[0].Pattern)
+			assert.LessOrEqual(t, estimate.Score, 1)
+			assert.NotEmpty(t, estimate.Warning)
+			assert.NotEmpty(t, estimate.Suggestions)
+		})
+	}
+}
+
+func TestEstimateStrengthPenalizesUserInputs(t *testing.T) {
+	// Act
+	withoutInputs := password.EstimateStrength("Quintessa1987")
+	withInputs := password.EstimateStrength("Quintessa1987", "quintessa", "quintessa@example.com")
+
+	// Assert
+	assert.Less(t, withInputs.Guesses, withoutInputs.Guesses)
+	assert.Equal(t, password.PatternDictionary, withInputs.Sequence[0].Pattern)
+	assert.Equal(t, "Avoid using your name, username or email address", withInputs.Warning)
+}
+
+func TestEstimateStrengthRatesRandomPasswordsHighly(t *testing.T) {
+	// Act
+	estimate := password.EstimateStrength("xK9#mQ2vL7!pZ")
+
+	// Assert
+	assert.Equal(t, 4, estimate.Score)
+	assert.Empty(t, estimate.Warning)
+	assert.Empty(t, estimate.Suggestions)
+}
+
+func TestValidatePasswordMinScore(t *testing.T) {
+	// Arrange
+	cfg := password.DefaultPasswordConfig()
+	cfg.Policy.MinScore = 3
+	service := password.NewPasswordService(cfg)
+
+	// Act & Assert
+	assert.ErrorIs(t, service.ValidatePasswordForRole("Qwerty12345", "user"), password.ErrPasswordTooWeak)
+	assert.ErrorIs(t, service.ValidatePasswordForRole("Jonathan1990x", "user", "jonathan"), password.ErrPasswordTooWeak)
+	assert.NoError(t, service.ValidatePasswordForRole("Plum7Gravel9Otter", "user"))
+}
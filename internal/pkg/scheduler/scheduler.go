@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidInterval = errors.New("scheduler: interval must be positive")
+	ErrAlreadyStarted  = errors.New("scheduler: already started")
+)
+
+// metrics is published under /debug/vars as "scheduler", keyed by job name.
+var metrics = expvar.NewMap("scheduler")
+
+// Job is a unit of periodic work. Run returns the number of records it
+// processed so that the scheduler can report it.
+type Job interface {
+	Name() string
+	Run(ctx context.Context) (int64, error)
+}
+
+// Locker guarantees that only one replica runs a given job at a time.
+type Locker interface {
+	TryLock(ctx context.Context, name string) (release func() error, acquired bool, err error)
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+	stats    *jobStats
+}
+
+type jobStats struct {
+	runs         expvar.Int
+	failures     expvar.Int
+	skipped      expvar.Int
+	processed    expvar.Int
+	lastDuration expvar.Int
+	lastRun      expvar.Int
+}
+
+func newJobStats(name string) *jobStats {
+	stats := &jobStats{}
+
+	m := new(expvar.Map).Init()
+	m.Set("runs", &stats.runs)
+	m.Set("failures", &stats.failures)
+	m.Set("skipped", &stats.skipped)
+	m.Set("processed", &stats.processed)
+	m.Set("last_duration_ms", &stats.lastDuration)
+	m.Set("last_run_unix", &stats.lastRun)
+	metrics.Set(name, m)
+
+	return stats
+}
+
+type Scheduler struct {
+	locker  Locker
+	entries []entry
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+func New(locker Locker) *Scheduler {
+	return &Scheduler{locker: locker}
+}
+
+func (s *Scheduler) Register(job Job, interval time.Duration) error {
+	if interval <= 0 {
+		return ErrInvalidInterval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrAlreadyStarted
+	}
+
+	s.entries = append(s.entries, entry{
+		job:      job,
+		interval: interval,
+		stats:    newJobStats(job.Name()),
+	})
+	return nil
+}
+
+// Start launches one goroutine per registered job. Jobs first run one interval
+// after start so that a rolling deploy does not trigger every job at once.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrAlreadyStarted
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.loop(ctx, e)
+	}
+
+	log.Info().Int("jobs", len(s.entries)).Msg("Scheduler started")
+	return nil
+}
+
+// Stop cancels running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	s.wg.Wait()
+	log.Info().Msg("Scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, e)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, e entry) {
+	name := e.job.Name()
+	l := log.With().Str("job", name).Logger()
+
+	release, acquired, err := s.locker.TryLock(ctx, "scheduler:"+name)
+	if err != nil {
+		e.stats.failures.Add(1)
+		l.Error().Err(err).Msg("Scheduled job could not acquire lock")
+		return
+	}
+	if !acquired {
+		e.stats.skipped.Add(1)
+		l.Debug().Msg("Scheduled job skipped, lock held by another instance")
+		return
+	}
+	defer func() {
+		if err := release(); err != nil {
+			l.Error().Err(err).Msg("Scheduled job failed to release lock")
+		}
+	}()
+
+	start := time.Now()
+	processed, err := e.job.Run(ctx)
+	duration := time.Since(start)
+
+	e.stats.runs.Add(1)
+	e.stats.processed.Add(processed)
+	e.stats.lastDuration.Set(duration.Milliseconds())
+	e.stats.lastRun.Set(start.Unix())
+
+	if err != nil {
+		e.stats.failures.Add(1)
+		l.Error().Err(err).
+			Int64("processed", processed).
+			Dur("duration", duration).
+			Msg("Scheduled job failed")
+		return
+	}
+
+	l.Info().
+		Int64("processed", processed).
+		Dur("duration", duration).
+		Msg("Scheduled job completed")
+}
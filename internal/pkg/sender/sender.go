@@ -0,0 +1,162 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"beerdosan-backend/internal/pkg/mailer"
+)
+
+var ErrUnsupportedDriver = errors.New("sender: unsupported driver")
+
+// Sender delivers a short text message, such as a one-time passcode, to an
+// email address or phone number. Implementations must be safe for concurrent
+// use.
+type Sender interface {
+	Send(ctx context.Context, to, message string) error
+}
+
+// EmailSender sends messages as plain text email with a fixed subject.
+type EmailSender struct {
+	mailer  mailer.Mailer
+	subject string
+}
+
+func NewEmailSender(m mailer.Mailer, subject string) *EmailSender {
+	return &EmailSender{mailer: m, subject: subject}
+}
+
+func (s *EmailSender) Send(ctx context.Context, to, message string) error {
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      to,
+		Subject: s.subject,
+		Body:    message,
+	})
+}
+
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	From       string
+}
+
+// TwilioSender sends SMS through the Twilio Messages API.
+type TwilioSender struct {
+	config   TwilioConfig
+	client   *http.Client
+	endpoint string
+}
+
+func NewTwilioSender(config TwilioConfig) *TwilioSender {
+	return &TwilioSender{
+		config:   config,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		endpoint: "https://api.twilio.com/2010-04-01/Accounts/" + url.PathEscape(config.AccountSID) + "/Messages.json",
+	}
+}
+
+func (s *TwilioSender) Send(ctx context.Context, to, message string) error {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", s.config.From)
+	form.Set("Body", message)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.config.AccountSID, s.config.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sender: twilio request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sender: twilio responded %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// Message is a message accepted by a FakeSender.
+type Message struct {
+	To      string
+	Message string
+}
+
+// FakeSender logs messages and keeps them in memory instead of delivering
+// them. It stands in for the email or SMS adapter in development and tests.
+type FakeSender struct {
+	channel string
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFakeEmailSender() *FakeSender {
+	return &FakeSender{channel: "email"}
+}
+
+func NewFakeSMSSender() *FakeSender {
+	return &FakeSender{channel: "sms"}
+}
+
+func (s *FakeSender) Send(_ context.Context, to, message string) error {
+	s.mu.Lock()
+	s.sent = append(s.sent, Message{To: to, Message: message})
+	s.mu.Unlock()
+
+	log.Info().
+		Str("channel", s.channel).
+		Str("to", to).
+		Str("message", message).
+		Msg("sender: message not delivered, fake sender in use")
+	return nil
+}
+
+// Sent returns the messages accepted so far, oldest first.
+func (s *FakeSender) Sent() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.sent...)
+}
+
+// NewEmail returns the email adapter for driver: "fake", or "mailer" to send
+// through m.
+func NewEmail(driver string, m mailer.Mailer, subject string) (Sender, error) {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "", "fake":
+		return NewFakeEmailSender(), nil
+	case "mailer":
+		return NewEmailSender(m, subject), nil
+	default:
+		return nil, ErrUnsupportedDriver
+	}
+}
+
+// NewSMS returns the SMS adapter for driver: "fake" or "twilio".
+func NewSMS(driver string, twilio TwilioConfig) (Sender, error) {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "", "fake":
+		return NewFakeSMSSender(), nil
+	case "twilio":
+		if twilio.AccountSID == "" || twilio.AuthToken == "" || twilio.From == "" {
+			return nil, errors.New("sender: twilio driver needs account_sid, auth_token and from")
+		}
+		return NewTwilioSender(twilio), nil
+	default:
+		return nil, ErrUnsupportedDriver
+	}
+}
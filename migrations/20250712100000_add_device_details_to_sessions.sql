@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Structured device details parsed from the user agent, and an optional
+-- user-chosen session name. Existing rows keep empty values and are parsed
+-- from user_agent when read.
+ALTER TABLE sessions ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN browser VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN browser_version VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN os VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN os_version VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN device_type VARCHAR(20) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN IF EXISTS device_type;
+ALTER TABLE sessions DROP COLUMN IF EXISTS os_version;
+ALTER TABLE sessions DROP COLUMN IF EXISTS os;
+ALTER TABLE sessions DROP COLUMN IF EXISTS browser_version;
+ALTER TABLE sessions DROP COLUMN IF EXISTS browser;
+ALTER TABLE sessions DROP COLUMN IF EXISTS name;
+-- +goose StatementEnd
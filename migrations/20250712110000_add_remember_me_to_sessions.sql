@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Whether the session was created with the long ("remember me") refresh
+-- lifetime, so that token refreshes keep issuing tokens for the same mode.
+ALTER TABLE sessions ADD COLUMN remember_me BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sessions DROP COLUMN IF EXISTS remember_me;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN phone_number VARCHAR(16),
+    ADD COLUMN phone_number_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- A number logs in only one account, so it can be verified by only one.
+CREATE UNIQUE INDEX idx_users_verified_phone_number ON users(phone_number)
+    WHERE phone_number_verified AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_verified_phone_number;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS phone_number_verified,
+    DROP COLUMN IF EXISTS phone_number;
+-- +goose StatementEnd
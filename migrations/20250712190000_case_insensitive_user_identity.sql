@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Usernames are NFKC-normalised and emails NFC-normalised, both lower-cased,
+-- as the application now does on write. Accounts that only differ in case
+-- make this fail and must be merged or renamed first.
+UPDATE users SET
+    username = LOWER(NORMALIZE(TRIM(username), NFKC)),
+    email = LOWER(NORMALIZE(TRIM(email), NFC));
+
+UPDATE login_attempts SET username = LOWER(NORMALIZE(TRIM(username), NFKC));
+UPDATE magic_links SET email = LOWER(NORMALIZE(TRIM(email), NFC));
+UPDATE email_changes SET
+    old_email = LOWER(NORMALIZE(TRIM(old_email), NFC)),
+    new_email = LOWER(NORMALIZE(TRIM(new_email), NFC));
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_username;
+DROP INDEX IF EXISTS idx_users_email;
+
+CREATE UNIQUE INDEX idx_users_username_lower ON users(LOWER(username));
+CREATE UNIQUE INDEX idx_users_email_lower ON users(LOWER(email));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The normalised values are kept.
+DROP INDEX IF EXISTS idx_users_username_lower;
+DROP INDEX IF EXISTS idx_users_email_lower;
+
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+CREATE INDEX idx_users_username ON users(username);
+CREATE INDEX idx_users_email ON users(email);
+-- +goose StatementEnd
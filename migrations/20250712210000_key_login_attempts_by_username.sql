@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Failed logins by email used to be recorded under the email. Record them
+-- under the account's username, as all other attempts are. Attempts for
+-- unknown accounts keep the typed identifier, which may be an email address.
+UPDATE login_attempts la SET username = u.username
+FROM users u
+WHERE la.username = LOWER(u.email);
+
+ALTER TABLE login_attempts ALTER COLUMN username TYPE VARCHAR(255);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The rewritten attempts stay keyed by username.
+ALTER TABLE login_attempts ALTER COLUMN username TYPE VARCHAR(50) USING LEFT(username, 50);
+-- +goose StatementEnd
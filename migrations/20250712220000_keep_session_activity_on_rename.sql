@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sessions are evicted least recently active first, by updated_at. Renaming a
+-- session is not activity, so an update that changes the name leaves
+-- updated_at alone.
+DROP TRIGGER IF EXISTS update_sessions_updated_at ON sessions;
+CREATE TRIGGER update_sessions_updated_at
+    BEFORE UPDATE ON sessions
+    FOR EACH ROW
+    WHEN (OLD.name IS NOT DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_sessions_updated_at ON sessions;
+CREATE TRIGGER update_sessions_updated_at
+    BEFORE UPDATE ON sessions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd